@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+const (
+	alertAction        = "NOTIFY"
+	alertItem          = "LAST"
+	alertItemPctChange = "PCT_CHANGE"
+	alertCmdList       = "list"
+	alertCmdDelete     = "delete"
+	alertCmdChange     = "change"
+
+	// maxAlertsPerOwner limits the alerts a single chat user can create
+	maxAlertsPerOwner = 20
+)
+
+var (
+	errAlertNoOwner     = errors.New("unable to identify the user creating the alert")
+	errAlertNotFound    = errors.New("alert not found")
+	errAlertLimit       = fmt.Errorf("you have reached the limit of %d alerts", maxAlertsPerOwner)
+	errAlertNoExchange  = errors.New("no enabled exchange supports the currency pair")
+	errAlertInvalidArgs = errors.New("invalid alert, examples: alert BTCUSD bitstamp > 10000, " +
+		"alert ETHUSD change 5% 1h, alert list, alert delete 3")
+)
+
+// StartChatAlerts registers the alert command with the communication mediums
+// handling chat commands. Alerts are stored as NOTIFY events owned by the
+// chat user and notify the chat they were created in
+func StartChatAlerts() {
+	if bot.Comms == nil {
+		return
+	}
+
+	bot.Comms.RegisterCommand(base.Command{
+		Name:        "alert",
+		Args:        "<pair> [exchange] <op> <price> | <pair> [exchange] change <pct>% <window> | list | delete <id>",
+		Description: "Manages your price alerts, which are sent to this chat",
+		MinArgs:     1,
+		MaxArgs:     5,
+		Handler:     handleAlertCommand,
+	})
+}
+
+// handleAlertCommand lists, deletes or creates the alerts of the chat user
+func handleAlertCommand(c *base.Commander, req base.CommandRequest) (string, error) {
+	owner := req.Owner()
+	if owner == "" {
+		return "", errAlertNoOwner
+	}
+
+	switch common.StringToLower(req.Args[0]) {
+	case alertCmdList:
+		return listAlerts(owner), nil
+	case alertCmdDelete:
+		if len(req.Args) != 2 {
+			return "", errAlertInvalidArgs
+		}
+		return deleteAlert(owner, req.Args[1])
+	}
+
+	if len(events.GetEventsByOwner(owner)) >= maxAlertsPerOwner {
+		return "", errAlertLimit
+	}
+
+	e, err := parseAlert(req.Args)
+	if err != nil {
+		return "", err
+	}
+
+	e.Owner = owner
+	e.Medium = req.Medium
+	e.Recipient = req.Chat
+	id, err := events.Add(e)
+	if err != nil {
+		return "", err
+	}
+
+	e, _ = events.GetEvent(id)
+	return fmt.Sprintf("alert %d created: %s", id, e.String()), nil
+}
+
+// listAlerts returns the alerts of a chat user
+func listAlerts(owner string) string {
+	alerts := events.GetEventsByOwner(owner)
+	if len(alerts) == 0 {
+		return "you have no alerts"
+	}
+
+	lines := make([]string, len(alerts))
+	for i := range alerts {
+		lines[i] = fmt.Sprintf("%d: %s", alerts[i].ID, alerts[i].String())
+		if alerts[i].Executed {
+			lines[i] += " (triggered)"
+		}
+	}
+	return common.JoinStrings(lines, "\n")
+}
+
+// deleteAlert removes an alert owned by the chat user
+func deleteAlert(owner, arg string) (string, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return "", errAlertNotFound
+	}
+
+	e, ok := events.GetEvent(id)
+	if !ok || e.Owner != owner || !events.RemoveEvent(id) {
+		return "", errAlertNotFound
+	}
+	return fmt.Sprintf("alert %d deleted", id), nil
+}
+
+// parseAlert converts the alert command arguments to an event. The exchange
+// is optional and defaults to the first enabled exchange supporting the pair.
+// A signed percent change alerts on a rise or fall, an unsigned one on either
+func parseAlert(args []string) (events.Event, error) {
+	p, err := parseRESTv2Pair(args[0])
+	if err != nil {
+		return events.Event{}, err
+	}
+
+	args = args[1:]
+	var exchName string
+	if len(args) > 0 && !events.IsValidCondition(args[0]) &&
+		common.StringToLower(args[0]) != alertCmdChange {
+		exchName = args[0]
+		args = args[1:]
+	} else {
+		exchName, err = alertExchange(p)
+		if err != nil {
+			return events.Event{}, err
+		}
+	}
+
+	e := events.Event{
+		Exchange: exchName,
+		Pair:     p,
+		Asset:    ticker.Spot,
+		Item:     alertItem,
+		Action:   alertAction,
+	}
+
+	switch {
+	case len(args) == 2 && events.IsValidCondition(args[0]):
+		if _, err = strconv.ParseFloat(args[1], 64); err != nil {
+			return events.Event{}, errAlertInvalidArgs
+		}
+		e.Condition = args[0] + "," + args[1]
+
+	case len(args) == 3 && common.StringToLower(args[0]) == alertCmdChange:
+		pct := strings.TrimSuffix(args[1], "%")
+		value, err := strconv.ParseFloat(pct, 64)
+		if err != nil || value == 0 {
+			return events.Event{}, errAlertInvalidArgs
+		}
+
+		window, err := time.ParseDuration(args[2])
+		if err != nil {
+			return events.Event{}, errAlertInvalidArgs
+		}
+
+		switch {
+		case strings.HasPrefix(pct, "-"):
+			e.Item, e.Window, e.Condition = alertItemPctChange, window, "<=,"+strconv.FormatFloat(value, 'f', -1, 64)
+		case strings.HasPrefix(pct, "+"):
+			e.Item, e.Window, e.Condition = alertItemPctChange, window, ">=,"+strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			e.Condition = fmt.Sprintf("abs(pct_change(%s:%s_%s.last, %s)) >= %s",
+				strings.Replace(exchName, " ", "_", -1), p.FirstCurrency, p.SecondCurrency,
+				args[2], pct)
+		}
+
+	default:
+		return events.Event{}, errAlertInvalidArgs
+	}
+	return e, nil
+}
+
+// alertExchange returns the first enabled exchange supporting the pair
+func alertExchange(p pair.CurrencyPair) (string, error) {
+	exchanges := GetExchangeNamesByCurrency(bot, p, true)
+	if len(exchanges) == 0 {
+		return "", errAlertNoExchange
+	}
+	return exchanges[0], nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/communications"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// testRecipientComm records the events pushed to recipients
+type testRecipientComm struct {
+	base.Base
+	pushed map[string][]base.Event
+}
+
+func (c *testRecipientComm) Setup(cfg config.CommunicationsConfig) {}
+func (c *testRecipientComm) Connect() error                        { return nil }
+func (c *testRecipientComm) PushEvent(base.Event) error            { return nil }
+
+func (c *testRecipientComm) PushEventTo(recipient string, event base.Event) error {
+	c.pushed[recipient] = append(c.pushed[recipient], event)
+	return nil
+}
+
+func testAlertRequest(chat, user string, args ...string) base.CommandRequest {
+	return base.CommandRequest{
+		Name:   "alert",
+		Args:   args,
+		Medium: "Telegram",
+		Chat:   chat,
+		Users:  []string{user},
+	}
+}
+
+func TestChatAlerts(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig("testdata/configtest.json")
+	if err != nil {
+		t.Fatalf("Test failed. LoadConfig: %s", err)
+	}
+
+	backup := bot.Config
+	bot.Config = cfg
+	events.LoadEvents(nil)
+	defer func() {
+		bot.Config = backup
+		events.LoadEvents(nil)
+		events.SetComms(nil)
+	}()
+
+	comm := &testRecipientComm{
+		Base:   base.Base{Name: "Telegram", Enabled: true, Connected: true},
+		pushed: make(map[string][]base.Event),
+	}
+	events.SetComms(&communications.Communications{IComm: base.IComm{comm}})
+
+	reply, err := handleAlertCommand(nil, testAlertRequest("100", "1", "BTCUSD", "bitstamp", ">", "10000"))
+	if err != nil || !strings.HasPrefix(reply, "alert 0 created") {
+		t.Fatalf("Test failed. handleAlertCommand create %q %v", reply, err)
+	}
+
+	reply, err = handleAlertCommand(nil, testAlertRequest("100", "1", "BTCUSD", "change", "5%", "1h"))
+	if err != nil || !strings.Contains(reply, "abs(pct_change(") {
+		t.Fatalf("Test failed. handleAlertCommand change %q %v", reply, err)
+	}
+
+	_, err = handleAlertCommand(nil, testAlertRequest("200", "2", "BTCUSD", "bitstamp", "change", "-5%", "1h"))
+	if err != nil {
+		t.Fatalf("Test failed. handleAlertCommand signed change %v", err)
+	}
+
+	e, _ := events.GetEvent(2)
+	if e.Item != alertItemPctChange || e.Condition != "<=,-5" || e.Owner != "telegram:2" ||
+		e.Medium != "Telegram" || e.Recipient != "200" {
+		t.Errorf("Test failed. Unexpected alert event %v", e)
+	}
+
+	for _, args := range [][]string{
+		{"BTCUSD", "bitstamp", "above", "10000"},
+		{"BTCUSD", "bitstamp", ">", "lots"},
+		{"BTCUSD", "change", "5%"},
+		{"BTC"},
+	} {
+		if _, err = handleAlertCommand(nil, testAlertRequest("100", "1", args...)); err == nil {
+			t.Errorf("Test failed. handleAlertCommand accepted %v", args)
+		}
+	}
+
+	reply, _ = handleAlertCommand(nil, testAlertRequest("100", "1", "list"))
+	if !strings.HasPrefix(reply, "0: If the BTCUSD [SPOT] last on Bitstamp is > 10000") ||
+		strings.Contains(reply, "\n2:") {
+		t.Errorf("Test failed. handleAlertCommand list %q", reply)
+	}
+
+	if _, err = handleAlertCommand(nil, testAlertRequest("100", "1", "delete", "2")); err != errAlertNotFound {
+		t.Error("Test failed. handleAlertCommand deleted an alert of another user")
+	}
+
+	reply, err = handleAlertCommand(nil, testAlertRequest("200", "2", "delete", "2"))
+	if err != nil || reply != "alert 2 deleted" {
+		t.Errorf("Test failed. handleAlertCommand delete %q %v", reply, err)
+	}
+
+	events.ProcessTicker("Bitstamp", ticker.Spot, ticker.Price{
+		Pair: pair.NewCurrencyPair("BTC", "USD"),
+		Last: 10001,
+	})
+
+	if len(comm.pushed["100"]) != 1 || comm.pushed["100"][0].Type != "ALERT" ||
+		len(comm.pushed) != 1 {
+		t.Errorf("Test failed. Alert should only notify the chat it was created in %v", comm.pushed)
+	}
+
+	reply, _ = handleAlertCommand(nil, testAlertRequest("100", "1", "list"))
+	if !strings.Contains(reply, "(triggered)") {
+		t.Errorf("Test failed. handleAlertCommand list should show triggered alerts %q", reply)
+	}
+
+	cfgs := events.GetEventConfigs()
+	events.LoadEvents(cfgs)
+	if len(events.GetEventsByOwner("telegram:1")) != 2 {
+		t.Error("Test failed. Alerts should be restored from the config")
+	}
+
+	e, _ = events.GetEvent(1)
+	e.ExecuteAction(1)
+	pushed := comm.pushed["100"]
+	if len(pushed) != 2 || pushed[1].Exchange != e.Exchange || e.Exchange == "" ||
+		pushed[1].Pair != "BTCUSD" {
+		t.Errorf("Test failed. Change alert notification should carry its exchange and pair %v", pushed)
+	}
+}
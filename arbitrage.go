@@ -0,0 +1,502 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/translation"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the arbitrage monitor
+const (
+	arbitrageFeedBufferSize = 4096
+	arbitrageEventType      = "ARBITRAGE"
+	arbitrageQueryStatus    = "status"
+	arbitrageStatusOpen     = "open"
+	arbitrageStatusClosed   = "closed"
+)
+
+var arbitrageMonitor *ArbitrageMonitor
+
+// ArbitrageQuote holds the best bid and ask of an exchange currency pair
+// converted to the market quote currency
+type ArbitrageQuote struct {
+	Exchange    string    `json:"exchange"`
+	Pair        string    `json:"pair"`
+	Bid         float64   `json:"bid"`
+	Ask         float64   `json:"ask"`
+	TakerFee    float64   `json:"takerFee"`
+	LastUpdated time.Time `json:"lastUpdated"`
+
+	currencyPair pair.CurrencyPair
+	// rate converts prices in the pair quote currency to the market quote
+	// currency
+	rate float64
+}
+
+// ArbitrageSpread holds the best cross-exchange spread of a market, buying at
+// the ask of one exchange and selling at the bid of another. Spreads are
+// percentages, NetSpread is after taker fees. Size is the base currency
+// amount which can be traded at a profit according to the orderbooks and
+// Profit the estimated profit of that amount in the quote currency
+type ArbitrageSpread struct {
+	Market      string         `json:"market"`
+	Buy         ArbitrageQuote `json:"buy"`
+	Sell        ArbitrageQuote `json:"sell"`
+	GrossSpread float64        `json:"grossSpread"`
+	NetSpread   float64        `json:"netSpread"`
+	Size        float64        `json:"size"`
+	Profit      float64        `json:"profit"`
+	Updated     time.Time      `json:"updated"`
+}
+
+// ArbitrageOpportunity is a spread which reached the threshold, it remains
+// open while the spread stays over the threshold between the same exchanges.
+// Duration is in seconds
+type ArbitrageOpportunity struct {
+	ID int `json:"id"`
+	ArbitrageSpread
+	MaxNetSpread float64    `json:"maxNetSpread"`
+	Opened       time.Time  `json:"opened"`
+	Closed       *time.Time `json:"closed,omitempty"`
+	Duration     float64    `json:"duration"`
+	Status       string     `json:"status"`
+}
+
+// ArbitrageMonitor tracks the best spread of each market and records the
+// opportunities over the threshold
+type ArbitrageMonitor struct {
+	config.ArbitrageConfig
+	spreads map[string]ArbitrageSpread
+	open    map[string]*ArbitrageOpportunity
+	history []ArbitrageOpportunity
+	nextID  int
+	notify  func(message string)
+	mtx     sync.Mutex
+}
+
+// NewArbitrageMonitor returns an arbitrage monitor, notify is called with a
+// message when an opportunity is opened or closed
+func NewArbitrageMonitor(cfg config.ArbitrageConfig, notify func(message string)) *ArbitrageMonitor {
+	return &ArbitrageMonitor{
+		ArbitrageConfig: cfg,
+		spreads:         make(map[string]ArbitrageSpread),
+		open:            make(map[string]*ArbitrageOpportunity),
+		nextID:          1,
+		notify:          notify,
+	}
+}
+
+// StartArbitrageMonitor evaluates the arbitrage spread of each spot ticker
+// update
+func StartArbitrageMonitor() {
+	if !bot.Config.Arbitrage.Enabled {
+		return
+	}
+
+	arbitrageMonitor = NewArbitrageMonitor(bot.Config.Arbitrage, func(message string) {
+		log.Println(message)
+		if bot.Config.Arbitrage.Notify && bot.Comms != nil {
+			bot.Comms.PushEvent(base.Event{
+				Type:         arbitrageEventType,
+				TradeDetails: message,
+				Severity:     base.SeverityInfo,
+			})
+		}
+	})
+	go arbitrageDispatcher(SubscribeFeed(arbitrageFeedBufferSize))
+	log.Printf("Arbitrage: monitor started, threshold %v%% in %s.",
+		bot.Config.Arbitrage.Threshold, bot.Config.Arbitrage.QuoteCurrency)
+}
+
+// arbitrageDispatcher evaluates the market of each spot ticker update
+func arbitrageDispatcher(feed chan WebsocketEvent) {
+	for evt := range feed {
+		t, ok := evt.Data.(ticker.Price)
+		if !ok || evt.AssetType != ticker.Spot {
+			continue
+		}
+		arbitrageMonitor.Evaluate(t.Pair, time.Now())
+	}
+}
+
+// canonicalCurrency returns the same currency for translated currencies such
+// as BTC and XBT
+func canonicalCurrency(c pair.CurrencyItem) pair.CurrencyItem {
+	c = c.Upper()
+	t, err := translation.GetTranslation(c)
+	if err == nil && !currency.IsFiatCurrency(t.String()) && t < c {
+		return t
+	}
+	return c
+}
+
+// Market returns the market of a currency pair, fiat quoted pairs share the
+// market of the monitor quote currency
+func (m *ArbitrageMonitor) Market(p pair.CurrencyPair) string {
+	quote := p.SecondCurrency.Upper().String()
+	if currency.IsFiatCurrency(quote) {
+		quote = m.QuoteCurrency
+	}
+	return canonicalCurrency(p.FirstCurrency).String() + "/" + quote
+}
+
+// candidatePairs returns the currency pairs quoted in the market of the pair
+func (m *ArbitrageMonitor) candidatePairs(p pair.CurrencyPair) []pair.CurrencyPair {
+	bases := []string{p.FirstCurrency.Upper().String()}
+	t, err := translation.GetTranslation(p.FirstCurrency.Upper())
+	if err == nil && !currency.IsFiatCurrency(t.String()) {
+		bases = append(bases, t.String())
+	}
+
+	quotes := []string{p.SecondCurrency.Upper().String()}
+	if currency.IsFiatCurrency(quotes[0]) && currency.HasExchangeRates() {
+		for _, x := range currency.FiatCurrencies {
+			if !common.StringDataCompare(quotes, x) {
+				quotes = append(quotes, x)
+			}
+		}
+	}
+
+	var pairs []pair.CurrencyPair
+	for x := range bases {
+		for y := range quotes {
+			pairs = append(pairs, pair.NewCurrencyPair(bases[x], quotes[y]))
+		}
+	}
+	return pairs
+}
+
+// takerFee returns the taker fee of an exchange, defaulting to the configured
+// taker fee
+func (m *ArbitrageMonitor) takerFee(exchangeName string) float64 {
+	exch := GetExchangeByName(bot, exchangeName)
+	if exch != nil && exch.GetTakerFee() > 0 {
+		return exch.GetTakerFee()
+	}
+	return m.DefaultTakerFee
+}
+
+// Quotes returns the fresh quotes of the enabled exchanges in the market of
+// the currency pair
+func (m *ArbitrageMonitor) Quotes(p pair.CurrencyPair, now time.Time) []ArbitrageQuote {
+	var quotes []ArbitrageQuote
+	for _, cp := range m.candidatePairs(p) {
+		rate := 1.0
+		if quote := cp.SecondCurrency.String(); quote != m.QuoteCurrency &&
+			currency.IsFiatCurrency(quote) {
+			if !currency.HasExchangeRates() {
+				continue
+			}
+
+			var err error
+			rate, err = currency.ConvertCurrency(1, quote, m.QuoteCurrency)
+			if err != nil || rate == 0 {
+				continue
+			}
+		}
+
+		for _, exchName := range GetExchangeNamesByCurrency(bot, cp, true) {
+			t, err := ticker.GetTicker(exchName, cp, ticker.Spot)
+			if err != nil || t.Bid == 0 || t.Ask == 0 ||
+				now.Sub(t.LastUpdated) > m.MaxTickerAge {
+				continue
+			}
+
+			quotes = append(quotes, ArbitrageQuote{
+				Exchange:     exchName,
+				Pair:         cp.Pair().String(),
+				Bid:          t.Bid * rate,
+				Ask:          t.Ask * rate,
+				TakerFee:     m.takerFee(exchName),
+				LastUpdated:  t.LastUpdated,
+				currencyPair: cp,
+				rate:         rate,
+			})
+		}
+	}
+	return quotes
+}
+
+// afterFees returns the ask price paid and the bid price received after the
+// taker fee
+func afterFees(price, takerFee float64, buy bool) float64 {
+	if buy {
+		return price * (1 + takerFee/100)
+	}
+	return price * (1 - takerFee/100)
+}
+
+// BestArbitrageSpread returns the best spread buying on one exchange and
+// selling on another
+func BestArbitrageSpread(market string, quotes []ArbitrageQuote) (ArbitrageSpread, bool) {
+	var best ArbitrageSpread
+	var found bool
+	for x := range quotes {
+		for y := range quotes {
+			if quotes[x].Exchange == quotes[y].Exchange {
+				continue
+			}
+
+			ask := afterFees(quotes[x].Ask, quotes[x].TakerFee, true)
+			bid := afterFees(quotes[y].Bid, quotes[y].TakerFee, false)
+			net := (bid - ask) / ask * 100
+			if found && net <= best.NetSpread {
+				continue
+			}
+
+			best = ArbitrageSpread{
+				Market:      market,
+				Buy:         quotes[x],
+				Sell:        quotes[y],
+				GrossSpread: (quotes[y].Bid - quotes[x].Ask) / quotes[x].Ask * 100,
+				NetSpread:   net,
+			}
+			found = true
+		}
+	}
+	return best, found
+}
+
+// ArbitrageSize returns the base currency amount which can be bought from the
+// asks of the buy exchange and sold to the bids of the sell exchange at a
+// profit after fees, and the estimated profit in the market quote currency
+func ArbitrageSize(buy, sell ArbitrageQuote, asks, bids []orderbook.Item) (size, profit float64) {
+	asks = append([]orderbook.Item(nil), asks...)
+	bids = append([]orderbook.Item(nil), bids...)
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+
+	var x, y int
+	var askUsed, bidUsed float64
+	for x < len(asks) && y < len(bids) {
+		ask := afterFees(asks[x].Price*buy.rate, buy.TakerFee, true)
+		bid := afterFees(bids[y].Price*sell.rate, sell.TakerFee, false)
+		if bid <= ask {
+			break
+		}
+
+		amount := asks[x].Amount - askUsed
+		if remaining := bids[y].Amount - bidUsed; remaining < amount {
+			amount = remaining
+		}
+
+		size += amount
+		profit += amount * (bid - ask)
+		askUsed += amount
+		bidUsed += amount
+
+		if askUsed >= asks[x].Amount {
+			x++
+			askUsed = 0
+		}
+
+		if bidUsed >= bids[y].Amount {
+			y++
+			bidUsed = 0
+		}
+	}
+	return size, profit
+}
+
+// Evaluate computes the best spread of the market of the currency pair and
+// records it
+func (m *ArbitrageMonitor) Evaluate(p pair.CurrencyPair, now time.Time) {
+	market := m.Market(p)
+	spread, ok := BestArbitrageSpread(market, m.Quotes(p, now))
+	if !ok {
+		m.Remove(market, now)
+		return
+	}
+
+	spread.Updated = now
+	if spread.NetSpread > 0 {
+		buyBook, err := orderbook.GetOrderbook(spread.Buy.Exchange,
+			spread.Buy.currencyPair, orderbook.Spot)
+		if err == nil {
+			sellBook, err := orderbook.GetOrderbook(spread.Sell.Exchange,
+				spread.Sell.currencyPair, orderbook.Spot)
+			if err == nil {
+				spread.Size, spread.Profit = ArbitrageSize(spread.Buy, spread.Sell,
+					buyBook.Asks, sellBook.Bids)
+			}
+		}
+	}
+	m.Update(spread)
+}
+
+// closeOpportunity closes the open opportunity of a market and returns its
+// notification message. The caller must hold the lock
+func (m *ArbitrageMonitor) closeOpportunity(market string, now time.Time) string {
+	o, ok := m.open[market]
+	if !ok {
+		return ""
+	}
+
+	delete(m.open, market)
+	o.Closed = &now
+	o.Duration = now.Sub(o.Opened).Seconds()
+	o.Status = arbitrageStatusClosed
+
+	m.history = append(m.history, *o)
+	if len(m.history) > m.HistorySize {
+		m.history = m.history[len(m.history)-m.HistorySize:]
+	}
+
+	return fmt.Sprintf("Arbitrage opportunity %d closed: %s buy %s sell %s, max net spread %.2f%% over %v.",
+		o.ID, market, o.Buy.Exchange, o.Sell.Exchange, o.MaxNetSpread,
+		now.Sub(o.Opened).Round(time.Second))
+}
+
+// Update records the best spread of a market, opening an opportunity when the
+// net spread reaches the threshold and closing it when the spread falls below
+// the threshold or moves to other exchanges
+func (m *ArbitrageMonitor) Update(s ArbitrageSpread) {
+	var messages []string
+	m.mtx.Lock()
+	m.spreads[s.Market] = s
+
+	exceeds := s.NetSpread >= m.Threshold
+	o, ok := m.open[s.Market]
+	if ok && (!exceeds || o.Buy.Exchange != s.Buy.Exchange ||
+		o.Sell.Exchange != s.Sell.Exchange) {
+		messages = append(messages, m.closeOpportunity(s.Market, s.Updated))
+		ok = false
+	}
+
+	if exceeds {
+		if !ok {
+			o = &ArbitrageOpportunity{
+				ID:     m.nextID,
+				Opened: s.Updated,
+				Status: arbitrageStatusOpen,
+			}
+			m.nextID++
+			m.open[s.Market] = o
+			messages = append(messages, fmt.Sprintf(
+				"Arbitrage opportunity %d opened: %s buy %s at %f on %s, sell %s at %f on %s, net spread %.2f%%, size %f.",
+				o.ID, s.Market, s.Buy.Pair, s.Buy.Ask, s.Buy.Exchange, s.Sell.Pair,
+				s.Sell.Bid, s.Sell.Exchange, s.NetSpread, s.Size))
+		}
+
+		o.ArbitrageSpread = s
+		o.Duration = s.Updated.Sub(o.Opened).Seconds()
+		if s.NetSpread > o.MaxNetSpread {
+			o.MaxNetSpread = s.NetSpread
+		}
+	}
+	m.mtx.Unlock()
+
+	m.sendNotifications(messages)
+}
+
+// Remove drops the spread of a market which can no longer be computed and
+// closes its open opportunity
+func (m *ArbitrageMonitor) Remove(market string, now time.Time) {
+	m.mtx.Lock()
+	delete(m.spreads, market)
+	message := m.closeOpportunity(market, now)
+	m.mtx.Unlock()
+
+	m.sendNotifications([]string{message})
+}
+
+func (m *ArbitrageMonitor) sendNotifications(messages []string) {
+	if m.notify == nil {
+		return
+	}
+
+	for x := range messages {
+		if messages[x] != "" {
+			m.notify(messages[x])
+		}
+	}
+}
+
+// Spreads returns the best spread of each market, highest net spread first
+func (m *ArbitrageMonitor) Spreads() []ArbitrageSpread {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	spreads := make([]ArbitrageSpread, 0, len(m.spreads))
+	for _, s := range m.spreads {
+		spreads = append(spreads, s)
+	}
+
+	sort.Slice(spreads, func(i, j int) bool {
+		return spreads[i].NetSpread > spreads[j].NetSpread
+	})
+	return spreads
+}
+
+// Opportunities returns the open and closed opportunities, newest first
+func (m *ArbitrageMonitor) Opportunities() []ArbitrageOpportunity {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	result := make([]ArbitrageOpportunity, 0, len(m.open)+len(m.history))
+	for _, o := range m.open {
+		result = append(result, *o)
+	}
+	result = append(result, m.history...)
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID > result[j].ID
+	})
+	return result
+}
+
+// RESTv2GetArbitrageSpreads returns the best cross-exchange spread of each
+// market
+func RESTv2GetArbitrageSpreads(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	spreads := []ArbitrageSpread{}
+	if arbitrageMonitor != nil {
+		spreads = arbitrageMonitor.Spreads()
+	}
+
+	restV2List(w, r, query, len(spreads), func(start, end int) interface{} {
+		return spreads[start:end]
+	})
+}
+
+// RESTv2GetArbitrageOpportunities returns the arbitrage opportunities filtered
+// by status
+func RESTv2GetArbitrageOpportunities(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	status := common.StringToLower(r.URL.Query().Get(arbitrageQueryStatus))
+	opportunities := []ArbitrageOpportunity{}
+	if arbitrageMonitor != nil {
+		for _, o := range arbitrageMonitor.Opportunities() {
+			if status != "" && o.Status != status {
+				continue
+			}
+			opportunities = append(opportunities, o)
+		}
+	}
+
+	restV2List(w, r, query, len(opportunities), func(start, end int) interface{} {
+		return opportunities[start:end]
+	})
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func TestBestArbitrageSpread(t *testing.T) {
+	quotes := []ArbitrageQuote{
+		{Exchange: "A", Bid: 100, Ask: 101, TakerFee: 0.1, rate: 1},
+		{Exchange: "B", Bid: 103, Ask: 104, TakerFee: 0.1, rate: 1},
+		{Exchange: "C", Bid: 102, Ask: 102.5, TakerFee: 2, rate: 1},
+	}
+
+	if _, ok := BestArbitrageSpread("BTC/USD", quotes[:1]); ok {
+		t.Error("Test failed. BestArbitrageSpread requires two exchanges")
+	}
+
+	spread, ok := BestArbitrageSpread("BTC/USD", quotes)
+	if !ok || spread.Buy.Exchange != "A" || spread.Sell.Exchange != "B" {
+		t.Fatalf("Test failed. BestArbitrageSpread unexpected result %v", spread)
+	}
+
+	net := (103*0.999 - 101*1.001) / (101 * 1.001) * 100
+	if math.Abs(spread.NetSpread-net) > 1e-9 ||
+		math.Abs(spread.GrossSpread-(2.0/101*100)) > 1e-9 {
+		t.Errorf("Test failed. BestArbitrageSpread unexpected spreads %v %v",
+			spread.GrossSpread, spread.NetSpread)
+	}
+}
+
+func TestArbitrageSize(t *testing.T) {
+	buy := ArbitrageQuote{Exchange: "A", rate: 1}
+	sell := ArbitrageQuote{Exchange: "B", rate: 1}
+	asks := []orderbook.Item{{Price: 102, Amount: 1}, {Price: 100, Amount: 1}, {Price: 104, Amount: 5}}
+	bids := []orderbook.Item{{Price: 103, Amount: 1.5}, {Price: 101, Amount: 2}}
+
+	size, profit := ArbitrageSize(buy, sell, asks, bids)
+	// 1 at 100 sold at 103, 0.5 at 102 sold at 103
+	if size != 1.5 || profit != 3.5 {
+		t.Errorf("Test failed. ArbitrageSize returned %v %v", size, profit)
+	}
+
+	buy.TakerFee = 1
+	sell.TakerFee = 1
+	size, _ = ArbitrageSize(buy, sell, asks, bids)
+	if size != 1 {
+		t.Errorf("Test failed. ArbitrageSize should account for fees, returned %v", size)
+	}
+}
+
+func TestArbitrageMonitorUpdate(t *testing.T) {
+	var messages []string
+	m := NewArbitrageMonitor(config.ArbitrageConfig{Threshold: 1, HistorySize: 1},
+		func(message string) {
+			messages = append(messages, message)
+		})
+
+	start := time.Now()
+	spread := ArbitrageSpread{
+		Market:    "BTC/USD",
+		Buy:       ArbitrageQuote{Exchange: "A"},
+		Sell:      ArbitrageQuote{Exchange: "B"},
+		NetSpread: 0.5,
+		Updated:   start,
+	}
+	m.Update(spread)
+	if len(m.Opportunities()) != 0 || len(m.Spreads()) != 1 {
+		t.Fatal("Test failed. Spread under the threshold recorded as an opportunity")
+	}
+
+	spread.NetSpread = 2
+	spread.Updated = start.Add(time.Second)
+	m.Update(spread)
+
+	spread.NetSpread = 1.5
+	spread.Updated = start.Add(time.Second * 5)
+	m.Update(spread)
+
+	opportunities := m.Opportunities()
+	if len(opportunities) != 1 || opportunities[0].Status != arbitrageStatusOpen ||
+		opportunities[0].MaxNetSpread != 2 || opportunities[0].Duration != 4 {
+		t.Fatalf("Test failed. Unexpected open opportunity %v", opportunities)
+	}
+
+	spread.Sell.Exchange = "C"
+	spread.Updated = start.Add(time.Second * 6)
+	m.Update(spread)
+
+	m.Remove("BTC/USD", start.Add(time.Second*10))
+	opportunities = m.Opportunities()
+	if len(opportunities) != 1 || opportunities[0].ID != 2 ||
+		opportunities[0].Status != arbitrageStatusClosed || opportunities[0].Duration != 4 {
+		t.Errorf("Test failed. Unexpected closed opportunities %v", opportunities)
+	}
+
+	if len(messages) != 4 || len(m.Spreads()) != 0 {
+		t.Errorf("Test failed. Expected 4 notifications got %d", len(messages))
+	}
+}
+
+func TestArbitrageMonitorEvaluate(t *testing.T) {
+	cfg := config.GetConfig()
+	err := cfg.LoadConfig("testdata/configtest.json")
+	if err != nil {
+		t.Fatalf("Test failed. LoadConfig: %s", err)
+	}
+
+	backupConfig, backupFiat := bot.Config, currency.FiatCurrencies
+	bot.Config = cfg
+	currency.FiatCurrencies = []string{"USD"}
+	defer func() {
+		bot.Config, currency.FiatCurrencies = backupConfig, backupFiat
+		arbitrageMonitor = nil
+	}()
+
+	now := time.Now()
+	prices := map[string]ticker.Price{
+		"Bitfinex": {Pair: pair.NewCurrencyPair("BTC", "USD"), Bid: 6600, Ask: 6601},
+		"GDAX":     {Pair: pair.NewCurrencyPair("BTC", "USD"), Bid: 6500, Ask: 6510},
+		"Kraken":   {Pair: pair.NewCurrencyPair("XBT", "USD"), Bid: 6700, Ask: 6710},
+	}
+
+	for exch, price := range prices {
+		ticker.ProcessTicker(exch, price.Pair, price, ticker.Spot)
+	}
+
+	orderbook.ProcessOrderbook("GDAX", prices["GDAX"].Pair, orderbook.Base{
+		Asks: []orderbook.Item{{Price: 6510, Amount: 0.5}, {Price: 6800, Amount: 1}},
+	}, orderbook.Spot)
+	orderbook.ProcessOrderbook("Kraken", prices["Kraken"].Pair, orderbook.Base{
+		Bids: []orderbook.Item{{Price: 6700, Amount: 2}},
+	}, orderbook.Spot)
+
+	arbitrageMonitor = NewArbitrageMonitor(config.ArbitrageConfig{
+		Threshold:       1,
+		DefaultTakerFee: 0.2,
+		QuoteCurrency:   "USD",
+		MaxTickerAge:    time.Minute,
+		HistorySize:     10,
+	}, nil)
+	arbitrageMonitor.Evaluate(pair.NewCurrencyPair("XBT", "USD"), now)
+
+	spreads := arbitrageMonitor.Spreads()
+	if len(spreads) != 1 || spreads[0].Market != "BTC/USD" ||
+		spreads[0].Buy.Exchange != "GDAX" || spreads[0].Sell.Exchange != "Kraken" ||
+		spreads[0].Size != 0.5 {
+		t.Fatalf("Test failed. Unexpected spreads %v", spreads)
+	}
+
+	w := httptest.NewRecorder()
+	RESTv2GetArbitrageOpportunities(w, httptest.NewRequest("GET",
+		"/v2/arbitrage/opportunities?status=open", nil))
+
+	var resp struct {
+		Data       []ArbitrageOpportunity
+		Pagination RESTv2Pagination
+	}
+	err = json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil || resp.Pagination.Total != 1 || resp.Data[0].Buy.Pair != "BTCUSD" ||
+		resp.Data[0].Sell.Pair != "XBTUSD" {
+		t.Errorf("Test failed. RESTv2GetArbitrageOpportunities unexpected result %v %v", resp, err)
+	}
+
+	arbitrageMonitor.Evaluate(pair.NewCurrencyPair("BTC", "USD"), now.Add(time.Hour))
+	if len(arbitrageMonitor.Spreads()) != 0 {
+		t.Error("Test failed. Stale tickers should not be compared")
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+// Const vars for the webserver auth layer
+const (
+	authSessionTokenLength = 32
+	authRateLimitWindow    = time.Minute
+)
+
+// Vars for the webserver auth layer
+var (
+	errAuthTokenMissing       = errors.New("missing bearer token")
+	errAuthTokenInvalid       = errors.New("invalid or expired token")
+	errAuthScopeDenied        = errors.New("token does not have the required scope")
+	errAuthRateLimited        = errors.New("rate limit exceeded")
+	errAuthInvalidCredentials = errors.New("invalid username/password")
+	errAuthLockedOut          = errors.New("too many failed login attempts, try again later")
+
+	authSessions    = make(map[string]*AuthIdentity)
+	authRateLimits  = make(map[string]*authRateLimit)
+	authLoginFailed = make(map[string]*authLoginAttempts)
+	authMtx         sync.Mutex
+)
+
+// AuthIdentity is an authenticated API token or session token
+type AuthIdentity struct {
+	Name      string
+	Scopes    []string
+	RateLimit int
+	Expires   time.Time
+	token     string
+}
+
+type authRateLimit struct {
+	windowStart time.Time
+	requests    int
+}
+
+type authLoginAttempts struct {
+	failures    int
+	lockedUntil time.Time
+}
+
+// AuthLoginRequest is the request body used to obtain a session token
+type AuthLoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// AuthSessionResponse holds an issued session token and its expiry
+type AuthSessionResponse struct {
+	Token   string    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+// HasScope returns whether or not the identity has the supplied scope, the
+// admin scope grants every scope
+func (a *AuthIdentity) HasScope(scope string) bool {
+	for x := range a.Scopes {
+		if a.Scopes[x] == scope || a.Scopes[x] == config.APIScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns whether or not the identity is a session which has expired
+func (a *AuthIdentity) IsExpired() bool {
+	return !a.Expires.IsZero() && time.Now().After(a.Expires)
+}
+
+// IsAuthScopeRequired returns whether or not requests for the supplied scope
+// must be authenticated. Market data is public unless RequireMarketDataAuth
+// is set
+func IsAuthScopeRequired(scope string) bool {
+	if scope == "" {
+		return false
+	}
+
+	if scope == config.APIScopeReadMarket {
+		return bot.Config.Webserver.RequireMarketDataAuth
+	}
+	return true
+}
+
+// secureCompare compares two secrets in constant time
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// AuthenticateToken returns the identity of a configured API token or an
+// unexpired session token
+func AuthenticateToken(token string) (*AuthIdentity, error) {
+	if token == "" {
+		return nil, errAuthTokenMissing
+	}
+
+	apiTokens := bot.Config.Webserver.APITokens
+	for x := range apiTokens {
+		if !apiTokens[x].Enabled || !secureCompare(apiTokens[x].Token, token) {
+			continue
+		}
+
+		rateLimit := apiTokens[x].RateLimit
+		if rateLimit <= 0 {
+			rateLimit = bot.Config.Webserver.APIRateLimit
+		}
+
+		var scopes []string
+		for _, scope := range common.SplitStrings(apiTokens[x].Scopes, ",") {
+			scopes = append(scopes, common.StringToLower(scope))
+		}
+
+		return &AuthIdentity{
+			Name:      apiTokens[x].Name,
+			Scopes:    scopes,
+			RateLimit: rateLimit,
+			token:     token,
+		}, nil
+	}
+
+	authMtx.Lock()
+	defer authMtx.Unlock()
+
+	session, ok := authSessions[token]
+	if !ok {
+		return nil, errAuthTokenInvalid
+	}
+
+	if session.IsExpired() {
+		delete(authSessions, token)
+		delete(authRateLimits, token)
+		return nil, errAuthTokenInvalid
+	}
+	return session, nil
+}
+
+// NewSessionToken issues an expiring admin session token
+func NewSessionToken() (*AuthIdentity, error) {
+	random, err := common.GetRandomSalt(nil, authSessionTokenLength)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &AuthIdentity{
+		Name:      bot.Config.Webserver.AdminUsername,
+		Scopes:    []string{config.APIScopeAdmin},
+		RateLimit: bot.Config.Webserver.APIRateLimit,
+		Expires:   time.Now().Add(bot.Config.Webserver.SessionTokenExpiry),
+		token:     common.HexEncodeToString(random),
+	}
+
+	authMtx.Lock()
+	defer authMtx.Unlock()
+
+	for token, s := range authSessions {
+		if s.IsExpired() {
+			delete(authSessions, token)
+			delete(authRateLimits, token)
+		}
+	}
+	authSessions[session.token] = session
+	return session, nil
+}
+
+// CheckRateLimit records a request for the identity and returns the time
+// until the next request is allowed if its rate limit has been exceeded
+func CheckRateLimit(identity *AuthIdentity) (time.Duration, bool) {
+	authMtx.Lock()
+	defer authMtx.Unlock()
+
+	limit, ok := authRateLimits[identity.token]
+	if !ok || time.Since(limit.windowStart) >= authRateLimitWindow {
+		limit = &authRateLimit{windowStart: time.Now()}
+		authRateLimits[identity.token] = limit
+	}
+
+	if limit.requests >= identity.RateLimit {
+		return authRateLimitWindow - time.Since(limit.windowStart), false
+	}
+	limit.requests++
+	return 0, true
+}
+
+// CheckLogin validates the admin username and SHA256 hex encoded password,
+// locking out the remote address after too many failed attempts
+func CheckLogin(remoteAddr, username, passwordHash string) error {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	authMtx.Lock()
+	defer authMtx.Unlock()
+
+	attempts, ok := authLoginFailed[host]
+	if ok && time.Now().Before(attempts.lockedUntil) {
+		return errAuthLockedOut
+	}
+
+	hashPW := common.HexEncodeToString(common.GetSHA256([]byte(bot.Config.Webserver.AdminPassword)))
+	if secureCompare(username, bot.Config.Webserver.AdminUsername) &&
+		secureCompare(passwordHash, hashPW) {
+		delete(authLoginFailed, host)
+		return nil
+	}
+
+	if !ok {
+		attempts = &authLoginAttempts{}
+		authLoginFailed[host] = attempts
+	}
+
+	attempts.failures++
+	if attempts.failures >= bot.Config.Webserver.LoginMaxFailures {
+		log.Printf("Auth: locking out %s for %v after %d failed login attempts",
+			host, bot.Config.Webserver.LoginLockoutDuration, attempts.failures)
+		attempts.failures = 0
+		attempts.lockedUntil = time.Now().Add(bot.Config.Webserver.LoginLockoutDuration)
+	}
+	return errAuthInvalidCredentials
+}
+
+// bearerToken returns the token from an Authorization header value
+func bearerToken(header string) string {
+	if len(header) > 7 && common.StringToLower(header[:7]) == "bearer " {
+		return header[7:]
+	}
+	return ""
+}
+
+// RESTAuth wraps a handler, requiring a token with the supplied scope and
+// enforcing the token rate limit
+func RESTAuth(inner http.Handler, scope string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsAuthScopeRequired(scope) {
+			inner.ServeHTTP(w, r)
+			return
+		}
+
+		identity, err := AuthenticateToken(bearerToken(r.Header.Get("Authorization")))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			RESTv2ErrorJSONResponse(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		if !identity.HasScope(scope) {
+			RESTv2ErrorJSONResponse(w, r, http.StatusForbidden, errAuthScopeDenied)
+			return
+		}
+
+		retryAfter, ok := CheckRateLimit(identity)
+		if !ok {
+			w.Header().Set("Retry-After",
+				strconv.Itoa(int(retryAfter.Seconds())+1))
+			RESTv2ErrorJSONResponse(w, r, http.StatusTooManyRequests,
+				errAuthRateLimited)
+			return
+		}
+		inner.ServeHTTP(w, r)
+	})
+}
+
+// RESTLogin validates the admin credentials and returns an expiring session
+// token usable as a bearer token or for websocket authentication
+func RESTLogin(w http.ResponseWriter, r *http.Request) {
+	var req AuthLoginRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	err = CheckLogin(r.RemoteAddr, req.Username, req.Password)
+	if err != nil {
+		statusCode := http.StatusUnauthorized
+		if err == errAuthLockedOut {
+			statusCode = http.StatusTooManyRequests
+		}
+		RESTv2ErrorJSONResponse(w, r, statusCode, err)
+		return
+	}
+
+	session, err := NewSessionToken()
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	RESTv2JSONResponse(w, r, http.StatusOK, AuthSessionResponse{
+		Token:   session.token,
+		Expires: session.Expires,
+	})
+}
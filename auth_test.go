@@ -0,0 +1,152 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+func setupAuthTestConfig() {
+	bot.Config = &config.Config{}
+	bot.Config.Webserver = config.WebserverConfig{
+		AdminUsername:        "admin",
+		AdminPassword:        "Password",
+		SessionTokenExpiry:   time.Hour,
+		APIRateLimit:         2,
+		LoginMaxFailures:     2,
+		LoginLockoutDuration: time.Minute,
+		APITokens: []config.APITokenConfig{
+			{Name: "market", Enabled: true, Token: "markettoken", Scopes: "read-market"},
+			{Name: "disabled", Enabled: false, Token: "disabledtoken", Scopes: "admin"},
+		},
+	}
+}
+
+func TestAuthenticateToken(t *testing.T) {
+	setupAuthTestConfig()
+
+	identity, err := AuthenticateToken("markettoken")
+	if err != nil {
+		t.Fatalf("Test failed. AuthenticateToken: %s", err)
+	}
+
+	if !identity.HasScope(config.APIScopeReadMarket) ||
+		identity.HasScope(config.APIScopeReadPortfolio) {
+		t.Error("Test failed. AuthenticateToken returned incorrect scopes")
+	}
+
+	for _, token := range []string{"", "disabledtoken", "invalid"} {
+		_, err = AuthenticateToken(token)
+		if err == nil {
+			t.Errorf("Test failed. AuthenticateToken %q should have thrown an error", token)
+		}
+	}
+
+	session, err := NewSessionToken()
+	if err != nil {
+		t.Fatalf("Test failed. NewSessionToken: %s", err)
+	}
+
+	identity, err = AuthenticateToken(session.token)
+	if err != nil || !identity.HasScope(config.APIScopeReadPortfolio) {
+		t.Error("Test failed. AuthenticateToken session token should have the admin scope")
+	}
+
+	session.Expires = time.Now().Add(-time.Second)
+	_, err = AuthenticateToken(session.token)
+	if err != errAuthTokenInvalid {
+		t.Error("Test failed. AuthenticateToken should reject an expired session token")
+	}
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	setupAuthTestConfig()
+
+	identity := &AuthIdentity{RateLimit: 2, token: "ratelimit"}
+	for i := 0; i < 2; i++ {
+		if _, ok := CheckRateLimit(identity); !ok {
+			t.Fatalf("Test failed. CheckRateLimit request %d should be allowed", i)
+		}
+	}
+
+	retryAfter, ok := CheckRateLimit(identity)
+	if ok || retryAfter <= 0 {
+		t.Error("Test failed. CheckRateLimit should have limited the request")
+	}
+}
+
+func TestCheckLogin(t *testing.T) {
+	setupAuthTestConfig()
+
+	hashPW := common.HexEncodeToString(common.GetSHA256([]byte("Password")))
+	err := CheckLogin("127.0.0.1:1337", "admin", hashPW)
+	if err != nil {
+		t.Fatalf("Test failed. CheckLogin: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = CheckLogin("127.0.0.2:1337", "admin", "wrong")
+		if err != errAuthInvalidCredentials {
+			t.Fatalf("Test failed. CheckLogin should have returned invalid credentials")
+		}
+	}
+
+	err = CheckLogin("127.0.0.2:1338", "admin", hashPW)
+	if err != errAuthLockedOut {
+		t.Error("Test failed. CheckLogin should have locked out the remote address")
+	}
+
+	err = CheckLogin("127.0.0.3:1337", "admin", hashPW)
+	if err != nil {
+		t.Error("Test failed. CheckLogin should not lock out other addresses")
+	}
+}
+
+func TestRESTAuth(t *testing.T) {
+	setupAuthTestConfig()
+	bot.Config.Webserver.RequireMarketDataAuth = true
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		scope    string
+		token    string
+		expected int
+	}{
+		{"", "", http.StatusOK},
+		{config.APIScopeReadMarket, "", http.StatusUnauthorized},
+		{config.APIScopeReadMarket, "invalid", http.StatusUnauthorized},
+		{config.APIScopeReadPortfolio, "markettoken", http.StatusForbidden},
+		{config.APIScopeReadMarket, "markettoken", http.StatusOK},
+		{config.APIScopeReadMarket, "markettoken", http.StatusOK},
+		{config.APIScopeReadMarket, "markettoken", http.StatusTooManyRequests},
+	}
+
+	for i := range tests {
+		r := httptest.NewRequest("GET", "/exchanges/enabled/latest/all", nil)
+		if tests[i].token != "" {
+			r.Header.Set("Authorization", "Bearer "+tests[i].token)
+		}
+
+		w := httptest.NewRecorder()
+		RESTAuth(inner, tests[i].scope).ServeHTTP(w, r)
+		if w.Code != tests[i].expected {
+			t.Errorf("Test failed. RESTAuth test %d returned %d expected %d",
+				i, w.Code, tests[i].expected)
+		}
+	}
+
+	bot.Config.Webserver.RequireMarketDataAuth = false
+	w := httptest.NewRecorder()
+	RESTAuth(inner, config.APIScopeReadMarket).ServeHTTP(w,
+		httptest.NewRequest("GET", "/exchanges/enabled/latest/all", nil))
+	if w.Code != http.StatusOK {
+		t.Error("Test failed. RESTAuth market data should be public by default")
+	}
+}
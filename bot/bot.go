@@ -1,10 +1,13 @@
 package bot
 
 import (
+	"github.com/trustfeed/go-crypto-pricefeeder/cache"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications"
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges"
 	"github.com/trustfeed/go-crypto-pricefeeder/portfolio"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks"
+	"github.com/trustfeed/go-crypto-pricefeeder/webhooks"
 )
 
 // Bot contains configuration, portfolio, exchange & ticker data and is the
@@ -14,7 +17,10 @@ type Bot struct {
 	Portfolio  *portfolio.Base
 	Exchanges  []exchange.IBotExchange
 	Comms      *communications.Communications
+	Sinks      *sinks.Sinks
+	Webhooks   *webhooks.Webhooks
+	Cache      *cache.Cache
 	Shutdown   chan bool
 	DryRun     bool
 	ConfigFile string
-}
\ No newline at end of file
+}
@@ -0,0 +1,392 @@
+package cache
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the Redis key layout. Keys are {prefix}:ticker:{exchange}:
+// {assetType}:{pair}, {prefix}:reference:{pair} and {prefix}:fx, each key
+// doubles as the pub/sub channel its changes are published on
+const (
+	KeyTicker    = "ticker"
+	KeyReference = "reference"
+	KeyFXRates   = "fx"
+
+	scanCount = "100"
+)
+
+// TickerEntry is a ticker mirrored into a Redis hash. Updated is the last
+// updated time of the ticker in Unix milliseconds
+type TickerEntry struct {
+	Exchange  string  `json:"exchange"`
+	AssetType string  `json:"assetType"`
+	Pair      string  `json:"pair"`
+	Base      string  `json:"base"`
+	Quote     string  `json:"quote"`
+	Delimiter string  `json:"delimiter,omitempty"`
+	Last      float64 `json:"last"`
+	High      float64 `json:"high"`
+	Low       float64 `json:"low"`
+	Bid       float64 `json:"bid"`
+	Ask       float64 `json:"ask"`
+	Volume    float64 `json:"volume"`
+	PriceATH  float64 `json:"priceATH"`
+	Updated   int64   `json:"updated"`
+}
+
+// ReferencePriceEntry is a reference price mirrored into a Redis hash, Sources
+// holds the JSON encoded tickers the price was calculated from
+type ReferencePriceEntry struct {
+	Pair    string          `json:"pair"`
+	Price   float64         `json:"price"`
+	Sources json.RawMessage `json:"sources,omitempty"`
+	Updated int64           `json:"updated"`
+}
+
+// FXRatesEntry is the FX rates change notification
+type FXRatesEntry struct {
+	Rates   map[string]float64 `json:"rates"`
+	Updated int64              `json:"updated"`
+}
+
+// Cache mirrors the ticker store, reference prices and FX rates into Redis
+// and publishes their changes
+type Cache struct {
+	config.RedisConfig
+	client    *Client
+	published map[string]string
+	mtx       sync.Mutex
+}
+
+// New returns a Redis cache for the supplied config, the connection is
+// established by the first command
+func New(cfg config.RedisConfig) *Cache {
+	return &Cache{
+		RedisConfig: cfg,
+		client:      NewClient(cfg.Address, cfg.Password, cfg.Database),
+		published:   make(map[string]string),
+	}
+}
+
+// unixMillis returns the time in Unix milliseconds
+func unixMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// fromUnixMillis returns the time of a Unix milliseconds timestamp
+func fromUnixMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
+
+// formatFloat formats a hash field price
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// pairKey returns the upper case currency pair without a delimiter
+func pairKey(p pair.CurrencyPair) string {
+	return p.FirstCurrency.Upper().String() + p.SecondCurrency.Upper().String()
+}
+
+// Key returns a key under the key prefix
+func (c *Cache) Key(parts ...string) string {
+	return c.KeyPrefix + ":" + strings.Join(parts, ":")
+}
+
+// TickerKey returns the key of an exchange ticker
+func (c *Cache) TickerKey(exchangeName, assetType string, p pair.CurrencyPair) string {
+	return c.Key(KeyTicker, common.StringToLower(exchangeName),
+		common.StringToLower(assetType), pairKey(p))
+}
+
+// Ping checks the connection to the Redis server
+func (c *Cache) Ping() error {
+	_, err := c.client.Do("PING")
+	return err
+}
+
+// set writes the hash fields, sets the key TTL and publishes the payload when
+// the supplied state differs from the last published state of the key
+func (c *Cache) set(key string, fields []string, ttl time.Duration, state string, payload interface{}) error {
+	cmds := [][]string{
+		append([]string{"HSET", key}, fields...),
+		{"PEXPIRE", key, strconv.FormatInt(int64(ttl/time.Millisecond), 10)},
+	}
+
+	c.mtx.Lock()
+	changed := c.published[key] != state
+	c.mtx.Unlock()
+
+	if changed {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		cmds = append(cmds, []string{"PUBLISH", key, string(data)})
+	}
+
+	replies, err := c.client.Pipeline(cmds)
+	if err != nil {
+		return err
+	}
+
+	for x := range replies {
+		if err, ok := replies[x].(Error); ok {
+			return err
+		}
+	}
+
+	if changed {
+		c.mtx.Lock()
+		c.published[key] = state
+		c.mtx.Unlock()
+	}
+	return nil
+}
+
+// NewTickerEntry returns the cache entry of an exchange ticker
+func NewTickerEntry(exchangeName, assetType string, t ticker.Price) TickerEntry {
+	return TickerEntry{
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Pair:      t.Pair.Pair().String(),
+		Base:      t.Pair.FirstCurrency.String(),
+		Quote:     t.Pair.SecondCurrency.String(),
+		Delimiter: t.Pair.Delimiter,
+		Last:      t.Last,
+		High:      t.High,
+		Low:       t.Low,
+		Bid:       t.Bid,
+		Ask:       t.Ask,
+		Volume:    t.Volume,
+		PriceATH:  t.PriceATH,
+		Updated:   unixMillis(t.LastUpdated),
+	}
+}
+
+// CurrencyPair returns the currency pair of the entry
+func (e *TickerEntry) CurrencyPair() pair.CurrencyPair {
+	return pair.CurrencyPair{
+		Delimiter:      e.Delimiter,
+		FirstCurrency:  pair.CurrencyItem(e.Base),
+		SecondCurrency: pair.CurrencyItem(e.Quote),
+	}
+}
+
+// Price returns the entry as a ticker price
+func (e *TickerEntry) Price() ticker.Price {
+	p := e.CurrencyPair()
+	return ticker.Price{
+		Pair:         p,
+		CurrencyPair: p.Pair().String(),
+		Last:         e.Last,
+		High:         e.High,
+		Low:          e.Low,
+		Bid:          e.Bid,
+		Ask:          e.Ask,
+		Volume:       e.Volume,
+		PriceATH:     e.PriceATH,
+		LastUpdated:  fromUnixMillis(e.Updated),
+	}
+}
+
+// prices returns the price fields which trigger a change notification
+func (e *TickerEntry) prices() []string {
+	return []string{
+		"last", formatFloat(e.Last),
+		"high", formatFloat(e.High),
+		"low", formatFloat(e.Low),
+		"bid", formatFloat(e.Bid),
+		"ask", formatFloat(e.Ask),
+		"volume", formatFloat(e.Volume),
+		"priceATH", formatFloat(e.PriceATH),
+	}
+}
+
+// SetTicker mirrors an exchange ticker, publishing it when its prices changed
+func (c *Cache) SetTicker(e TickerEntry) error {
+	prices := e.prices()
+	fields := append([]string{
+		"exchange", e.Exchange,
+		"assetType", e.AssetType,
+		"pair", e.Pair,
+		"base", e.Base,
+		"quote", e.Quote,
+		"delimiter", e.Delimiter,
+		"updated", strconv.FormatInt(e.Updated, 10),
+	}, prices...)
+
+	return c.set(c.TickerKey(e.Exchange, e.AssetType, e.CurrencyPair()), fields,
+		c.TickerTTL, strings.Join(prices, ","), e)
+}
+
+// parseTickerEntry parses a ticker hash
+func parseTickerEntry(fields map[string]string) (TickerEntry, error) {
+	e := TickerEntry{
+		Exchange:  fields["exchange"],
+		AssetType: fields["assetType"],
+		Pair:      fields["pair"],
+		Base:      fields["base"],
+		Quote:     fields["quote"],
+		Delimiter: fields["delimiter"],
+	}
+
+	if e.Exchange == "" || e.Base == "" || e.Quote == "" {
+		return e, errInvalidReply
+	}
+
+	var err error
+	values := []*float64{&e.Last, &e.High, &e.Low, &e.Bid, &e.Ask, &e.Volume, &e.PriceATH}
+	names := []string{"last", "high", "low", "bid", "ask", "volume", "priceATH"}
+	for x := range names {
+		*values[x], err = strconv.ParseFloat(fields[names[x]], 64)
+		if err != nil {
+			return e, err
+		}
+	}
+
+	e.Updated, err = strconv.ParseInt(fields["updated"], 10, 64)
+	return e, err
+}
+
+// Tickers returns the mirrored tickers which have not expired
+func (c *Cache) Tickers() ([]TickerEntry, error) {
+	keys, err := c.scan(c.Key(KeyTicker, "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([][]string, len(keys))
+	for x := range keys {
+		cmds[x] = []string{"HGETALL", keys[x]}
+	}
+
+	replies, err := c.client.Pipeline(cmds)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TickerEntry
+	for x := range replies {
+		fields, err := toStringMap(replies[x])
+		if err != nil || len(fields) == 0 {
+			// Expired between the scan and the read
+			continue
+		}
+
+		e, err := parseTickerEntry(fields)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// scan returns the keys matching the pattern
+func (c *Cache) scan(pattern string) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := c.client.Do("SCAN", cursor, "MATCH", pattern, "COUNT", scanCount)
+		if err != nil {
+			return nil, err
+		}
+
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 2 {
+			return nil, errInvalidReply
+		}
+
+		next, ok := values[0].([]byte)
+		if !ok {
+			return nil, errInvalidReply
+		}
+
+		batch, err := toStrings(values[1])
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, batch...)
+		cursor = string(next)
+		if cursor == "0" {
+			return keys, nil
+		}
+	}
+}
+
+// SetReferencePrice mirrors a reference price, publishing it when the price
+// changed
+func (c *Cache) SetReferencePrice(e ReferencePriceEntry) error {
+	price := formatFloat(e.Price)
+	fields := []string{
+		"pair", e.Pair,
+		"price", price,
+		"sources", string(e.Sources),
+		"updated", strconv.FormatInt(e.Updated, 10),
+	}
+
+	return c.set(c.Key(KeyReference, e.Pair), fields, c.TickerTTL, price, e)
+}
+
+// SetFXRates mirrors the FX rates, publishing them when a rate changed
+func (c *Cache) SetFXRates(rates map[string]float64) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	var fields []string
+	for currency, rate := range rates {
+		fields = append(fields, currency, formatFloat(rate))
+	}
+
+	state, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+
+	return c.set(c.Key(KeyFXRates), fields, c.FXRatesTTL, string(state),
+		FXRatesEntry{Rates: rates, Updated: unixMillis(time.Now())})
+}
+
+// FXRates returns the mirrored FX rates, which are empty once expired
+func (c *Cache) FXRates() (map[string]float64, error) {
+	reply, err := c.client.Do("HGETALL", c.Key(KeyFXRates))
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := toStringMap(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64, len(fields))
+	for currency, value := range fields {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		rates[currency] = rate
+	}
+	return rates, nil
+}
+
+// Close closes the Redis connection
+func (c *Cache) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,287 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// fakeServer is an in-memory Redis server supporting the commands used by the
+// cache
+type fakeServer struct {
+	listener  net.Listener
+	password  string
+	hashes    map[string]map[string]string
+	ttls      map[string]int64
+	published map[string][]string
+	mtx       sync.Mutex
+}
+
+func newFakeServer(t *testing.T, password string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeServer{
+		listener:  listener,
+		password:  password,
+		hashes:    make(map[string]map[string]string),
+		ttls:      make(map[string]int64),
+		published: make(map[string][]string),
+	}
+
+	go func() {
+		for {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	authenticated := s.password == ""
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+
+		args, err := toStrings(reply)
+		if err != nil || len(args) == 0 {
+			return
+		}
+
+		cmd := strings.ToUpper(args[0])
+		if !authenticated && cmd != "AUTH" {
+			fmt.Fprint(c, "-NOAUTH Authentication required.\r\n")
+			continue
+		}
+
+		s.mtx.Lock()
+		switch cmd {
+		case "AUTH":
+			if args[1] != s.password {
+				fmt.Fprint(c, "-WRONGPASS invalid password\r\n")
+				break
+			}
+			authenticated = true
+			fmt.Fprint(c, "+OK\r\n")
+		case "PING":
+			fmt.Fprint(c, "+PONG\r\n")
+		case "SELECT":
+			fmt.Fprint(c, "+OK\r\n")
+		case "HSET":
+			if s.hashes[args[1]] == nil {
+				s.hashes[args[1]] = make(map[string]string)
+			}
+
+			for x := 2; x+1 < len(args); x += 2 {
+				s.hashes[args[1]][args[x]] = args[x+1]
+			}
+			fmt.Fprintf(c, ":%d\r\n", (len(args)-2)/2)
+		case "PEXPIRE":
+			ttl, _ := strconv.ParseInt(args[2], 10, 64)
+			s.ttls[args[1]] = ttl
+			fmt.Fprint(c, ":1\r\n")
+		case "PUBLISH":
+			s.published[args[1]] = append(s.published[args[1]], args[2])
+			fmt.Fprint(c, ":0\r\n")
+		case "HGETALL":
+			hash := s.hashes[args[1]]
+			fmt.Fprintf(c, "*%d\r\n", len(hash)*2)
+			for field, value := range hash {
+				fmt.Fprintf(c, "$%d\r\n%s\r\n$%d\r\n%s\r\n", len(field), field, len(value), value)
+			}
+		case "SCAN":
+			prefix := strings.TrimSuffix(args[3], "*")
+			var keys []string
+			for key := range s.hashes {
+				if strings.HasPrefix(key, prefix) {
+					keys = append(keys, key)
+				}
+			}
+
+			fmt.Fprintf(c, "*2\r\n$1\r\n0\r\n*%d\r\n", len(keys))
+			for x := range keys {
+				fmt.Fprintf(c, "$%d\r\n%s\r\n", len(keys[x]), keys[x])
+			}
+		default:
+			fmt.Fprintf(c, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.mtx.Unlock()
+	}
+}
+
+func testCache(s *fakeServer) *Cache {
+	return New(config.RedisConfig{
+		Address:    s.listener.Addr().String(),
+		Password:   s.password,
+		Database:   1,
+		KeyPrefix:  "test",
+		TickerTTL:  time.Minute,
+		FXRatesTTL: time.Hour,
+	})
+}
+
+func TestClient(t *testing.T) {
+	s := newFakeServer(t, "secret")
+	defer s.listener.Close()
+
+	c := NewClient(s.listener.Addr().String(), "wrong", 0)
+	if _, err := c.Do("PING"); err == nil {
+		t.Error("test failed - Do() should fail with an invalid password")
+	}
+
+	c = NewClient(s.listener.Addr().String(), "secret", 0)
+	reply, err := c.Do("PING")
+	if err != nil || reply != "PONG" {
+		t.Errorf("test failed - Do() returned %v %v", reply, err)
+	}
+
+	_, err = c.Do("FLUSHALL")
+	if _, ok := err.(Error); !ok {
+		t.Errorf("test failed - Do() should return the error reply, got %v", err)
+	}
+
+	c.Close()
+	if _, err = c.Do("PING"); err != nil {
+		t.Errorf("test failed - Do() should reconnect after Close(), got %s", err)
+	}
+	c.Close()
+}
+
+func TestTickers(t *testing.T) {
+	s := newFakeServer(t, "")
+	defer s.listener.Close()
+	c := testCache(s)
+	defer c.Close()
+
+	lastUpdated := time.Now().Add(-time.Second).Round(time.Millisecond)
+	p := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	price := ticker.Price{Pair: p, Last: 6500.5, Bid: 6500, Ask: 6501, LastUpdated: lastUpdated}
+
+	for x := 0; x < 2; x++ {
+		err := c.SetTicker(NewTickerEntry("Bitfinex", ticker.Spot, price))
+		if err != nil {
+			t.Fatalf("test failed - SetTicker() error %s", err)
+		}
+	}
+
+	key := "test:ticker:bitfinex:spot:BTCUSD"
+	s.mtx.Lock()
+	published := len(s.published[key])
+	ttl := s.ttls[key]
+	s.mtx.Unlock()
+	if published != 1 || ttl != 60000 {
+		t.Errorf("test failed - expected one notification and a 60000ms TTL, got %d %d",
+			published, ttl)
+	}
+
+	price.Last = 6502
+	err := c.SetTicker(NewTickerEntry("Bitfinex", ticker.Spot, price))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.mtx.Lock()
+	var notification TickerEntry
+	err = json.Unmarshal([]byte(s.published[key][len(s.published[key])-1]), &notification)
+	s.mtx.Unlock()
+	if err != nil || notification.Last != 6502 || notification.Exchange != "Bitfinex" {
+		t.Errorf("test failed - unexpected change notification %v %v", notification, err)
+	}
+
+	entries, err := c.Tickers()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("test failed - Tickers() returned %v %v", entries, err)
+	}
+
+	restored := entries[0].Price()
+	if restored.Pair.Pair() != p.Pair() || restored.Last != 6502 || restored.Ask != 6501 ||
+		!restored.LastUpdated.Equal(lastUpdated) || entries[0].AssetType != ticker.Spot {
+		t.Errorf("test failed - unexpected restored ticker %v", restored)
+	}
+}
+
+func TestReferencePrice(t *testing.T) {
+	s := newFakeServer(t, "")
+	defer s.listener.Close()
+	c := testCache(s)
+	defer c.Close()
+
+	err := c.SetReferencePrice(ReferencePriceEntry{
+		Pair:    "BTCUSD",
+		Price:   6500,
+		Sources: json.RawMessage(`[{"exchange":"Bitfinex"}]`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	hash := s.hashes["test:reference:BTCUSD"]
+	if hash["price"] != "6500" || len(s.published["test:reference:BTCUSD"]) != 1 {
+		t.Errorf("test failed - unexpected reference price hash %v", hash)
+	}
+}
+
+func TestFXRates(t *testing.T) {
+	s := newFakeServer(t, "")
+	defer s.listener.Close()
+	c := testCache(s)
+	defer c.Close()
+
+	rates := map[string]float64{"USDAUD": 1.35, "USDEUR": 0.86}
+	err := c.SetFXRates(rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.SetFXRates(rates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.mtx.Lock()
+	published := len(s.published["test:fx"])
+	ttl := s.ttls["test:fx"]
+	s.mtx.Unlock()
+	if published != 1 || ttl != 3600000 {
+		t.Errorf("test failed - expected one notification and a 3600000ms TTL, got %d %d",
+			published, ttl)
+	}
+
+	restored, err := c.FXRates()
+	if err != nil || len(restored) != 2 || restored["USDAUD"] != 1.35 {
+		t.Errorf("test failed - FXRates() returned %v %v", restored, err)
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	c := New(config.RedisConfig{Address: "127.0.0.1:1", KeyPrefix: "test", TickerTTL: time.Minute})
+	if c.Ping() == nil {
+		t.Error("test failed - Ping() should fail without a server")
+	}
+
+	if c.SetFXRates(map[string]float64{"USDAUD": 1.35}) == nil {
+		t.Error("test failed - SetFXRates() should fail without a server")
+	}
+}
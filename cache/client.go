@@ -0,0 +1,272 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Const vars for the Redis client
+const (
+	requestTimeout = time.Second * 5
+	maxBulkLength  = 512 << 20
+)
+
+var errInvalidReply = errors.New("redis: invalid reply")
+
+// Error is an error reply returned by the Redis server
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client is a minimal Redis client which pipelines commands over a single
+// connection using the RESP protocol. The connection is redialled on the next
+// command after a network error
+type Client struct {
+	Address  string
+	Password string
+	Database int
+
+	conn   net.Conn
+	reader *bufio.Reader
+	mtx    sync.Mutex
+}
+
+// NewClient returns a client for the supplied server address
+func NewClient(address, password string, database int) *Client {
+	return &Client{Address: address, Password: password, Database: database}
+}
+
+// connect dials the server, authenticates and selects the database. The
+// caller must hold the lock
+func (c *Client) connect() error {
+	conn, err := net.DialTimeout("tcp", c.Address, requestTimeout)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	var cmds [][]string
+	if c.Password != "" {
+		cmds = append(cmds, []string{"AUTH", c.Password})
+	}
+
+	if c.Database != 0 {
+		cmds = append(cmds, []string{"SELECT", strconv.Itoa(c.Database)})
+	}
+
+	if len(cmds) == 0 {
+		cmds = append(cmds, []string{"PING"})
+	}
+
+	replies, err := c.roundTrip(cmds)
+	if err != nil {
+		return err
+	}
+
+	for x := range replies {
+		if err, ok := replies[x].(Error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// close closes the connection. The caller must hold the lock
+func (c *Client) close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// Do sends a single command and returns its reply
+func (c *Client) Do(args ...string) (interface{}, error) {
+	replies, err := c.Pipeline([][]string{args})
+	if err != nil {
+		return nil, err
+	}
+
+	if err, ok := replies[0].(Error); ok {
+		return nil, err
+	}
+	return replies[0], nil
+}
+
+// Pipeline sends the commands in a single write and returns their replies in
+// order. Error replies are returned as Error values
+func (c *Client) Pipeline(cmds [][]string) ([]interface{}, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.conn == nil {
+		err := c.connect()
+		if err != nil {
+			c.close()
+			return nil, err
+		}
+	}
+
+	replies, err := c.roundTrip(cmds)
+	if err != nil {
+		c.close()
+		return nil, err
+	}
+	return replies, nil
+}
+
+// roundTrip writes the commands and reads a reply for each. The caller must
+// hold the lock
+func (c *Client) roundTrip(cmds [][]string) ([]interface{}, error) {
+	err := c.conn.SetDeadline(time.Now().Add(requestTimeout))
+	if err != nil {
+		return nil, err
+	}
+
+	w := bufio.NewWriter(c.conn)
+	for x := range cmds {
+		writeCommand(w, cmds[x])
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return nil, err
+	}
+
+	replies := make([]interface{}, len(cmds))
+	for x := range cmds {
+		replies[x], err = readReply(c.reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return replies, nil
+}
+
+// Close closes the server connection
+func (c *Client) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.close()
+	return nil
+}
+
+// writeCommand writes a command as a RESP array of bulk strings
+func writeCommand(w *bufio.Writer, args []string) {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for x := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(args[x]), args[x])
+	}
+}
+
+// readLine reads a RESP line without the trailing CRLF
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return "", errInvalidReply
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply reads a RESP reply. Simple strings are returned as string, errors
+// as Error, integers as int64, bulk strings as []byte (nil when null) and
+// arrays as []interface{}
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return Error(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length > maxBulkLength {
+			return nil, errInvalidReply
+		}
+
+		if length < 0 {
+			return []byte(nil), nil
+		}
+
+		data := make([]byte, length+2)
+		_, err = io.ReadFull(r, data)
+		if err != nil {
+			return nil, err
+		}
+		return data[:length], nil
+	case '*':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, errInvalidReply
+		}
+
+		if length < 0 {
+			return []interface{}(nil), nil
+		}
+
+		values := make([]interface{}, length)
+		for x := range values {
+			values[x], err = readReply(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, errInvalidReply
+	}
+}
+
+// toStrings converts an array reply of bulk strings
+func toStrings(reply interface{}) ([]string, error) {
+	values, ok := reply.([]interface{})
+	if !ok {
+		return nil, errInvalidReply
+	}
+
+	result := make([]string, len(values))
+	for x := range values {
+		b, ok := values[x].([]byte)
+		if !ok {
+			return nil, errInvalidReply
+		}
+		result[x] = string(b)
+	}
+	return result, nil
+}
+
+// toStringMap converts an HGETALL array reply of field and value pairs
+func toStringMap(reply interface{}) (map[string]string, error) {
+	values, err := toStrings(reply)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values)%2 != 0 {
+		return nil, errInvalidReply
+	}
+
+	result := make(map[string]string, len(values)/2)
+	for x := 0; x < len(values); x += 2 {
+		result[values[x]] = values[x+1]
+	}
+	return result, nil
+}
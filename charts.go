@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/charts"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the price charts
+const (
+	chartCommand = "chart"
+)
+
+var (
+	errChartNoData = errors.New("no price history found for currency pair")
+	errChartPeriod = errors.New("invalid period, use a duration such as 30m, 6h or 1d")
+)
+
+// StartCharts registers the chart command and attaches charts to alerts if
+// enabled
+func StartCharts() {
+	if !bot.Config.Charts.Enabled {
+		return
+	}
+
+	startMarketHistory()
+	if bot.Comms == nil {
+		return
+	}
+
+	bot.Comms.RegisterCommand(base.Command{
+		Name:         chartCommand,
+		Args:         "<pair> [exchange] [period]",
+		Description:  "Displays a price chart of a currency pair, across exchanges unless one is supplied",
+		MinArgs:      1,
+		MaxArgs:      3,
+		ReplyHandler: cmdChart,
+	})
+
+	if bot.Config.Charts.AlertCharts {
+		bot.Comms.SetImageRenderer(renderEventChart)
+	}
+	log.Printf("Charts: price charts enabled, %s of history kept.", bot.Config.Charts.History)
+}
+
+// cmdChart replies with a price chart of a currency pair on an exchange, or
+// every exchange trading it
+func cmdChart(c *base.Commander, req base.CommandRequest) (base.Reply, error) {
+	period := bot.Config.Charts.Period
+	var exchangeName string
+	for _, arg := range req.Args[1:] {
+		if d, err := parseChartPeriod(arg); err == nil {
+			period = d
+			continue
+		}
+
+		if exchangeName != "" {
+			return base.Reply{}, errChartPeriod
+		}
+		exchangeName = arg
+	}
+
+	if period > bot.Config.Charts.History {
+		return base.Reply{}, fmt.Errorf("period exceeds the %s of price history kept",
+			formatChartPeriod(bot.Config.Charts.History))
+	}
+
+	chart, err := buildChart(req.Args[0], exchangeName, period, time.Now())
+	if err != nil {
+		return base.Reply{}, err
+	}
+
+	image, err := chart.PNG()
+	if err != nil {
+		return base.Reply{}, err
+	}
+	return base.Reply{Text: chart.Title, Image: image}, nil
+}
+
+// renderEventChart returns a chart of the exchange currency pair of an
+// event, or nil if the event has no market or price history
+func renderEventChart(event base.Event) []byte {
+	if event.Exchange == "" || event.Pair == "" {
+		return nil
+	}
+
+	chart, err := buildChart(event.Pair, event.Exchange, bot.Config.Charts.Period, time.Now())
+	if err != nil {
+		return nil
+	}
+
+	image, err := chart.PNG()
+	if err != nil {
+		log.Printf("Charts: failed to render %s %s chart. Err: %s", event.Exchange, event.Pair, err)
+		return nil
+	}
+	return image
+}
+
+// buildChart returns a chart of the spot price history of a currency pair.
+// Charts of a single exchange use the configured style and volume, charts
+// across exchanges overlay the price of each exchange as a line
+func buildChart(p, exchangeName string, period time.Duration, now time.Time) (*charts.Chart, error) {
+	if marketHistory == nil {
+		return nil, errChartNoData
+	}
+
+	interval := period / time.Duration(bot.Config.Charts.Candles)
+	if interval%time.Minute != 0 {
+		interval = interval.Truncate(time.Minute) + time.Minute
+	}
+
+	series := marketHistory.Candles(p, exchangeName, ticker.Spot, now.Add(-period), now, interval)
+	if len(series) == 0 {
+		return nil, errChartNoData
+	}
+
+	chart := &charts.Chart{
+		Style:  charts.StyleLine,
+		Width:  bot.Config.Charts.Width,
+		Height: bot.Config.Charts.Height,
+		Series: series,
+	}
+
+	title := []string{strings.ToUpper(p), formatChartPeriod(period)}
+	if exchangeName != "" {
+		title = append([]string{series[0].Name}, title...)
+		chart.Style = bot.Config.Charts.Style
+		chart.Volume = bot.Config.Charts.Volume
+	}
+	chart.Title = strings.Join(title, " ")
+	return chart, nil
+}
+
+// parseChartPeriod parses a duration, also accepting a number of days such
+// as 1d
+func parseChartPeriod(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || days <= 0 {
+			return 0, errChartPeriod
+		}
+		return time.Duration(days) * time.Hour * 24, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Minute {
+		return 0, errChartPeriod
+	}
+	return d, nil
+}
+
+// formatChartPeriod formats a period in whole days, hours or minutes
+func formatChartPeriod(d time.Duration) string {
+	switch {
+	case d%(time.Hour*24) == 0:
+		return fmt.Sprintf("%dd", d/(time.Hour*24))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
@@ -0,0 +1,387 @@
+// Package charts renders candlestick and line price charts to PNG images
+// without external dependencies, for attaching to chat notifications
+package charts
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"time"
+)
+
+// Chart styles
+const (
+	StyleCandlestick = "candlestick"
+	StyleLine        = "line"
+)
+
+// Chart defaults and limits in pixels
+const (
+	DefaultWidth  = 640
+	DefaultHeight = 360
+	MinWidth      = 200
+	MinHeight     = 120
+	MaxWidth      = 2000
+	MaxHeight     = 2000
+
+	margin        = 6
+	gridLines     = 5
+	timeLabels    = 4
+	volumeDivisor = 5 // the volume panel is a fifth of the plot height
+)
+
+var (
+	errNoData       = errors.New("chart has no data")
+	errInvalidSize  = fmt.Errorf("chart size must be between %dx%d and %dx%d", MinWidth, MinHeight, MaxWidth, MaxHeight)
+	errInvalidStyle = errors.New("unsupported chart style")
+)
+
+// Chart colours
+var (
+	colourBackground = color.RGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	colourGrid       = color.RGBA{0xE6, 0xE6, 0xE6, 0xFF}
+	colourText       = color.RGBA{0x4D, 0x4D, 0x4D, 0xFF}
+	colourUp         = color.RGBA{0x2E, 0xCC, 0x71, 0xFF}
+	colourDown       = color.RGBA{0xE7, 0x4C, 0x3C, 0xFF}
+	colourVolume     = color.RGBA{0xBD, 0xC3, 0xC7, 0xFF}
+
+	// palette colours the line of each series
+	palette = []color.RGBA{
+		{0x34, 0x98, 0xDB, 0xFF},
+		{0x9B, 0x59, 0xB6, 0xFF},
+		{0xF3, 0x9C, 0x12, 0xFF},
+		{0x1A, 0xBC, 0x9C, 0xFF},
+		{0x34, 0x49, 0x5E, 0xFF},
+		{0xD3, 0x54, 0x00, 0xFF},
+	}
+)
+
+// Candle holds the prices of an interval, Volume is the volume reported by
+// the exchange at the close of the interval
+type Candle struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Series is the price history of a market, such as a currency pair on an
+// exchange
+type Series struct {
+	Name    string
+	Candles []Candle
+}
+
+// Chart describes a price chart. Candlestick charts draw the first series as
+// candles and overlay the close of the other series as lines, line charts
+// draw the close of every series. Volume adds a volume panel for the first
+// series
+type Chart struct {
+	Title  string
+	Style  string
+	Width  int
+	Height int
+	Volume bool
+	Series []Series
+}
+
+// layout holds the pixel areas of a chart
+type layout struct {
+	price  image.Rectangle
+	volume image.Rectangle
+	min    float64
+	max    float64
+	start  time.Time
+	end    time.Time
+	pad    int
+}
+
+// x returns the horizontal position of a time
+func (l *layout) x(t time.Time) int {
+	width := l.price.Dx() - 2*l.pad
+	span := l.end.Sub(l.start)
+	if span <= 0 {
+		return l.price.Min.X + l.price.Dx()/2
+	}
+	return l.price.Min.X + l.pad + int(float64(width)*float64(t.Sub(l.start))/float64(span))
+}
+
+// y returns the vertical position of a price
+func (l *layout) y(price float64) int {
+	return l.price.Max.Y - 1 - int(float64(l.price.Dy()-1)*(price-l.min)/(l.max-l.min))
+}
+
+// PNG renders the chart to a PNG image
+func (c *Chart) PNG() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.Render(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render renders the chart and writes it to w as a PNG image
+func (c *Chart) Render(w io.Writer) error {
+	img, err := c.Draw()
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Draw renders the chart to an image
+func (c *Chart) Draw() (*image.RGBA, error) {
+	width, height := c.Width, c.Height
+	if width == 0 {
+		width = DefaultWidth
+	}
+	if height == 0 {
+		height = DefaultHeight
+	}
+
+	if width < MinWidth || width > MaxWidth || height < MinHeight || height > MaxHeight {
+		return nil, errInvalidSize
+	}
+
+	style := c.Style
+	if style == "" {
+		style = StyleCandlestick
+	}
+
+	if style != StyleCandlestick && style != StyleLine {
+		return nil, errInvalidStyle
+	}
+
+	l, err := c.layout(width, height, style)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colourBackground}, image.Point{}, draw.Src)
+
+	drawText(img, margin, margin, c.Title, colourText)
+	c.drawAxes(img, l)
+
+	if c.Volume {
+		c.drawVolume(img, l, style)
+	}
+
+	for i := range c.Series {
+		if i == 0 && style == StyleCandlestick {
+			drawCandles(img, l, c.Series[i].Candles)
+			continue
+		}
+		drawLine(img, l, c.Series[i].Candles, seriesColour(i, style))
+	}
+
+	c.drawLegend(img, l, style)
+	return img, nil
+}
+
+// seriesColour returns the line colour of a series, candlestick charts draw
+// the first series as candles so their lines start at the first colour
+func seriesColour(i int, style string) color.RGBA {
+	if style == StyleCandlestick {
+		i--
+	}
+	return palette[i%len(palette)]
+}
+
+// layout calculates the price range and the areas of the chart
+func (c *Chart) layout(width, height int, style string) (*layout, error) {
+	l := &layout{min: math.MaxFloat64, max: -math.MaxFloat64}
+	var candles int
+	for i := range c.Series {
+		for _, candle := range c.Series[i].Candles {
+			low, high := candle.Low, candle.High
+			if style == StyleLine || i > 0 {
+				low, high = candle.Close, candle.Close
+			}
+
+			l.min = math.Min(l.min, low)
+			l.max = math.Max(l.max, high)
+			if l.start.IsZero() || candle.Time.Before(l.start) {
+				l.start = candle.Time
+			}
+			if candle.Time.After(l.end) {
+				l.end = candle.Time
+			}
+		}
+
+		if len(c.Series[i].Candles) > candles {
+			candles = len(c.Series[i].Candles)
+		}
+	}
+
+	if candles == 0 {
+		return nil, errNoData
+	}
+
+	padding := (l.max - l.min) * 0.05
+	if padding == 0 {
+		padding = math.Max(math.Abs(l.max)*0.01, 1e-8)
+	}
+	l.min -= padding
+	l.max += padding
+
+	labelWidth := 0
+	for i := 0; i < gridLines; i++ {
+		labelWidth = maxInt(labelWidth, textWidth(formatPrice(l.gridPrice(i))))
+	}
+
+	top := margin*2 + glyphHeight
+	bottom := height - margin*2 - glyphHeight
+	right := width - margin*2 - labelWidth
+	l.price = image.Rect(margin, top, right, bottom)
+	if c.Volume && len(c.Series[0].Candles) > 0 {
+		volumeHeight := l.price.Dy() / volumeDivisor
+		l.volume = image.Rect(margin, bottom-volumeHeight, right, bottom)
+		l.price.Max.Y = l.volume.Min.Y - margin
+	}
+
+	l.pad = l.price.Dx() / candles / 2
+	return l, nil
+}
+
+// gridPrice returns the price of a horizontal grid line
+func (l *layout) gridPrice(i int) float64 {
+	return l.min + (l.max-l.min)*float64(i)/float64(gridLines-1)
+}
+
+// drawAxes draws the grid, price labels and time labels
+func (c *Chart) drawAxes(img *image.RGBA, l *layout) {
+	for i := 0; i < gridLines; i++ {
+		price := l.gridPrice(i)
+		y := l.y(price)
+		horizontalLine(img, l.price.Min.X, l.price.Max.X, y, colourGrid)
+		drawText(img, l.price.Max.X+margin, y-glyphHeight/2, formatPrice(price), colourText)
+	}
+
+	layoutFormat := "15:04"
+	switch span := l.end.Sub(l.start); {
+	case span > time.Hour*24*7:
+		layoutFormat = "01/02"
+	case span > time.Hour*24:
+		layoutFormat = "01/02 15:04"
+	}
+
+	bottom := l.price.Max.Y
+	if !l.volume.Empty() {
+		bottom = l.volume.Max.Y
+	}
+
+	for i := 0; i < timeLabels; i++ {
+		t := l.start.Add(time.Duration(float64(l.end.Sub(l.start)) * float64(i) / float64(timeLabels-1)))
+		label := t.Format(layoutFormat)
+		x := l.x(t) - textWidth(label)/2
+		x = maxInt(margin, minInt(x, l.price.Max.X-textWidth(label)))
+		drawText(img, x, bottom+margin, label, colourText)
+		if i == 0 && l.end.Equal(l.start) {
+			break
+		}
+	}
+}
+
+// drawCandles draws a candle for each interval
+func drawCandles(img *image.RGBA, l *layout, candles []Candle) {
+	bodyWidth := maxInt(1, l.pad*2*3/5)
+	for _, candle := range candles {
+		x := l.x(candle.Time)
+		colour := colourUp
+		if candle.Close < candle.Open {
+			colour = colourDown
+		}
+
+		verticalLine(img, x, l.y(candle.High), l.y(candle.Low), colour)
+		top, bottom := l.y(math.Max(candle.Open, candle.Close)), l.y(math.Min(candle.Open, candle.Close))
+		fillRect(img, image.Rect(x-bodyWidth/2, top, x-bodyWidth/2+bodyWidth, bottom+1), colour)
+	}
+}
+
+// drawLine draws a line joining the closes of the candles
+func drawLine(img *image.RGBA, l *layout, candles []Candle, colour color.Color) {
+	for i := range candles {
+		x, y := l.x(candles[i].Time), l.y(candles[i].Close)
+		if i == 0 {
+			fillRect(img, image.Rect(x, y, x+2, y+2), colour)
+			continue
+		}
+		line(img, l.x(candles[i-1].Time), l.y(candles[i-1].Close), x, y, colour)
+	}
+}
+
+// drawVolume draws the volume bars of the first series
+func (c *Chart) drawVolume(img *image.RGBA, l *layout, style string) {
+	if l.volume.Empty() {
+		return
+	}
+
+	candles := c.Series[0].Candles
+	var highest float64
+	for i := range candles {
+		highest = math.Max(highest, candles[i].Volume)
+	}
+
+	horizontalLine(img, l.volume.Min.X, l.volume.Max.X, l.volume.Max.Y-1, colourGrid)
+	if highest <= 0 {
+		return
+	}
+
+	barWidth := maxInt(1, l.pad*2*3/5)
+	for _, candle := range candles {
+		colour := colourVolume
+		if style == StyleCandlestick {
+			colour = colourUp
+			if candle.Close < candle.Open {
+				colour = colourDown
+			}
+		}
+
+		x := l.x(candle.Time)
+		height := int(float64(l.volume.Dy()) * candle.Volume / highest)
+		fillRect(img, image.Rect(x-barWidth/2, l.volume.Max.Y-height, x-barWidth/2+barWidth, l.volume.Max.Y), colour)
+	}
+	drawText(img, l.volume.Max.X+margin, l.volume.Min.Y, "VOL", colourText)
+}
+
+// drawLegend names the series of charts with more than one series
+func (c *Chart) drawLegend(img *image.RGBA, l *layout, style string) {
+	if len(c.Series) < 2 {
+		return
+	}
+
+	x := l.price.Min.X + margin
+	y := l.price.Min.Y + margin
+	for i := range c.Series {
+		colour := colourUp
+		if i > 0 || style == StyleLine {
+			colour = seriesColour(i, style)
+		}
+
+		fillRect(img, image.Rect(x, y+1, x+glyphWidth, y+glyphHeight-1), colour)
+		drawText(img, x+glyphAdvance+2, y, c.Series[i].Name, colourText)
+		y += glyphHeight + 4
+	}
+}
+
+// formatPrice formats an axis price with decimals suited to its magnitude
+func formatPrice(v float64) string {
+	switch a := math.Abs(v); {
+	case a >= 1000:
+		return fmt.Sprintf("%.0f", v)
+	case a >= 1:
+		return fmt.Sprintf("%.2f", v)
+	case a >= 0.01:
+		return fmt.Sprintf("%.4f", v)
+	}
+	return fmt.Sprintf("%.8f", v)
+}
@@ -0,0 +1,133 @@
+package charts
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func testSeries(name string, start time.Time, prices ...float64) Series {
+	s := Series{Name: name}
+	for i := 1; i < len(prices); i++ {
+		open, close := prices[i-1], prices[i]
+		s.Candles = append(s.Candles, Candle{
+			Time:   start.Add(time.Hour * time.Duration(i)),
+			Open:   open,
+			High:   open*0.5 + close*0.5 + 20,
+			Low:    open*0.5 + close*0.5 - 20,
+			Close:  close,
+			Volume: float64(i * 10),
+		})
+	}
+	return s
+}
+
+// countColour returns the number of pixels of a colour within a rectangle
+func countColour(img *image.RGBA, r image.Rectangle, c color.RGBA) int {
+	var n int
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if img.RGBAAt(x, y) == c {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestDraw(t *testing.T) {
+	start := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	chart := Chart{
+		Title:  "Bitstamp BTCUSD 6h",
+		Volume: true,
+		Series: []Series{
+			testSeries("Bitstamp", start, 10000, 10100, 10050, 10200, 10150, 10300),
+			testSeries("Kraken", start, 10010, 10090, 10060, 10210, 10160, 10290),
+		},
+	}
+
+	img, err := chart.Draw()
+	if err != nil {
+		t.Fatal("test failed - Draw() error", err)
+	}
+
+	if img.Bounds().Dx() != DefaultWidth || img.Bounds().Dy() != DefaultHeight {
+		t.Error("test failed - Draw() default size not applied", img.Bounds())
+	}
+
+	l, err := chart.layout(DefaultWidth, DefaultHeight, StyleCandlestick)
+	if err != nil {
+		t.Fatal("test failed - layout() error", err)
+	}
+
+	if countColour(img, l.price, colourUp) == 0 || countColour(img, l.price, colourDown) == 0 {
+		t.Error("test failed - Draw() candles not drawn")
+	}
+
+	if countColour(img, l.price, palette[0]) == 0 {
+		t.Error("test failed - Draw() overlay line not drawn")
+	}
+
+	if l.volume.Empty() || countColour(img, l.volume, colourUp) == 0 {
+		t.Error("test failed - Draw() volume not drawn")
+	}
+
+	if countColour(img, image.Rect(0, 0, DefaultWidth, l.price.Min.Y), colourText) == 0 {
+		t.Error("test failed - Draw() title not drawn")
+	}
+
+	chart.Style = StyleLine
+	chart.Volume = false
+	chart.Width, chart.Height = 300, 200
+	data, err := chart.PNG()
+	if err != nil {
+		t.Fatal("test failed - PNG() error", err)
+	}
+
+	decoded, err := png.Decode(bytes.NewReader(data))
+	if err != nil || decoded.Bounds().Dx() != 300 || decoded.Bounds().Dy() != 200 {
+		t.Error("test failed - PNG() invalid image", err)
+	}
+}
+
+func TestDrawErrors(t *testing.T) {
+	start := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	series := []Series{testSeries("Bitstamp", start, 1, 2)}
+
+	for _, tc := range []struct {
+		chart Chart
+		err   error
+	}{
+		{Chart{}, errNoData},
+		{Chart{Series: []Series{{Name: "empty"}}}, errNoData},
+		{Chart{Width: 50, Series: series}, errInvalidSize},
+		{Chart{Height: 5000, Series: series}, errInvalidSize},
+		{Chart{Style: "pie", Series: series}, errInvalidStyle},
+	} {
+		if _, err := tc.chart.Draw(); err != tc.err {
+			t.Errorf("test failed - Draw() expected %v got %v", tc.err, err)
+		}
+	}
+
+	// a single candle with no price movement is still drawn
+	flat := Chart{Series: []Series{{Name: "flat", Candles: []Candle{{Time: start, Open: 5, High: 5, Low: 5, Close: 5}}}}}
+	if _, err := flat.Draw(); err != nil {
+		t.Error("test failed - Draw() flat chart error", err)
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	for v, expected := range map[float64]string{
+		12345.678:  "12346",
+		123.456:    "123.46",
+		0.1234567:  "0.1235",
+		0.00012345: "0.00012345",
+	} {
+		if s := formatPrice(v); s != expected {
+			t.Errorf("test failed - formatPrice(%v) expected %s got %s", v, expected, s)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package charts
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// horizontalLine draws a line from x1 to x2 at y
+func horizontalLine(img *image.RGBA, x1, x2, y int, c color.Color) {
+	for x := x1; x < x2; x++ {
+		img.Set(x, y, c)
+	}
+}
+
+// verticalLine draws a line from y1 to y2 at x
+func verticalLine(img *image.RGBA, x, y1, y2 int, c color.Color) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		img.Set(x, y, c)
+	}
+}
+
+// line draws a two pixel thick line between the points using Bresenham's
+// algorithm
+func line(img *image.RGBA, x1, y1, x2, y2 int, c color.Color) {
+	dx, dy := abs(x2-x1), -abs(y2-y1)
+	sx, sy := 1, 1
+	if x1 > x2 {
+		sx = -1
+	}
+	if y1 > y2 {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		img.Set(x1, y1, c)
+		img.Set(x1, y1+1, c)
+		if x1 == x2 && y1 == y2 {
+			return
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+// fillRect fills a rectangle
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
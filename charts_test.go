@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/communications"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/digest"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func TestCharts(t *testing.T) {
+	backupConfig, backupComms := bot.Config, bot.Comms
+	defer func() {
+		bot.Config, bot.Comms = backupConfig, backupComms
+		marketHistory = nil
+	}()
+
+	bot.Config = &config.Config{}
+	bot.Config.Charts = config.ChartsConfig{Enabled: true, AlertCharts: true, Volume: true}
+	bot.Config.CheckChartsConfigValues()
+
+	now := time.Now()
+	btc := pair.NewCurrencyPair("BTC", "USD")
+	marketHistory = digest.NewCollector(bot.Config.Charts.History)
+	for i := 10; i >= 0; i-- {
+		at := now.Add(-time.Minute * 10 * time.Duration(i))
+		marketHistory.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 10000 + float64(i*10), Volume: 5}, at)
+		marketHistory.RecordTicker("Kraken", ticker.Spot, ticker.Price{Pair: btc, Last: 10005 + float64(i*10)}, at)
+	}
+
+	comm := &testRecipientComm{
+		Base:   base.Base{Name: "Telegram", Enabled: true, Connected: true},
+		pushed: make(map[string][]base.Event),
+	}
+	bot.Comms = &communications.Communications{IComm: base.IComm{comm}}
+	StartCharts()
+
+	commander := base.NewCommander("TestBot", "Telegram", nil)
+	commander.Register(base.Command{Name: chartCommand, MinArgs: 1, MaxArgs: 3, ReplyHandler: cmdChart})
+
+	reply, _ := commander.HandleReply("/chart BTC-USD bitstamp 2h", "100")
+	if reply.Text != "Bitstamp BTC-USD 2h" || len(reply.Image) == 0 {
+		t.Fatalf("Test failed. cmdChart unexpected reply %q", reply.Text)
+	}
+
+	if _, err := png.Decode(bytes.NewReader(reply.Image)); err != nil {
+		t.Error("Test failed. cmdChart invalid image", err)
+	}
+
+	chart, err := buildChart("BTCUSD", "", time.Hour*6, now)
+	if err != nil || chart.Style != "line" || len(chart.Series) != 2 || chart.Title != "BTCUSD 6h" {
+		t.Errorf("Test failed. buildChart across exchanges error %v", err)
+	}
+
+	chart, err = buildChart("BTCUSD", "bitstamp", time.Hour, now)
+	if err != nil || chart.Style != "candlestick" || !chart.Volume || len(chart.Series[0].Candles) != 7 {
+		t.Errorf("Test failed. buildChart exchange error %v", err)
+	}
+
+	for _, args := range []string{"ETHUSD", "BTCUSD bitstamp 2d", "BTCUSD bitstamp kraken", "BTCUSD 1x"} {
+		if reply, _ = commander.HandleReply("/chart "+args, "100"); reply.Image != nil ||
+			!strings.HasPrefix(reply.Text, "chart: ") {
+			t.Errorf("Test failed. cmdChart accepted %s: %q", args, reply.Text)
+		}
+	}
+
+	bot.Comms.PushEventTo("Telegram", "100", base.Event{Type: "ALERT", Exchange: "Kraken", Pair: "BTCUSD"})
+	bot.Comms.PushEventTo("Telegram", "100", base.Event{Type: "ALERT", Exchange: "Kraken", Pair: "LTCUSD"})
+	if events := comm.pushed["100"]; len(events) != 2 || len(events[0].Image) == 0 || events[1].Image != nil {
+		t.Error("Test failed. Alert chart not attached")
+	}
+}
+
+func TestChartPeriod(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"30m": time.Minute * 30,
+		"6h":  time.Hour * 6,
+		"2d":  time.Hour * 48,
+	} {
+		if d, err := parseChartPeriod(s); err != nil || d != expected {
+			t.Errorf("Test failed. parseChartPeriod(%s) expected %s got %s", s, expected, d)
+		}
+
+		if formatChartPeriod(expected) != s {
+			t.Errorf("Test failed. formatChartPeriod(%s) expected %s", expected, s)
+		}
+	}
+
+	for _, s := range []string{"", "d", "0d", "30s", "bitstamp"} {
+		if _, err := parseChartPeriod(s); err == nil {
+			t.Errorf("Test failed. parseChartPeriod accepted %q", s)
+		}
+	}
+}
@@ -20,6 +20,12 @@ var (
 	m               sync.Mutex
 )
 
+// Const vars for the retry delays of the communication medium pollers
+const (
+	MinBackoff = time.Second
+	MaxBackoff = time.Minute * 5
+)
+
 // Orderbook holds the minimal orderbook details to be sent to a communication
 // medium
 type Orderbook struct {
@@ -58,11 +64,17 @@ type Base struct {
 	Connected bool
 }
 
-// Event is a generalise event type
+// Event is a generalise event type. Severity, Exchange and Pair are used to
+// route the event to the communication mediums. Image is an optional PNG
+// image, such as a price chart, sent by the mediums supporting images
 type Event struct {
 	Type         string
 	GainLoss     string
 	TradeDetails string
+	Severity     string
+	Exchange     string
+	Pair         string
+	Image        []byte
 }
 
 // IsEnabled returns if the comms package has been enabled in the configuration
@@ -164,3 +176,16 @@ func (b *Base) GetStatus() string {
 	GoCryptoTrader Service: Online
 	Service Started: ` + ServiceStarted.String()
 }
+
+// NextBackoff doubles the retry delay of a poller up to MaxBackoff
+func NextBackoff(backoff time.Duration) time.Duration {
+	if backoff < MinBackoff {
+		return MinBackoff
+	}
+
+	backoff *= 2
+	if backoff > MaxBackoff {
+		return MaxBackoff
+	}
+	return backoff
+}
@@ -1,7 +1,9 @@
 package base
 
 import (
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
@@ -22,6 +24,18 @@ type ICommunicate interface {
 	GetName() string
 }
 
+// IRecipientPusher is implemented by communication packages able to push an
+// event to a single recipient, such as the chat an alert was created in
+type IRecipientPusher interface {
+	PushEventTo(recipient string, event Event) error
+}
+
+// ICommander is implemented by communication packages which handle chat
+// commands
+type ICommander interface {
+	RegisterCommand(cmd Command)
+}
+
 // Setup sets up communication variables and intiates a connection to the
 // communication mediums
 func (c IComm) Setup() {
@@ -52,6 +66,53 @@ func (c IComm) PushEvent(event Event) {
 	}
 }
 
+// PushEventTo pushes an event to a recipient of the named communication
+// medium
+func (c IComm) PushEventTo(medium, recipient string, event Event) error {
+	for i := range c {
+		if !strings.EqualFold(c[i].GetName(), medium) {
+			continue
+		}
+
+		pusher, ok := c[i].(IRecipientPusher)
+		if !ok {
+			return fmt.Errorf("communications medium %s cannot push to a recipient", medium)
+		}
+
+		if !c[i].IsEnabled() || !c[i].IsConnected() {
+			return fmt.Errorf("communications medium %s is not connected", medium)
+		}
+		return pusher.PushEventTo(recipient, event)
+	}
+	return fmt.Errorf("communications medium %s not found", medium)
+}
+
+// PushEventToMedium pushes an event to every recipient of the named
+// communication medium
+func (c IComm) PushEventToMedium(medium string, event Event) error {
+	for i := range c {
+		if !strings.EqualFold(c[i].GetName(), medium) {
+			continue
+		}
+
+		if !c[i].IsEnabled() || !c[i].IsConnected() {
+			return fmt.Errorf("communications medium %s is not connected", medium)
+		}
+		return c[i].PushEvent(event)
+	}
+	return fmt.Errorf("communications medium %s not found", medium)
+}
+
+// RegisterCommand adds a chat command to every communication medium which
+// handles chat commands
+func (c IComm) RegisterCommand(cmd Command) {
+	for i := range c {
+		if commander, ok := c[i].(ICommander); ok {
+			commander.RegisterCommand(cmd)
+		}
+	}
+}
+
 // GetEnabledCommunicationMediums prints out enabled and connected communication
 // packages
 func (c IComm) GetEnabledCommunicationMediums() {
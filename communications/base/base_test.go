@@ -2,6 +2,8 @@ package base
 
 import (
 	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
 )
 
 var (
@@ -82,3 +84,41 @@ func TestPushEvent(t *testing.T) {
 func TestGetEnabledCommunicationMediums(t *testing.T) {
 	i.GetEnabledCommunicationMediums()
 }
+
+type testRecipientComm struct {
+	Base
+	recipient string
+}
+
+func (c *testRecipientComm) Setup(cfg config.CommunicationsConfig) {}
+func (c *testRecipientComm) Connect() error                        { return nil }
+func (c *testRecipientComm) PushEvent(Event) error                 { return nil }
+func (c *testRecipientComm) PushEventTo(recipient string, event Event) error {
+	c.recipient = recipient
+	return nil
+}
+
+func TestPushEventTo(t *testing.T) {
+	comm := &testRecipientComm{Base: Base{Name: "Telegram", Enabled: true, Connected: true}}
+	comms := IComm{comm}
+
+	if err := comms.PushEventTo("slack", "1", Event{}); err == nil {
+		t.Error("test failed - IComm PushEventTo() pushed to an unknown medium")
+	}
+
+	if err := comms.PushEventTo("telegram", "1337", Event{}); err != nil || comm.recipient != "1337" {
+		t.Error("test failed - IComm PushEventTo() error", err)
+	}
+
+	comm.Connected = false
+	if err := comms.PushEventTo("telegram", "1", Event{}); err == nil {
+		t.Error("test failed - IComm PushEventTo() pushed to a disconnected medium")
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	if NextBackoff(0) != MinBackoff || NextBackoff(MinBackoff) != MinBackoff*2 ||
+		NextBackoff(MaxBackoff) != MaxBackoff {
+		t.Error("test failed - NextBackoff() error")
+	}
+}
@@ -0,0 +1,516 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/translation"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Chat command defaults
+const (
+	CommandPrefixes = "/!"
+
+	defaultBookDepth = 5
+	maxBookDepth     = 25
+)
+
+var (
+	errCommandNotFound     = errors.New("command not recognised, send help for a list of commands")
+	errCommandUnauthorised = errors.New("you are not authorised to use this command")
+	errCommandUsage        = errors.New("invalid arguments")
+	errExchangeNotFound    = errors.New("no data found for exchange")
+	errPairNotFound        = errors.New("no data found for currency pair")
+	errInvalidPair         = errors.New("invalid currency pair, use a format like BTCUSD or BTC-USD")
+	errInvalidDepth        = errors.New("invalid depth")
+)
+
+// CommandRequest holds a parsed chat command, the medium and chat it was sent
+// from and the identifiers of the user who sent it
+type CommandRequest struct {
+	Name   string
+	Args   []string
+	Medium string
+	Chat   string
+	Users  []string
+}
+
+// Owner returns an identifier of the user who sent the command, unique across
+// mediums
+func (r *CommandRequest) Owner() string {
+	if len(r.Users) == 0 {
+		return ""
+	}
+	return common.StringToLower(r.Medium) + ":" + r.Users[0]
+}
+
+// Command is a chat command shared by the communication mediums
+type Command struct {
+	Name        string
+	Args        string
+	Description string
+	MinArgs     int
+	MaxArgs     int
+	// Public commands can be used by users that are not authorised
+	Public  bool
+	Handler func(c *Commander, req CommandRequest) (string, error)
+	// ReplyHandler is used instead of Handler by commands replying with an
+	// image
+	ReplyHandler func(c *Commander, req CommandRequest) (Reply, error)
+}
+
+// Reply is the response to a chat command, Image is an optional PNG image
+// sent by the mediums supporting images
+type Reply struct {
+	Text  string
+	Image []byte
+}
+
+// Usage returns the command usage string
+func (c *Command) Usage() string {
+	if c.Args == "" {
+		return c.Name
+	}
+	return c.Name + " " + c.Args
+}
+
+// Commander parses and dispatches chat commands and authorises the users
+// sending them
+type Commander struct {
+	Name            string
+	Medium          string
+	AuthorisedUsers []string
+
+	commands map[string]*Command
+	mtx      sync.RWMutex
+}
+
+// NewCommander returns a Commander for a medium with the default commands
+// registered. If no users are supplied every user is authorised
+func NewCommander(name, medium string, authorisedUsers []string) *Commander {
+	c := &Commander{
+		Name:            name,
+		Medium:          medium,
+		AuthorisedUsers: authorisedUsers,
+		commands:        make(map[string]*Command),
+	}
+
+	for _, cmd := range DefaultCommands() {
+		c.Register(cmd)
+	}
+	return c
+}
+
+// DefaultCommands returns the commands available to every communication
+// medium
+func DefaultCommands() []Command {
+	return []Command{
+		{
+			Name:        "help",
+			Description: "Displays this command list",
+			Public:      true,
+			Handler:     cmdHelp,
+		},
+		{
+			Name:        "status",
+			Args:        "[exchange]",
+			Description: "Displays the status of the bot or an exchange",
+			MaxArgs:     1,
+			Handler:     cmdStatus,
+		},
+		{
+			Name:        "ticker",
+			Args:        "<exchange> [pair]",
+			Description: "Displays ticker data for an exchange",
+			MinArgs:     1,
+			MaxArgs:     2,
+			Handler:     cmdTicker,
+		},
+		{
+			Name:        "book",
+			Args:        "<exchange> <pair> [depth]",
+			Description: fmt.Sprintf("Displays the top of an orderbook, depth defaults to %d", defaultBookDepth),
+			MinArgs:     2,
+			MaxArgs:     3,
+			Handler:     cmdBook,
+		},
+		{
+			Name:        "price",
+			Args:        "<pair>",
+			Description: "Displays the price of a currency pair across exchanges",
+			MinArgs:     1,
+			MaxArgs:     1,
+			Handler:     cmdPrice,
+		},
+		{
+			Name:        "settings",
+			Description: "Displays current bot settings",
+			Handler: func(c *Commander, req CommandRequest) (string, error) {
+				return new(Base).GetSettings(), nil
+			},
+		},
+		{
+			Name:        "portfolio",
+			Description: "Displays portfolio data",
+			Handler: func(c *Commander, req CommandRequest) (string, error) {
+				return new(Base).GetPortfolio(), nil
+			},
+		},
+	}
+}
+
+// Register adds or replaces a command
+func (c *Commander) Register(cmd Command) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	cmd.Name = common.StringToLower(cmd.Name)
+	c.commands[cmd.Name] = &cmd
+}
+
+// IsAuthorised returns whether any of the supplied user identifiers is an
+// authorised user
+func (c *Commander) IsAuthorised(users ...string) bool {
+	if len(c.AuthorisedUsers) == 0 {
+		return true
+	}
+
+	for i := range users {
+		if users[i] == "" {
+			continue
+		}
+		for j := range c.AuthorisedUsers {
+			if strings.EqualFold(strings.TrimPrefix(c.AuthorisedUsers[j], "@"), users[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseCommand splits a chat message into a command request, returning false
+// if the message is not a command
+func ParseCommand(text string) (CommandRequest, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.ContainsAny(fields[0][:1], CommandPrefixes) {
+		return CommandRequest{}, false
+	}
+
+	name := common.StringToLower(fields[0][1:])
+	// Telegram appends the bot name to commands in group chats
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+
+	if name == "" {
+		return CommandRequest{}, false
+	}
+	return CommandRequest{Name: name, Args: fields[1:]}, true
+}
+
+// Handle executes a chat message sent in a chat by the supplied user
+// identifiers and returns the reply, returning false if the message is not a
+// command
+func (c *Commander) Handle(text, chat string, users ...string) (string, bool) {
+	reply, ok := c.HandleReply(text, chat, users...)
+	return reply.Text, ok
+}
+
+// HandleReply executes a chat message like Handle, returning the reply with
+// its image for the mediums supporting images
+func (c *Commander) HandleReply(text, chat string, users ...string) (Reply, bool) {
+	req, ok := ParseCommand(text)
+	if !ok {
+		return Reply{}, false
+	}
+	req.Medium = c.Medium
+	req.Chat = chat
+	req.Users = users
+
+	reply, err := c.ExecuteReply(req)
+	if err != nil {
+		return Reply{Text: fmt.Sprintf("%s: %s", req.Name, err)}, true
+	}
+	return reply, true
+}
+
+// Execute runs a parsed command request
+func (c *Commander) Execute(req CommandRequest) (string, error) {
+	reply, err := c.ExecuteReply(req)
+	return reply.Text, err
+}
+
+// ExecuteReply runs a parsed command request, returning the reply with its
+// image
+func (c *Commander) ExecuteReply(req CommandRequest) (Reply, error) {
+	c.mtx.RLock()
+	cmd, ok := c.commands[req.Name]
+	c.mtx.RUnlock()
+	if !ok {
+		return Reply{}, errCommandNotFound
+	}
+
+	if !cmd.Public && !c.IsAuthorised(req.Users...) {
+		return Reply{}, errCommandUnauthorised
+	}
+
+	if len(req.Args) < cmd.MinArgs || len(req.Args) > cmd.MaxArgs {
+		return Reply{}, fmt.Errorf("%s, usage: %s", errCommandUsage, cmd.Usage())
+	}
+
+	if cmd.ReplyHandler != nil {
+		return cmd.ReplyHandler(c, req)
+	}
+
+	text, err := cmd.Handler(c, req)
+	return Reply{Text: text}, err
+}
+
+// Help returns the command list generated from the registered commands
+func (c *Commander) Help() string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	var names []string
+	for name := range c.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{fmt.Sprintf("%s, thank you for using this service! Current commands are:", c.Name)}
+	for _, name := range names {
+		cmd := c.commands[name]
+		lines = append(lines, fmt.Sprintf("%s - %s", cmd.Usage(), cmd.Description))
+	}
+	return common.JoinStrings(lines, "\n")
+}
+
+func cmdHelp(c *Commander, req CommandRequest) (string, error) {
+	return c.Help(), nil
+}
+
+func cmdStatus(c *Commander, req CommandRequest) (string, error) {
+	if len(req.Args) == 0 {
+		return common.JoinStrings([]string{
+			"GoCryptoTrader Service: Online",
+			"Service Started: " + ServiceStarted.Format(time.RFC1123),
+			fmt.Sprintf("Uptime: %s", time.Since(ServiceStarted).Truncate(time.Second)),
+		}, "\n"), nil
+	}
+
+	exchangeName, ok := resolveExchange(req.Args[0])
+	if !ok {
+		return "", errExchangeNotFound
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	var tickers int
+	var lastUpdated time.Time
+	for _, x := range TickerStaged[exchangeName] {
+		for _, y := range x {
+			tickers++
+			if y.LastUpdated.After(lastUpdated) {
+				lastUpdated = y.LastUpdated
+			}
+		}
+	}
+
+	var orderbooks int
+	for _, x := range OrderbookStaged[exchangeName] {
+		orderbooks += len(x)
+	}
+
+	status := fmt.Sprintf("%s: %d tickers, %d orderbooks", exchangeName, tickers, orderbooks)
+	if !lastUpdated.IsZero() {
+		status += fmt.Sprintf(", last ticker update %s ago",
+			time.Since(lastUpdated).Truncate(time.Second))
+	}
+	return status, nil
+}
+
+func cmdTicker(c *Commander, req CommandRequest) (string, error) {
+	exchangeName, ok := resolveExchange(req.Args[0])
+	if !ok {
+		return "", errExchangeNotFound
+	}
+
+	if len(req.Args) == 1 {
+		return new(Base).GetTicker(exchangeName), nil
+	}
+
+	p, err := parseCommandPair(req.Args[1])
+	if err != nil {
+		return "", err
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for assetType, x := range TickerStaged[exchangeName] {
+		for _, y := range x {
+			if matchPair(y.CurrencyPair, p) {
+				return fmt.Sprintf("%s %s %s\nLast: %v Bid: %v Ask: %v\nHigh: %v Low: %v Volume: %v",
+					exchangeName, y.CurrencyPair, assetType, y.Last, y.Bid, y.Ask, y.High,
+					y.Low, y.Volume), nil
+			}
+		}
+	}
+	return "", errPairNotFound
+}
+
+func cmdBook(c *Commander, req CommandRequest) (string, error) {
+	exchangeName, ok := resolveExchange(req.Args[0])
+	if !ok {
+		return "", errExchangeNotFound
+	}
+
+	p, err := parseCommandPair(req.Args[1])
+	if err != nil {
+		return "", err
+	}
+
+	depth := defaultBookDepth
+	if len(req.Args) == 3 {
+		depth, err = strconv.Atoi(req.Args[2])
+		if err != nil || depth <= 0 || depth > maxBookDepth {
+			return "", fmt.Errorf("%s, must be between 1 and %d", errInvalidDepth, maxBookDepth)
+		}
+	}
+
+	var book orderbook.Base
+	for _, x := range pairVariants(p) {
+		// some exchanges store their orderbooks with lower case currencies
+		lower := pair.NewCurrencyPair(x.GetFirstCurrency().Lower().String(),
+			x.GetSecondCurrency().Lower().String())
+		book, err = orderbook.GetOrderbook(exchangeName, x, orderbook.Spot)
+		if err != nil {
+			book, err = orderbook.GetOrderbook(exchangeName, lower, orderbook.Spot)
+		}
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", errPairNotFound
+	}
+
+	lines := []string{fmt.Sprintf("%s %s orderbook updated %s", exchangeName,
+		book.CurrencyPair, book.LastUpdated.Format(time.RFC1123))}
+	lines = append(lines, "Asks:")
+	for i := 0; i < depth && i < len(book.Asks); i++ {
+		lines = append(lines, fmt.Sprintf("%v @ %v", book.Asks[i].Amount, book.Asks[i].Price))
+	}
+	lines = append(lines, "Bids:")
+	for i := 0; i < depth && i < len(book.Bids); i++ {
+		lines = append(lines, fmt.Sprintf("%v @ %v", book.Bids[i].Amount, book.Bids[i].Price))
+	}
+	return common.JoinStrings(lines, "\n"), nil
+}
+
+func cmdPrice(c *Commander, req CommandRequest) (string, error) {
+	p, err := parseCommandPair(req.Args[0])
+	if err != nil {
+		return "", err
+	}
+
+	m.Lock()
+	var prices []ticker.Price
+	var exchanges []string
+	for exchangeName, x := range TickerStaged {
+		for _, y := range x[ticker.Spot] {
+			if matchPair(y.CurrencyPair, p) {
+				prices = append(prices, y)
+				exchanges = append(exchanges, exchangeName)
+			}
+		}
+	}
+	m.Unlock()
+
+	if len(prices) == 0 {
+		return "", errPairNotFound
+	}
+
+	lines := make([]string, len(prices))
+	var total float64
+	for i := range prices {
+		lines[i] = fmt.Sprintf("%s %s: %v (bid %v ask %v)", exchanges[i],
+			prices[i].CurrencyPair, prices[i].Last, prices[i].Bid, prices[i].Ask)
+		total += prices[i].Last
+	}
+	sort.Strings(lines)
+	lines = append(lines, fmt.Sprintf("Average: %v", total/float64(len(prices))))
+	return common.JoinStrings(lines, "\n"), nil
+}
+
+// resolveExchange matches an exchange name case insensitively against the
+// staged exchange data
+func resolveExchange(name string) (string, bool) {
+	m.Lock()
+	defer m.Unlock()
+
+	normalised := normaliseName(name)
+	for exchangeName := range TickerStaged {
+		if normaliseName(exchangeName) == normalised {
+			return exchangeName, true
+		}
+	}
+	for exchangeName := range OrderbookStaged {
+		if normaliseName(exchangeName) == normalised {
+			return exchangeName, true
+		}
+	}
+	return "", false
+}
+
+func normaliseName(name string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "", "/", "").Replace(common.StringToUpper(name))
+}
+
+// parseCommandPair parses a currency pair supplied as BTCUSD, BTC-USD, BTC_USD
+// or BTC/USD
+func parseCommandPair(s string) (pair.CurrencyPair, error) {
+	s = strings.Replace(common.StringToUpper(s), "/", "-", -1)
+	if strings.ContainsAny(s, "-_") {
+		fields := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+		if len(fields) != 2 {
+			return pair.CurrencyPair{}, errInvalidPair
+		}
+		return pair.NewCurrencyPair(fields[0], fields[1]), nil
+	}
+
+	if len(s) < 6 {
+		return pair.CurrencyPair{}, errInvalidPair
+	}
+	return pair.NewCurrencyPairFromString(s), nil
+}
+
+// pairVariants returns the pair and its translated form, such as XBTUSD for
+// BTCUSD
+func pairVariants(p pair.CurrencyPair) []pair.CurrencyPair {
+	variants := []pair.CurrencyPair{p}
+	if first, err := translation.GetTranslation(p.GetFirstCurrency()); err == nil {
+		variants = append(variants, pair.NewCurrencyPair(first.String(), p.GetSecondCurrency().String()))
+	}
+	return variants
+}
+
+// matchPair returns whether a staged currency pair string matches the pair
+// or its translated form
+func matchPair(staged string, p pair.CurrencyPair) bool {
+	staged = normaliseName(staged)
+	for _, x := range pairVariants(p) {
+		if staged == x.Pair().String() {
+			return true
+		}
+	}
+	return false
+}
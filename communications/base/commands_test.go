@@ -0,0 +1,160 @@
+package base
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func testStageCommandData() {
+	i.Setup()
+
+	kraken := ticker.Price{
+		Pair: pair.NewCurrencyPairDelimiter("XBT-USD", "-"),
+		Last: 6500, Bid: 6499, Ask: 6501,
+	}
+	kraken.CurrencyPair = kraken.Pair.Pair().String()
+	i.StageTickerData("Kraken", ticker.Spot, kraken)
+
+	gdax := ticker.Price{
+		Pair: pair.NewCurrencyPair("BTC", "USD"),
+		Last: 6510, Bid: 6509, Ask: 6511,
+	}
+	gdax.CurrencyPair = gdax.Pair.Pair().String()
+	i.StageTickerData("GDAX", ticker.Spot, gdax)
+
+	book := orderbook.Base{
+		Asks: []orderbook.Item{{Price: 6511, Amount: 1}, {Price: 6512, Amount: 2}},
+		Bids: []orderbook.Item{{Price: 6509, Amount: 3}, {Price: 6508, Amount: 4}},
+	}
+	orderbook.ProcessOrderbook("GDAX", gdax.Pair, book, orderbook.Spot)
+	book.CurrencyPair = gdax.CurrencyPair
+	i.StageOrderbookData("GDAX", ticker.Spot, book)
+}
+
+func TestParseCommand(t *testing.T) {
+	for _, x := range []string{"", "hello", "/", "! ticker"} {
+		if _, ok := ParseCommand(x); ok {
+			t.Errorf("test failed - ParseCommand() parsed %q", x)
+		}
+	}
+
+	req, ok := ParseCommand("/Book@GoCryptoTraderBot  gdax BTC-USD 10")
+	if !ok || req.Name != "book" || len(req.Args) != 3 || req.Args[2] != "10" {
+		t.Errorf("test failed - ParseCommand() unexpected result %v", req)
+	}
+
+	req, ok = ParseCommand("!price BTCUSD")
+	if !ok || req.Name != "price" || req.Args[0] != "BTCUSD" {
+		t.Errorf("test failed - ParseCommand() unexpected result %v", req)
+	}
+}
+
+func TestCommanderAuthorisation(t *testing.T) {
+	c := NewCommander("TestBot", "test", []string{"@alice", "1234"})
+
+	if !c.IsAuthorised("5678", "Alice") || !c.IsAuthorised("1234") ||
+		c.IsAuthorised("5678", "bob") || c.IsAuthorised("") {
+		t.Error("test failed - Commander IsAuthorised() error")
+	}
+
+	if _, err := c.Execute(CommandRequest{Name: "status", Users: []string{"bob"}}); err != errCommandUnauthorised {
+		t.Error("test failed - Commander Execute() should reject unauthorised users", err)
+	}
+
+	reply, ok := c.Handle("/help", "", "bob")
+	if !ok || !strings.Contains(reply, "TestBot") ||
+		!strings.Contains(reply, "book <exchange> <pair> [depth] - Displays the top of an orderbook") {
+		t.Errorf("test failed - Commander Handle() help %q", reply)
+	}
+
+	if !NewCommander("TestBot", "test", nil).IsAuthorised("bob") {
+		t.Error("test failed - Commander IsAuthorised() should allow all users without a list")
+	}
+}
+
+func TestCommanderExecute(t *testing.T) {
+	testStageCommandData()
+	c := NewCommander("TestBot", "test", nil)
+
+	if _, ok := c.Handle("hello", ""); ok {
+		t.Error("test failed - Commander Handle() handled a message")
+	}
+
+	reply, _ := c.Handle("/unknown", "")
+	if !strings.Contains(reply, errCommandNotFound.Error()) {
+		t.Errorf("test failed - Commander Handle() unknown command %q", reply)
+	}
+
+	reply, _ = c.Handle("/ticker", "")
+	if !strings.Contains(reply, "usage: ticker <exchange> [pair]") {
+		t.Errorf("test failed - Commander Handle() usage %q", reply)
+	}
+
+	reply, _ = c.Handle("/ticker kraken BTCUSD", "")
+	if !strings.HasPrefix(reply, "Kraken XBT-USD SPOT\nLast: 6500") {
+		t.Errorf("test failed - Commander Handle() ticker %q", reply)
+	}
+
+	reply, _ = c.Handle("/ticker bitstamp", "")
+	if !strings.Contains(reply, errExchangeNotFound.Error()) {
+		t.Errorf("test failed - Commander Handle() ticker unknown exchange %q", reply)
+	}
+
+	reply, _ = c.Handle("/ticker kraken LTC", "")
+	if !strings.Contains(reply, errInvalidPair.Error()) {
+		t.Errorf("test failed - Commander Handle() ticker invalid pair %q", reply)
+	}
+
+	reply, _ = c.Handle("/price btc/usd", "")
+	if !strings.Contains(reply, "GDAX BTCUSD: 6510") || !strings.Contains(reply, "Kraken XBT-USD: 6500") ||
+		!strings.HasSuffix(reply, "Average: 6505") {
+		t.Errorf("test failed - Commander Handle() price %q", reply)
+	}
+
+	reply, _ = c.Handle("/book gdax BTC-USD 1", "")
+	if !strings.Contains(reply, "Asks:\n1 @ 6511\nBids:\n3 @ 6509") {
+		t.Errorf("test failed - Commander Handle() book %q", reply)
+	}
+
+	reply, _ = c.Handle("/book gdax BTC-USD 100", "")
+	if !strings.Contains(reply, errInvalidDepth.Error()) {
+		t.Errorf("test failed - Commander Handle() book depth %q", reply)
+	}
+
+	reply, _ = c.Handle("/status gdax", "")
+	if !strings.HasPrefix(reply, "GDAX: 1 tickers, 1 orderbooks") {
+		t.Errorf("test failed - Commander Handle() status %q", reply)
+	}
+
+	c.Register(Command{
+		Name: "Echo",
+		Handler: func(c *Commander, req CommandRequest) (string, error) {
+			return "echo", nil
+		},
+	})
+	if reply, _ = c.Handle("/echo", ""); reply != "echo" {
+		t.Errorf("test failed - Commander Register() %q", reply)
+	}
+
+	c.Register(Command{
+		Name: "image",
+		ReplyHandler: func(c *Commander, req CommandRequest) (Reply, error) {
+			return Reply{Text: "caption", Image: []byte{1}}, nil
+		},
+	})
+	if r, _ := c.HandleReply("/image", ""); r.Text != "caption" || len(r.Image) != 1 {
+		t.Errorf("test failed - Commander HandleReply() %v", r)
+	}
+
+	if reply, _ = c.Handle("/image", ""); reply != "caption" {
+		t.Errorf("test failed - Commander Handle() image reply %q", reply)
+	}
+
+	if r, _ := c.HandleReply("/ticker", ""); !strings.Contains(r.Text, "usage") || r.Image != nil {
+		t.Errorf("test failed - Commander HandleReply() usage %v", r)
+	}
+}
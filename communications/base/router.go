@@ -0,0 +1,437 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+// Severity levels of an event in ascending order, events without a severity
+// are info
+const (
+	SeverityInfo     = config.NotificationSeverityInfo
+	SeverityWarning  = config.NotificationSeverityWarning
+	SeverityCritical = config.NotificationSeverityCritical
+
+	cmdAck = "ack"
+)
+
+var errAckNotFound = errors.New("no pending notification with this ID, it may have been escalated already")
+
+// Router applies the notification rules to events before pushing them to the
+// communication mediums
+type Router struct {
+	comm       IComm
+	cfg        config.NotificationsConfig
+	location   *time.Location
+	quietStart int
+	quietEnd   int
+
+	sent    map[string]time.Time
+	rates   map[string][]time.Time // keyed by rate limit index
+	pending map[int]*pendingEscalation
+	nextID  int
+	now     func() time.Time
+	mtx     sync.Mutex
+}
+
+// pendingEscalation is an event awaiting acknowledgement
+type pendingEscalation struct {
+	event     Event
+	timers    []*time.Timer
+	remaining int
+}
+
+// NewRouter returns a router applying the notification rules to the
+// communication mediums and registers the ack command if events can be
+// escalated
+func NewRouter(comm IComm, cfg config.NotificationsConfig) *Router {
+	r := &Router{
+		comm:     comm,
+		cfg:      cfg,
+		location: time.UTC,
+		sent:     make(map[string]time.Time),
+		rates:    make(map[string][]time.Time),
+		pending:  make(map[int]*pendingEscalation),
+		nextID:   1,
+		now:      time.Now,
+	}
+
+	if cfg.QuietHours.Enabled {
+		if loc, err := time.LoadLocation(cfg.QuietHours.Location); err == nil {
+			r.location = loc
+		}
+		r.quietStart = minuteOfDay(cfg.QuietHours.Start)
+		r.quietEnd = minuteOfDay(cfg.QuietHours.End)
+	}
+
+	if cfg.Enabled && len(cfg.Escalations) > 0 {
+		comm.RegisterCommand(Command{
+			Name:        cmdAck,
+			Args:        "<id>",
+			Description: "Acknowledges a notification so it is not escalated",
+			MinArgs:     1,
+			MaxArgs:     1,
+			Handler:     r.cmdAck,
+		})
+	}
+	return r
+}
+
+// minuteOfDay converts a 15:04 formatted time to minutes since midnight
+func minuteOfDay(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// severityLevel returns the order of a severity, unknown severities are
+// treated as info
+func severityLevel(severity string) int {
+	switch strings.ToLower(severity) {
+	case SeverityCritical:
+		return 2
+	case SeverityWarning:
+		return 1
+	}
+	return 0
+}
+
+// containsFold returns whether the list contains the value ignoring case, an
+// empty list matches every value
+func containsFold(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+
+	for i := range list {
+		if strings.EqualFold(list[i], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normaliseRoutePair removes the delimiters of a currency pair
+func normaliseRoutePair(p string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "_", "", "/", "").Replace(p))
+}
+
+// matchesRule returns whether an event has one of the types and at least the
+// minimum severity
+func matchesRule(event Event, eventTypes []string, minSeverity string) bool {
+	return containsFold(eventTypes, event.Type) &&
+		severityLevel(event.Severity) >= severityLevel(minSeverity)
+}
+
+// matchesRoute returns whether an event matches every filter of a route
+func matchesRoute(event Event, route config.NotificationRouteConfig) bool {
+	if !matchesRule(event, route.EventTypes, route.MinSeverity) ||
+		!containsFold(route.Exchanges, event.Exchange) {
+		return false
+	}
+
+	if len(route.Pairs) == 0 {
+		return true
+	}
+
+	for i := range route.Pairs {
+		if normaliseRoutePair(route.Pairs[i]) == normaliseRoutePair(event.Pair) {
+			return true
+		}
+	}
+	return false
+}
+
+// PushEvent pushes an event to the targets of the matching routes, or every
+// medium if none match, and schedules the escalations of the mediums which
+// delivered it. Events are pushed to every medium if routing is disabled
+func (r *Router) PushEvent(event Event) {
+	if !r.cfg.Enabled {
+		r.comm.PushEvent(event)
+		return
+	}
+
+	if event.Severity == "" {
+		event.Severity = SeverityInfo
+	}
+
+	r.mtx.Lock()
+	if r.isDuplicate(event, r.now()) {
+		r.mtx.Unlock()
+		log.Printf("Communications: dropped duplicate %s event %q.", event.Type, event.TradeDetails)
+		return
+	}
+	id, rules := r.reserve(event)
+	r.mtx.Unlock()
+
+	var ackMediums []string
+	for i := range rules {
+		ackMediums = append(ackMediums, rules[i].Medium)
+	}
+
+	delivered := make(map[string]bool)
+	for _, target := range r.targets(event) {
+		e := event
+		if len(ackMediums) > 0 && containsFold(ackMediums, target.Medium) {
+			e.TradeDetails = fmt.Sprintf("%s (send %s %d to acknowledge)", e.TradeDetails, cmdAck, id)
+		}
+
+		if r.deliver(target, e) {
+			delivered[strings.ToLower(target.Medium)] = true
+		}
+	}
+
+	if len(rules) > 0 {
+		r.schedule(id, rules, delivered)
+	}
+}
+
+// targets returns the unique targets of the routes matching an event, or
+// every connected medium if none match
+func (r *Router) targets(event Event) []config.NotificationTargetConfig {
+	var targets []config.NotificationTargetConfig
+	seen := make(map[string]bool)
+	for i := range r.cfg.Routes {
+		if !matchesRoute(event, r.cfg.Routes[i]) {
+			continue
+		}
+
+		for _, target := range r.cfg.Routes[i].Targets {
+			key := strings.ToLower(target.Medium) + "|" + target.Recipient
+			if !seen[key] {
+				seen[key] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	if len(targets) > 0 {
+		return targets
+	}
+
+	for i := range r.comm {
+		if r.comm[i].IsEnabled() && r.comm[i].IsConnected() {
+			targets = append(targets, config.NotificationTargetConfig{Medium: r.comm[i].GetName()})
+		}
+	}
+	return targets
+}
+
+// deliver pushes an event to a target unless it is held back by the quiet
+// hours or rate limit of the medium, returning whether it was delivered
+func (r *Router) deliver(target config.NotificationTargetConfig, event Event) bool {
+	now := r.now()
+	if r.isQuiet(target.Medium, event, now) {
+		log.Printf("Communications: %s event to %s suppressed during quiet hours.",
+			event.Type, target.Medium)
+		return false
+	}
+
+	r.mtx.Lock()
+	allowed := r.allow(target.Medium, now)
+	r.mtx.Unlock()
+	if !allowed {
+		log.Printf("Communications: %s event to %s dropped, rate limit reached.",
+			event.Type, target.Medium)
+		return false
+	}
+
+	var err error
+	if target.Recipient == "" {
+		err = r.comm.PushEventToMedium(target.Medium, event)
+	} else {
+		err = r.comm.PushEventTo(target.Medium, target.Recipient, event)
+	}
+
+	if err != nil {
+		log.Printf("Communications error - PushEvent() in package %s with %v. Err: %s",
+			target.Medium, event, err)
+		return false
+	}
+	return true
+}
+
+// isDuplicate returns whether an identical event was pushed within the dedup
+// window and records the event otherwise
+func (r *Router) isDuplicate(event Event, now time.Time) bool {
+	if r.cfg.DedupWindow <= 0 {
+		return false
+	}
+
+	for key, sent := range r.sent {
+		if now.Sub(sent) >= r.cfg.DedupWindow {
+			delete(r.sent, key)
+		}
+	}
+
+	key := strings.Join([]string{event.Type, event.Severity, event.Exchange,
+		event.Pair, event.TradeDetails, event.GainLoss}, "|")
+	if _, ok := r.sent[key]; ok {
+		return true
+	}
+	r.sent[key] = now
+	return false
+}
+
+// allow returns whether the rate limit of a medium permits another event and
+// records it
+func (r *Router) allow(medium string, now time.Time) bool {
+	for i, limit := range r.cfg.RateLimits {
+		if !strings.EqualFold(limit.Medium, medium) {
+			continue
+		}
+
+		key := strconv.Itoa(i)
+		var recent []time.Time
+		for _, sent := range r.rates[key] {
+			if now.Sub(sent) < limit.Interval {
+				recent = append(recent, sent)
+			}
+		}
+
+		if len(recent) >= limit.MaxEvents {
+			r.rates[key] = recent
+			return false
+		}
+		r.rates[key] = append(recent, now)
+	}
+	return true
+}
+
+// isQuiet returns whether an event to a medium is suppressed by the quiet
+// hours
+func (r *Router) isQuiet(medium string, event Event, now time.Time) bool {
+	quiet := r.cfg.QuietHours
+	if !quiet.Enabled || r.quietStart == r.quietEnd || !containsFold(quiet.Mediums, medium) {
+		return false
+	}
+
+	bypass := quiet.BypassSeverity
+	if bypass == "" {
+		bypass = SeverityCritical
+	}
+
+	if severityLevel(event.Severity) >= severityLevel(bypass) {
+		return false
+	}
+
+	local := now.In(r.location)
+	minute := local.Hour()*60 + local.Minute()
+	if r.quietStart < r.quietEnd {
+		return minute >= r.quietStart && minute < r.quietEnd
+	}
+	return minute >= r.quietStart || minute < r.quietEnd
+}
+
+// reserve registers a pending escalation for an event matching the
+// escalation rules, returning its acknowledgement ID and the matching rules.
+// The timers are started by schedule once the event has been delivered. The
+// caller must hold the lock
+func (r *Router) reserve(event Event) (int, []config.NotificationEscalationConfig) {
+	var rules []config.NotificationEscalationConfig
+	for i := range r.cfg.Escalations {
+		if matchesRule(event, r.cfg.Escalations[i].EventTypes, r.cfg.Escalations[i].MinSeverity) {
+			rules = append(rules, r.cfg.Escalations[i])
+		}
+	}
+
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.pending[id] = &pendingEscalation{event: event}
+	return id, rules
+}
+
+// schedule starts the timers of the escalation rules whose acknowledgement
+// medium delivered the event. The pending escalation is removed if none did
+// or it was acknowledged during the delivery
+func (r *Router) schedule(id int, rules []config.NotificationEscalationConfig, delivered map[string]bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	p, ok := r.pending[id]
+	if !ok {
+		return
+	}
+
+	for i := range rules {
+		rule := rules[i]
+		if !delivered[strings.ToLower(rule.Medium)] {
+			log.Printf("Communications: %s event not delivered to %s, escalation %s not scheduled.",
+				p.event.Type, rule.Medium, rule.Name)
+			continue
+		}
+
+		p.remaining++
+		p.timers = append(p.timers, time.AfterFunc(rule.Timeout, func() {
+			r.escalate(id, rule)
+		}))
+	}
+
+	if p.remaining == 0 {
+		delete(r.pending, id)
+	}
+}
+
+// escalate pushes an unacknowledged event to the escalation targets
+func (r *Router) escalate(id int, rule config.NotificationEscalationConfig) {
+	r.mtx.Lock()
+	p, ok := r.pending[id]
+	if ok {
+		p.remaining--
+		if p.remaining <= 0 {
+			delete(r.pending, id)
+		}
+	}
+	r.mtx.Unlock()
+
+	if !ok {
+		return
+	}
+
+	event := p.event
+	event.TradeDetails = fmt.Sprintf("Not acknowledged on %s within %s: %s",
+		rule.Medium, rule.Timeout, event.TradeDetails)
+	for _, target := range rule.Targets {
+		r.deliver(target, event)
+	}
+}
+
+// Acknowledge cancels the pending escalations of an event, returning false if
+// there are none
+func (r *Router) Acknowledge(id int) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	p, ok := r.pending[id]
+	if !ok {
+		return false
+	}
+
+	for _, timer := range p.timers {
+		timer.Stop()
+	}
+	delete(r.pending, id)
+	return true
+}
+
+// cmdAck acknowledges a notification
+func (r *Router) cmdAck(c *Commander, req CommandRequest) (string, error) {
+	id, err := strconv.Atoi(req.Args[0])
+	if err != nil || !r.Acknowledge(id) {
+		return "", errAckNotFound
+	}
+	return fmt.Sprintf("notification %d acknowledged", id), nil
+}
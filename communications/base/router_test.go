@@ -0,0 +1,223 @@
+package base
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+// testRouterComm records the events pushed and commands registered, or
+// fails to push events if err is set
+type testRouterComm struct {
+	Base
+	events     []Event
+	recipients []string
+	commands   []Command
+	err        error
+}
+
+func (c *testRouterComm) Setup(cfg config.CommunicationsConfig) {}
+func (c *testRouterComm) Connect() error                        { return nil }
+
+func (c *testRouterComm) PushEvent(event Event) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.events = append(c.events, event)
+	return nil
+}
+
+func (c *testRouterComm) PushEventTo(recipient string, event Event) error {
+	c.recipients = append(c.recipients, recipient)
+	return c.PushEvent(event)
+}
+
+func (c *testRouterComm) RegisterCommand(cmd Command) {
+	c.commands = append(c.commands, cmd)
+}
+
+func newTestRouter(cfg config.NotificationsConfig) (*Router, *testRouterComm, *testRouterComm, *time.Time) {
+	slack := &testRouterComm{Base: Base{Name: "Slack", Enabled: true, Connected: true}}
+	sms := &testRouterComm{Base: Base{Name: "SMSGlobal", Enabled: true, Connected: true}}
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	r := NewRouter(IComm{slack, sms}, cfg)
+	r.now = func() time.Time { return now }
+	return r, slack, sms, &now
+}
+
+func TestRouterRoutes(t *testing.T) {
+	r, slack, sms, _ := newTestRouter(config.NotificationsConfig{})
+	r.PushEvent(Event{Type: "ALERT"})
+	if len(slack.events) != 1 || len(sms.events) != 1 {
+		t.Error("test failed - Router PushEvent() disabled routing should push to every medium")
+	}
+
+	r, slack, sms, _ = newTestRouter(config.NotificationsConfig{
+		Enabled: true,
+		Routes: []config.NotificationRouteConfig{
+			{
+				Name:        "critical",
+				MinSeverity: SeverityCritical,
+				Targets:     []config.NotificationTargetConfig{{Medium: "smsglobal"}},
+			},
+			{
+				Name:      "bitstamp",
+				Exchanges: []string{"Bitstamp"},
+				Pairs:     []string{"BTC-USD"},
+				Targets:   []config.NotificationTargetConfig{{Medium: "Slack", Recipient: "alerts"}},
+			},
+		},
+	})
+
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityCritical, Exchange: "Kraken"})
+	if len(slack.events) != 0 || len(sms.events) != 1 {
+		t.Error("test failed - Router PushEvent() critical event not routed to SMS")
+	}
+
+	r.PushEvent(Event{Type: "EVENT", Exchange: "bitstamp", Pair: "BTCUSD"})
+	if len(slack.recipients) != 1 || slack.recipients[0] != "alerts" || len(sms.events) != 1 {
+		t.Error("test failed - Router PushEvent() event not routed to the slack recipient")
+	}
+
+	r.PushEvent(Event{Type: "EVENT", Exchange: "bitstamp", Pair: "ETHUSD"})
+	if len(slack.events) != 2 || len(slack.recipients) != 1 || len(sms.events) != 2 {
+		t.Error("test failed - Router PushEvent() unmatched event should push to every medium")
+	}
+}
+
+func TestRouterThrottling(t *testing.T) {
+	r, slack, sms, now := newTestRouter(config.NotificationsConfig{
+		Enabled:     true,
+		DedupWindow: time.Minute,
+		RateLimits: []config.NotificationRateLimitConfig{
+			{Medium: "SMSGlobal", MaxEvents: 2, Interval: time.Hour},
+		},
+	})
+
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "BTCUSD > 10000"})
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "BTCUSD > 10000"})
+	if len(slack.events) != 1 {
+		t.Error("test failed - Router PushEvent() duplicate event pushed")
+	}
+
+	*now = now.Add(time.Minute)
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "BTCUSD > 10000"})
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "ETHUSD > 1000"})
+	if len(slack.events) != 3 || len(sms.events) != 2 {
+		t.Errorf("test failed - Router PushEvent() rate limit error slack %d sms %d",
+			len(slack.events), len(sms.events))
+	}
+
+	*now = now.Add(time.Hour)
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "LTCUSD > 100"})
+	if len(sms.events) != 3 {
+		t.Error("test failed - Router PushEvent() rate limit should reset after the interval")
+	}
+}
+
+func TestRouterQuietHours(t *testing.T) {
+	r, slack, sms, now := newTestRouter(config.NotificationsConfig{
+		Enabled: true,
+		QuietHours: config.QuietHoursConfig{
+			Enabled:  true,
+			Start:    "22:00",
+			End:      "07:00",
+			Location: "Australia/Sydney",
+			Mediums:  []string{"SMSGlobal"},
+		},
+	})
+
+	// 12:00 UTC is 22:00 in Sydney
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityWarning})
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityCritical})
+	if len(slack.events) != 2 || len(sms.events) != 1 || sms.events[0].Severity != SeverityCritical {
+		t.Error("test failed - Router PushEvent() quiet hours error")
+	}
+
+	*now = now.Add(time.Hour * 9)
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityWarning})
+	if len(sms.events) != 2 {
+		t.Error("test failed - Router PushEvent() event suppressed outside quiet hours")
+	}
+}
+
+func TestRouterEscalation(t *testing.T) {
+	escalation := config.NotificationEscalationConfig{
+		Name:        "unacknowledged",
+		MinSeverity: SeverityWarning,
+		Medium:      "Slack",
+		Timeout:     time.Hour,
+		Targets:     []config.NotificationTargetConfig{{Medium: "SMSGlobal", Recipient: "bob"}},
+	}
+	r, slack, sms, _ := newTestRouter(config.NotificationsConfig{
+		Enabled:     true,
+		Routes:      []config.NotificationRouteConfig{{Name: "slack", Targets: []config.NotificationTargetConfig{{Medium: "Slack"}}}},
+		Escalations: []config.NotificationEscalationConfig{escalation},
+	})
+
+	if len(slack.commands) != 1 || slack.commands[0].Name != cmdAck {
+		t.Fatal("test failed - NewRouter() ack command not registered")
+	}
+
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityInfo, TradeDetails: "info"})
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityWarning, TradeDetails: "first"})
+	r.PushEvent(Event{Type: "EVENT", Severity: SeverityWarning, TradeDetails: "second"})
+	if len(slack.events) != 3 || !strings.Contains(slack.events[1].TradeDetails, "send ack 1") ||
+		strings.Contains(slack.events[0].TradeDetails, "ack") || len(r.pending) != 2 {
+		t.Fatalf("test failed - Router PushEvent() escalation not scheduled %v", slack.events)
+	}
+
+	handler := slack.commands[0].Handler
+	if reply, err := handler(nil, CommandRequest{Args: []string{"1"}}); err != nil ||
+		reply != "notification 1 acknowledged" {
+		t.Error("test failed - Router ack command error", err)
+	}
+
+	if _, err := handler(nil, CommandRequest{Args: []string{"1"}}); err != errAckNotFound {
+		t.Error("test failed - Router ack command acknowledged twice")
+	}
+
+	r.escalate(1, escalation)
+	r.escalate(2, escalation)
+	r.escalate(2, escalation)
+	if len(sms.events) != 1 || len(sms.recipients) != 1 || sms.recipients[0] != "bob" ||
+		!strings.Contains(sms.events[0].TradeDetails, "Not acknowledged on Slack within 1h0m0s: second") {
+		t.Errorf("test failed - Router escalate() error %v", sms.events)
+	}
+
+	if len(r.pending) != 0 {
+		t.Error("test failed - Router escalate() pending escalation not removed")
+	}
+}
+
+func TestRouterEscalationUndelivered(t *testing.T) {
+	r, slack, _, _ := newTestRouter(config.NotificationsConfig{
+		Enabled:    true,
+		Routes:     []config.NotificationRouteConfig{{Name: "slack", Targets: []config.NotificationTargetConfig{{Medium: "Slack"}}}},
+		RateLimits: []config.NotificationRateLimitConfig{{Medium: "Slack", MaxEvents: 1, Interval: time.Hour}},
+		Escalations: []config.NotificationEscalationConfig{{
+			Name:    "unacknowledged",
+			Medium:  "Slack",
+			Timeout: time.Hour,
+			Targets: []config.NotificationTargetConfig{{Medium: "SMSGlobal"}},
+		}},
+	})
+
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "first"})
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "rate limited"})
+	if len(slack.events) != 1 || len(r.pending) != 1 || r.pending[1] == nil {
+		t.Fatalf("test failed - Router PushEvent() scheduled an escalation for a dropped event %v", r.pending)
+	}
+
+	r.cfg.RateLimits = nil
+	slack.err = errors.New("unavailable")
+	r.PushEvent(Event{Type: "EVENT", TradeDetails: "failed"})
+	if len(r.pending) != 1 {
+		t.Errorf("test failed - Router PushEvent() scheduled an escalation for a failed delivery %v", r.pending)
+	}
+	r.Acknowledge(1)
+}
@@ -0,0 +1,178 @@
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// Communication mediums with their own event template
+const (
+	MediumSlack = "slack"
+	MediumEmail = "email"
+	MediumSMS   = "sms"
+
+	// SMSMaxLength is the length a rendered SMS event is truncated to
+	SMSMaxLength = 160
+
+	serviceName = "GoCryptoTrader"
+
+	// ColourGain and ColourLoss highlight events in chat embeds
+	ColourGain = 0x2ECC71
+	ColourLoss = 0xE74C3C
+)
+
+// Default event templates. The Slack template renders a JSON array of Slack
+// message blocks, the email template renders a HTML document and the SMS
+// template renders plain text
+const (
+	DefaultSlackTemplate = `[
+	{"type": "header", "text": {"type": "plain_text", "text": {{json (or .Type "Notification")}}}}
+	{{- if .TradeDetails}},
+	{"type": "section", "text": {"type": "mrkdwn", "text": {{json .TradeDetails}}}}
+	{{- end}}
+	{{- if .GainLoss}},
+	{"type": "section", "fields": [{"type": "mrkdwn", "text": {{json (printf "*Gain/Loss:*\n%s" .GainLoss)}}}]}
+	{{- end}},
+	{"type": "context", "elements": [{"type": "mrkdwn", "text": {{json (printf "%s | %s" .Service (.Time.Format "2006-01-02 15:04:05 MST"))}}}]}
+]`
+
+	DefaultEmailTemplate = `<html>
+<body style="font-family: sans-serif;">
+<h2>{{or .Type "Notification"}}</h2>
+{{if .TradeDetails}}<p>{{.TradeDetails}}</p>{{end}}
+{{if .GainLoss}}<p><strong>Gain/Loss:</strong> {{.GainLoss}}</p>{{end}}
+<p style="color: #888888;"><small>{{.Service}} - {{.Time.Format "2006-01-02 15:04:05 MST"}}</small></p>
+</body>
+</html>`
+
+	DefaultSMSTemplate = `{{with .Type}}{{.}}: {{end}}{{.TradeDetails}}{{with .GainLoss}} ({{.}}){{end}}`
+)
+
+var (
+	errEmptyEvent     = errors.New("event has no type or details")
+	errUnknownMedium  = errors.New("unknown communication medium")
+	errInvalidBlocks  = errors.New("slack template did not render a JSON array of blocks")
+	eventTemplates    map[string]executor
+	eventTemplatesMtx sync.RWMutex
+)
+
+// EventData is the value event templates are executed with
+type EventData struct {
+	Event
+	Service string
+	Time    time.Time
+}
+
+// executor is satisfied by both text and HTML templates
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+var templateFuncs = map[string]interface{}{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func init() {
+	eventTemplates = make(map[string]executor)
+	for medium, text := range map[string]string{
+		MediumSlack: DefaultSlackTemplate,
+		MediumEmail: DefaultEmailTemplate,
+		MediumSMS:   DefaultSMSTemplate,
+	} {
+		if err := SetEventTemplate(medium, text); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// parseEventTemplate parses a template for the supplied medium, email
+// templates are parsed as HTML so event details are escaped
+func parseEventTemplate(medium, text string) (executor, error) {
+	switch medium {
+	case MediumEmail:
+		return htmltemplate.New(medium).Funcs(templateFuncs).Parse(text)
+	case MediumSlack, MediumSMS:
+		return template.New(medium).Funcs(templateFuncs).Parse(text)
+	}
+	return nil, errUnknownMedium
+}
+
+// SetEventTemplate replaces the event template used by a communication
+// medium
+func SetEventTemplate(medium, text string) error {
+	tmpl, err := parseEventTemplate(medium, text)
+	if err != nil {
+		return fmt.Errorf("communications %s template: %s", medium, err)
+	}
+
+	eventTemplatesMtx.Lock()
+	eventTemplates[medium] = tmpl
+	eventTemplatesMtx.Unlock()
+	return nil
+}
+
+// RenderEvent formats an event for the supplied communication medium
+func RenderEvent(medium string, event Event) (string, error) {
+	if event.Type == "" && event.TradeDetails == "" {
+		return "", errEmptyEvent
+	}
+
+	eventTemplatesMtx.RLock()
+	tmpl, ok := eventTemplates[medium]
+	eventTemplatesMtx.RUnlock()
+	if !ok {
+		return "", errUnknownMedium
+	}
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, EventData{
+		Event:   event,
+		Service: serviceName,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch medium {
+	case MediumSlack:
+		var blocks []json.RawMessage
+		if err = json.Unmarshal(buf.Bytes(), &blocks); err != nil {
+			return "", errInvalidBlocks
+		}
+	case MediumSMS:
+		text := []rune(buf.String())
+		if len(text) > SMSMaxLength {
+			return string(text[:SMSMaxLength-3]) + "...", nil
+		}
+	}
+	return buf.String(), nil
+}
+
+// EventSubject returns a short subject line for an event
+func EventSubject(event Event) string {
+	if event.Type == "" {
+		return serviceName + " notification"
+	}
+	return fmt.Sprintf("%s %s notification", serviceName, event.Type)
+}
+
+// EventColour returns the RGB colour used to highlight an event in chat
+// embeds, red for losses and green otherwise
+func EventColour(event Event) int {
+	if strings.HasPrefix(strings.TrimSpace(event.GainLoss), "-") {
+		return ColourLoss
+	}
+	return ColourGain
+}
@@ -0,0 +1,100 @@
+package base
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRenderEvent(t *testing.T) {
+	if _, err := RenderEvent(MediumSMS, Event{}); err != errEmptyEvent {
+		t.Error("test failed - RenderEvent() should reject empty events")
+	}
+
+	event := Event{
+		Type:         "ARBITRAGE",
+		TradeDetails: `BTC/USD buy on "GDAX" <sell> on Kraken`,
+		GainLoss:     "1.5%",
+	}
+
+	if _, err := RenderEvent("pigeon", event); err != errUnknownMedium {
+		t.Error("test failed - RenderEvent() should reject unknown mediums")
+	}
+
+	sms, err := RenderEvent(MediumSMS, event)
+	if err != nil || sms != `ARBITRAGE: BTC/USD buy on "GDAX" <sell> on Kraken (1.5%)` {
+		t.Errorf("test failed - RenderEvent() sms %q %v", sms, err)
+	}
+
+	email, err := RenderEvent(MediumEmail, event)
+	if err != nil || !strings.Contains(email, "<h2>ARBITRAGE</h2>") ||
+		!strings.Contains(email, "&lt;sell&gt;") {
+		t.Errorf("test failed - RenderEvent() email %q %v", email, err)
+	}
+
+	slack, err := RenderEvent(MediumSlack, event)
+	if err != nil {
+		t.Fatal("test failed - RenderEvent() slack error", err)
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text struct {
+			Text string `json:"text"`
+		} `json:"text"`
+	}
+	err = json.Unmarshal([]byte(slack), &blocks)
+	if err != nil || len(blocks) != 4 || blocks[0].Text.Text != "ARBITRAGE" ||
+		blocks[1].Text.Text != event.TradeDetails || blocks[3].Type != "context" {
+		t.Errorf("test failed - RenderEvent() slack blocks %s %v", slack, err)
+	}
+
+	slack, err = RenderEvent(MediumSlack, Event{TradeDetails: "triggered"})
+	if err != nil || !strings.Contains(slack, `"Notification"`) ||
+		strings.Contains(slack, "Gain/Loss") {
+		t.Errorf("test failed - RenderEvent() slack without type %s %v", slack, err)
+	}
+
+	long, err := RenderEvent(MediumSMS, Event{TradeDetails: strings.Repeat("a", 200)})
+	if err != nil || len(long) != SMSMaxLength || !strings.HasSuffix(long, "...") {
+		t.Errorf("test failed - RenderEvent() sms should be truncated, got %d", len(long))
+	}
+}
+
+func TestSetEventTemplate(t *testing.T) {
+	defer SetEventTemplate(MediumSMS, DefaultSMSTemplate)
+	defer SetEventTemplate(MediumSlack, DefaultSlackTemplate)
+
+	if err := SetEventTemplate(MediumSMS, "{{.Type"); err == nil {
+		t.Error("test failed - SetEventTemplate() should reject invalid templates")
+	}
+
+	if err := SetEventTemplate("pigeon", "{{.Type}}"); err == nil {
+		t.Error("test failed - SetEventTemplate() should reject unknown mediums")
+	}
+
+	if err := SetEventTemplate(MediumSMS, "{{.Service}} {{.TradeDetails}}"); err != nil {
+		t.Fatal("test failed - SetEventTemplate() error", err)
+	}
+
+	sms, err := RenderEvent(MediumSMS, Event{TradeDetails: "triggered"})
+	if err != nil || sms != "GoCryptoTrader triggered" {
+		t.Errorf("test failed - RenderEvent() custom sms %q %v", sms, err)
+	}
+
+	if err = SetEventTemplate(MediumSlack, "{{.TradeDetails}}"); err != nil {
+		t.Fatal("test failed - SetEventTemplate() error", err)
+	}
+
+	if _, err = RenderEvent(MediumSlack, Event{TradeDetails: "triggered"}); err != errInvalidBlocks {
+		t.Error("test failed - RenderEvent() should reject invalid slack blocks", err)
+	}
+}
+
+func TestEventColour(t *testing.T) {
+	if EventColour(Event{GainLoss: " -1.5%"}) != ColourLoss ||
+		EventColour(Event{GainLoss: "1.5%"}) != ColourGain ||
+		EventColour(Event{}) != ColourGain {
+		t.Error("test failed - EventColour() error")
+	}
+}
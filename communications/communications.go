@@ -1,7 +1,11 @@
 package communications
 
 import (
+	"log"
+
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/discord"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/mattermost"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/slack"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/smsglobal"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/smtpservice"
@@ -12,12 +16,20 @@ import (
 // Communications is the overarching type across the communications packages
 type Communications struct {
 	base.IComm
+	router        *base.Router
+	imageRenderer func(base.Event) []byte
 }
 
 // NewComm sets up and returns a pointer to a Communications object
 func NewComm(config config.CommunicationsConfig) *Communications {
 	var comm Communications
 
+	for medium, text := range config.EventTemplates {
+		if err := base.SetEventTemplate(medium, text); err != nil {
+			log.Printf("Communications: using default event template. Err: %s", err)
+		}
+	}
+
 	if config.TelegramConfig.Enabled {
 		Telegram := new(telegram.Telegram)
 		Telegram.Setup(config)
@@ -42,6 +54,51 @@ func NewComm(config config.CommunicationsConfig) *Communications {
 		comm.IComm = append(comm.IComm, Slack)
 	}
 
+	if config.DiscordConfig.Enabled {
+		Discord := new(discord.Discord)
+		Discord.Setup(config)
+		comm.IComm = append(comm.IComm, Discord)
+	}
+
+	if config.MattermostConfig.Enabled {
+		Mattermost := new(mattermost.Mattermost)
+		Mattermost.Setup(config)
+		comm.IComm = append(comm.IComm, Mattermost)
+	}
+
 	comm.Setup()
+	comm.router = base.NewRouter(comm.IComm, config.Notifications)
 	return &comm
 }
+
+// SetImageRenderer sets the function returning the image, such as a price
+// chart, attached to events without one. It returns nil if the event has no
+// image
+func (c *Communications) SetImageRenderer(fn func(base.Event) []byte) {
+	c.imageRenderer = fn
+}
+
+// attachImage renders the image of an event without one
+func (c *Communications) attachImage(event base.Event) base.Event {
+	if c.imageRenderer != nil && len(event.Image) == 0 {
+		event.Image = c.imageRenderer(event)
+	}
+	return event
+}
+
+// PushEventTo attaches an image to an event and pushes it to a recipient of
+// the named communication medium
+func (c *Communications) PushEventTo(medium, recipient string, event base.Event) error {
+	return c.IComm.PushEventTo(medium, recipient, c.attachImage(event))
+}
+
+// PushEvent applies the notification rules to an event and pushes it to the
+// communication mediums
+func (c *Communications) PushEvent(event base.Event) {
+	event = c.attachImage(event)
+	if c.router == nil {
+		c.IComm.PushEvent(event)
+		return
+	}
+	c.router.PushEvent(event)
+}
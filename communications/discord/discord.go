@@ -0,0 +1,317 @@
+// Package discord is used to push events to a Discord channel via a webhook
+// as defined in https://discordapp.com/developers/docs/resources/webhook and
+// optionally handle chat commands sent to a channel using the bot API
+package discord
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+const (
+	talkRoot = "GoCryptoTrader bot"
+	botName  = "GoCryptoTrader"
+
+	// maxMessageLength is the maximum length of a Discord message
+	maxMessageLength = 2000
+
+	pollInterval = time.Second * 5
+)
+
+// apiURL is the Discord REST API endpoint, replaced in tests
+var apiURL = "https://discordapp.com/api/v6"
+
+var (
+	errNoWebhook      = errors.New("discord webhook URL not set")
+	errNoBot          = errors.New("discord bot token not set")
+	errUnknownChannel = errors.New("discord channel is not handled by the bot")
+)
+
+// Discord is the overarching type across this package
+type Discord struct {
+	base.Base
+	WebhookURL    string
+	BotToken      string
+	ChannelID     string
+	BotUserID     string
+	LastMessageID string
+
+	commander *base.Commander
+}
+
+// Setup takes in a Discord configuration and sets the webhook and the
+// optional bot credentials
+func (d *Discord) Setup(config config.CommunicationsConfig) {
+	d.Name = config.DiscordConfig.Name
+	d.Enabled = config.DiscordConfig.Enabled
+	d.Verbose = config.DiscordConfig.Verbose
+	d.WebhookURL = config.DiscordConfig.WebhookURL
+	d.BotToken = config.DiscordConfig.BotToken
+	d.ChannelID = config.DiscordConfig.ChannelID
+	d.commander = base.NewCommander(botName, d.Name, config.DiscordConfig.AuthorisedUsers)
+}
+
+// Connect verifies the bot token and starts polling the channel for
+// commands if a bot is configured
+func (d *Discord) Connect() error {
+	if d.WebhookURL == "" {
+		return errNoWebhook
+	}
+
+	if d.BotToken != "" {
+		if err := d.TestConnection(); err != nil {
+			return err
+		}
+		go d.PollerStart()
+	}
+
+	d.Connected = true
+	return nil
+}
+
+// PushEvent sends an event embed via the webhook
+func (d *Discord) PushEvent(event base.Event) error {
+	return d.PostWebhook(WebhookMessage{
+		Username: botName,
+		Embeds:   []Embed{newEmbed(event)},
+	})
+}
+
+// PushEventTo sends an event embed to the channel handled by the bot
+func (d *Discord) PushEventTo(recipient string, event base.Event) error {
+	if d.BotToken == "" {
+		return errNoBot
+	}
+
+	if recipient != d.ChannelID {
+		return errUnknownChannel
+	}
+
+	embed := newEmbed(event)
+	return d.SendMessage(recipient, CreateMessage{Embed: &embed})
+}
+
+// RegisterCommand adds a chat command to the bot
+func (d *Discord) RegisterCommand(cmd base.Command) {
+	d.commander.Register(cmd)
+}
+
+// newEmbed returns the embed for an event
+func newEmbed(event base.Event) Embed {
+	embed := Embed{
+		Title:       event.Type,
+		Description: event.TradeDetails,
+		Color:       base.EventColour(event),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339),
+		Footer:      &EmbedFooter{Text: talkRoot},
+	}
+
+	if embed.Title == "" {
+		embed.Title = base.EventSubject(event)
+	}
+
+	if event.GainLoss != "" {
+		embed.Fields = append(embed.Fields, EmbedField{
+			Name:   "Gain/Loss",
+			Value:  event.GainLoss,
+			Inline: true,
+		})
+	}
+	return embed
+}
+
+// truncate shortens text to the maximum message length
+func truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) > maxMessageLength {
+		return string(runes[:maxMessageLength-3]) + "..."
+	}
+	return text
+}
+
+// PollerStart polls the channel for commands, API errors are logged and
+// retried with an exponential backoff
+func (d *Discord) PollerStart() {
+	var backoff time.Duration
+	for {
+		err := d.InitialConnect()
+		if err == nil {
+			break
+		}
+
+		backoff = base.NextBackoff(backoff)
+		log.Printf("Discord: initial connection failed, retrying in %s. Err: %s", backoff, err)
+		time.Sleep(backoff)
+	}
+
+	backoff = 0
+	for {
+		err := d.Poll()
+		if err != nil {
+			backoff = base.NextBackoff(backoff)
+			log.Printf("Discord: unable to get messages, retrying in %s. Err: %s", backoff, err)
+			time.Sleep(backoff)
+			continue
+		}
+		backoff = 0
+		time.Sleep(pollInterval)
+	}
+}
+
+// InitialConnect skips the messages sent while the bot was offline
+func (d *Discord) InitialConnect() error {
+	messages, err := d.GetMessages("", 1)
+	if err != nil {
+		return err
+	}
+
+	if len(messages) > 0 {
+		d.LastMessageID = messages[0].ID
+	}
+	return nil
+}
+
+// Poll handles the messages sent to the channel since the last poll
+func (d *Discord) Poll() error {
+	messages, err := d.GetMessages(d.LastMessageID, 0)
+	if err != nil {
+		return err
+	}
+
+	// messages are returned newest first
+	for i := len(messages) - 1; i >= 0; i-- {
+		d.LastMessageID = messages[i].ID
+		err = d.HandleMessage(messages[i])
+		if err != nil {
+			log.Printf("Discord: unable to handle message %s. Err: %s", messages[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// HandleMessage replies to commands sent by users other than bots
+func (d *Discord) HandleMessage(msg Message) error {
+	if msg.Author.Bot || msg.Author.ID == d.BotUserID {
+		return nil
+	}
+
+	reply, ok := d.commander.Handle(msg.Content, msg.ChannelID, msg.Author.ID, msg.Author.Username)
+	if !ok {
+		return nil
+	}
+	return d.SendMessage(msg.ChannelID, CreateMessage{
+		Content: truncate(fmt.Sprintf("%s: %s", talkRoot, reply)),
+	})
+}
+
+// TestConnection tests the bot token and stores the bot user ID
+func (d *Discord) TestConnection() error {
+	var user User
+	err := d.SendHTTPRequest("GET", apiURL+"/users/@me", nil, &user)
+	if err != nil {
+		return err
+	}
+
+	d.BotUserID = user.ID
+	return nil
+}
+
+// GetMessages returns the channel messages following the message ID, newest
+// first. A limit of zero uses the API default
+func (d *Discord) GetMessages(after string, limit int) ([]Message, error) {
+	params := url.Values{}
+	if after != "" {
+		params.Set("after", after)
+	}
+	if limit > 0 {
+		params.Set("limit", fmt.Sprintf("%d", limit))
+	}
+
+	path := fmt.Sprintf("%s/channels/%s/messages", apiURL, d.ChannelID)
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var messages []Message
+	err := d.SendHTTPRequest("GET", path, nil, &messages)
+	return messages, err
+}
+
+// SendMessage sends a message to a channel as the bot
+func (d *Discord) SendMessage(channelID string, msg CreateMessage) error {
+	path := fmt.Sprintf("%s/channels/%s/messages", apiURL, channelID)
+	return d.SendHTTPRequest("POST", path, msg, nil)
+}
+
+// PostWebhook sends a message via the webhook
+func (d *Discord) PostWebhook(msg WebhookMessage) error {
+	json, err := common.JSONEncode(msg)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	headers["content-type"] = "application/json"
+
+	resp, err := common.SendHTTPRequest("POST", d.WebhookURL, headers, bytes.NewBuffer(json))
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, nil)
+}
+
+// SendHTTPRequest sends a request authenticated with the bot token
+func (d *Discord) SendHTTPRequest(method, path string, data, result interface{}) error {
+	if d.BotToken == "" {
+		return errNoBot
+	}
+
+	headers := make(map[string]string)
+	headers["Authorization"] = "Bot " + d.BotToken
+
+	body := new(bytes.Buffer)
+	if data != nil {
+		json, err := common.JSONEncode(data)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(json)
+		headers["content-type"] = "application/json"
+	}
+
+	resp, err := common.SendHTTPRequest(method, path, headers, body)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, result)
+}
+
+// decodeResponse returns the API error in the response if there is one,
+// otherwise it decodes the response into the result
+func decodeResponse(resp string, result interface{}) error {
+	resp = strings.TrimSpace(resp)
+	if resp == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(resp, "{") {
+		var apiErr APIError
+		if err := common.JSONDecode([]byte(resp), &apiErr); err == nil && apiErr.Message != "" {
+			return fmt.Errorf("discord API error %d: %s", apiErr.Code, apiErr.Message)
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return common.JSONDecode([]byte(resp), result)
+}
@@ -0,0 +1,125 @@
+package discord
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+var d Discord
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	d.Setup(cfg.GetCommunicationsConfig())
+
+	if d.Name != "Discord" || d.WebhookURL == "" || d.BotToken != "" {
+		t.Error("test failed - discord Setup() error")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	var received WebhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Error("test failed - discord webhook requests should not be authenticated")
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	discord := Discord{WebhookURL: server.URL}
+	err := discord.PushEvent(base.Event{Type: "ALERT", TradeDetails: "BTCUSD > 10000", GainLoss: "-5%"})
+	if err != nil {
+		t.Fatal("test failed - discord PushEvent() error", err)
+	}
+
+	if len(received.Embeds) != 1 || received.Embeds[0].Title != "ALERT" ||
+		received.Embeds[0].Color != base.ColourLoss || len(received.Embeds[0].Fields) != 1 {
+		t.Errorf("test failed - discord PushEvent() unexpected payload %+v", received)
+	}
+
+	if err = discord.PushEventTo("1", base.Event{}); err != errNoBot {
+		t.Error("test failed - discord PushEventTo() without a bot error", err)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	var replies []CreateMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"code": 0, "message": "401: Unauthorized"}`))
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/users/@me":
+			w.Write([]byte(`{"id": "99", "username": "gct", "bot": true}`))
+		case r.Method == "GET" && r.URL.Path == "/channels/10/messages":
+			if r.URL.Query().Get("after") == "3" {
+				w.Write([]byte(`[]`))
+				return
+			}
+			w.Write([]byte(`[
+				{"id": "3", "channel_id": "10", "content": "!help", "author": {"id": "99", "username": "gct", "bot": true}},
+				{"id": "2", "channel_id": "10", "content": "!status", "author": {"id": "2", "username": "mallory"}},
+				{"id": "1", "channel_id": "10", "content": "hello", "author": {"id": "1", "username": "alice"}}
+			]`))
+		case r.Method == "POST" && r.URL.Path == "/channels/10/messages":
+			var msg CreateMessage
+			json.NewDecoder(r.Body).Decode(&msg)
+			replies = append(replies, msg)
+			w.Write([]byte(`{"id": "4", "channel_id": "10"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	backup := apiURL
+	apiURL = server.URL
+	defer func() { apiURL = backup }()
+
+	discord := Discord{BotToken: "invalid", ChannelID: "10"}
+	discord.Setup(config.CommunicationsConfig{DiscordConfig: config.DiscordConfig{
+		Name:            "Discord",
+		Enabled:         true,
+		BotToken:        "invalid",
+		ChannelID:       "10",
+		AuthorisedUsers: []string{"alice"},
+	}})
+
+	if err := discord.TestConnection(); err == nil || !strings.Contains(err.Error(), "Unauthorized") {
+		t.Error("test failed - discord TestConnection() should return the API error", err)
+	}
+
+	discord.BotToken = "token"
+	if err := discord.TestConnection(); err != nil || discord.BotUserID != "99" {
+		t.Fatal("test failed - discord TestConnection() error", err)
+	}
+
+	if err := discord.Poll(); err != nil {
+		t.Fatal("test failed - discord Poll() error", err)
+	}
+
+	if discord.LastMessageID != "3" || len(replies) != 1 ||
+		!strings.Contains(replies[0].Content, "not authorised") {
+		t.Errorf("test failed - discord Poll() unexpected replies %+v", replies)
+	}
+
+	if err := discord.PushEventTo("11", base.Event{}); err != errUnknownChannel {
+		t.Error("test failed - discord PushEventTo() sent to an unknown channel", err)
+	}
+
+	if err := discord.PushEventTo("10", base.Event{Type: "ALERT"}); err != nil ||
+		len(replies) != 2 || replies[1].Embed == nil || replies[1].Embed.Title != "ALERT" {
+		t.Error("test failed - discord PushEventTo() error", err)
+	}
+}
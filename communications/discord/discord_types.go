@@ -0,0 +1,57 @@
+package discord
+
+// WebhookMessage is the payload posted to a Discord webhook
+type WebhookMessage struct {
+	Username string  `json:"username,omitempty"`
+	Content  string  `json:"content,omitempty"`
+	Embeds   []Embed `json:"embeds,omitempty"`
+}
+
+// CreateMessage is the payload posted to a channel by the bot
+type CreateMessage struct {
+	Content string `json:"content,omitempty"`
+	Embed   *Embed `json:"embed,omitempty"`
+}
+
+// Embed holds rich message content
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+}
+
+// EmbedField holds a named embed value
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// EmbedFooter holds the embed footer text
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// User holds Discord user information
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Bot      bool   `json:"bot"`
+}
+
+// Message holds a channel message
+type Message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+	Content   string `json:"content"`
+	Author    User   `json:"author"`
+}
+
+// APIError holds an error returned by the Discord API
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,339 @@
+// Package mattermost is used to push events to a Mattermost channel via an
+// incoming webhook as defined in
+// https://docs.mattermost.com/developer/webhooks-incoming.html and
+// optionally handle chat commands sent to a channel using the API v4
+package mattermost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+const (
+	talkRoot = "GoCryptoTrader bot"
+	botName  = "GoCryptoTrader"
+
+	apiPath = "/api/v4"
+
+	pollInterval = time.Second * 5
+)
+
+var (
+	errNoWebhook      = errors.New("mattermost webhook URL not set")
+	errNoBot          = errors.New("mattermost bot token not set")
+	errUnknownChannel = errors.New("mattermost channel is not handled by the bot")
+)
+
+// Mattermost is the overarching type across this package
+type Mattermost struct {
+	base.Base
+	WebhookURL   string
+	ServerURL    string
+	BotToken     string
+	ChannelID    string
+	BotUserID    string
+	LastPostTime int64
+
+	commander *base.Commander
+	usernames map[string]string
+}
+
+// Setup takes in a Mattermost configuration and sets the webhook and the
+// optional bot credentials
+func (m *Mattermost) Setup(config config.CommunicationsConfig) {
+	m.Name = config.MattermostConfig.Name
+	m.Enabled = config.MattermostConfig.Enabled
+	m.Verbose = config.MattermostConfig.Verbose
+	m.WebhookURL = config.MattermostConfig.WebhookURL
+	m.ServerURL = strings.TrimSuffix(config.MattermostConfig.ServerURL, "/")
+	m.BotToken = config.MattermostConfig.BotToken
+	m.ChannelID = config.MattermostConfig.ChannelID
+	m.commander = base.NewCommander(botName, m.Name, config.MattermostConfig.AuthorisedUsers)
+	m.usernames = make(map[string]string)
+}
+
+// Connect verifies the bot token and starts polling the channel for
+// commands if a bot is configured
+func (m *Mattermost) Connect() error {
+	if m.WebhookURL == "" {
+		return errNoWebhook
+	}
+
+	if m.BotToken != "" {
+		if err := m.TestConnection(); err != nil {
+			return err
+		}
+		go m.PollerStart()
+	}
+
+	m.Connected = true
+	return nil
+}
+
+// PushEvent sends an event attachment via the webhook
+func (m *Mattermost) PushEvent(event base.Event) error {
+	return m.PostWebhook(WebhookMessage{
+		Username:    botName,
+		Attachments: []Attachment{newAttachment(event)},
+	})
+}
+
+// PushEventTo sends an event attachment to the channel handled by the bot
+func (m *Mattermost) PushEventTo(recipient string, event base.Event) error {
+	if m.BotToken == "" {
+		return errNoBot
+	}
+
+	if recipient != m.ChannelID {
+		return errUnknownChannel
+	}
+
+	return m.CreatePost(CreatePost{
+		ChannelID: recipient,
+		Props:     PostProps{Attachments: []Attachment{newAttachment(event)}},
+	})
+}
+
+// RegisterCommand adds a chat command to the bot
+func (m *Mattermost) RegisterCommand(cmd base.Command) {
+	m.commander.Register(cmd)
+}
+
+// newAttachment returns the message attachment for an event
+func newAttachment(event base.Event) Attachment {
+	attachment := Attachment{
+		Fallback: fmt.Sprintf("%s: %s", base.EventSubject(event), event.TradeDetails),
+		Color:    fmt.Sprintf("#%06X", base.EventColour(event)),
+		Title:    event.Type,
+		Text:     event.TradeDetails,
+		Footer:   talkRoot,
+	}
+
+	if attachment.Title == "" {
+		attachment.Title = base.EventSubject(event)
+	}
+
+	if event.GainLoss != "" {
+		attachment.Fields = append(attachment.Fields, AttachmentField{
+			Short: true,
+			Title: "Gain/Loss",
+			Value: event.GainLoss,
+		})
+	}
+	return attachment
+}
+
+// PollerStart polls the channel for commands, API errors are logged and
+// retried with an exponential backoff
+func (m *Mattermost) PollerStart() {
+	var backoff time.Duration
+	for {
+		err := m.InitialConnect()
+		if err == nil {
+			break
+		}
+
+		backoff = base.NextBackoff(backoff)
+		log.Printf("Mattermost: initial connection failed, retrying in %s. Err: %s", backoff, err)
+		time.Sleep(backoff)
+	}
+
+	backoff = 0
+	for {
+		err := m.Poll()
+		if err != nil {
+			backoff = base.NextBackoff(backoff)
+			log.Printf("Mattermost: unable to get posts, retrying in %s. Err: %s", backoff, err)
+			time.Sleep(backoff)
+			continue
+		}
+		backoff = 0
+		time.Sleep(pollInterval)
+	}
+}
+
+// InitialConnect skips the posts sent while the bot was offline
+func (m *Mattermost) InitialConnect() error {
+	var list PostList
+	path := fmt.Sprintf("%s%s/channels/%s/posts?per_page=1", m.ServerURL, apiPath, m.ChannelID)
+	err := m.SendHTTPRequest("GET", path, nil, &list)
+	if err != nil {
+		return err
+	}
+
+	for _, post := range list.Posts {
+		if post.CreateAt > m.LastPostTime {
+			m.LastPostTime = post.CreateAt
+		}
+	}
+	return nil
+}
+
+// Poll handles the posts created in the channel since the last poll
+func (m *Mattermost) Poll() error {
+	posts, err := m.GetPosts(m.LastPostTime)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		m.LastPostTime = posts[i].CreateAt
+		err = m.HandlePost(posts[i])
+		if err != nil {
+			log.Printf("Mattermost: unable to handle post %s. Err: %s", posts[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// HandlePost replies to commands sent by users other than the bot
+func (m *Mattermost) HandlePost(post Post) error {
+	if post.UserID == m.BotUserID || post.Type != "" {
+		return nil
+	}
+
+	if _, ok := base.ParseCommand(post.Message); !ok {
+		return nil
+	}
+
+	users := []string{post.UserID}
+	if name := m.getUsername(post.UserID); name != "" {
+		users = append(users, name)
+	}
+
+	reply, _ := m.commander.Handle(post.Message, post.ChannelID, users...)
+	return m.CreatePost(CreatePost{
+		ChannelID: post.ChannelID,
+		Message:   fmt.Sprintf("%s: %s", talkRoot, reply),
+	})
+}
+
+// getUsername returns the cached username of a user, looking it up if
+// unknown
+func (m *Mattermost) getUsername(userID string) string {
+	if name, ok := m.usernames[userID]; ok {
+		return name
+	}
+
+	var user User
+	err := m.SendHTTPRequest("GET", fmt.Sprintf("%s%s/users/%s", m.ServerURL, apiPath, userID), nil, &user)
+	if err != nil {
+		log.Printf("Mattermost: unable to get user %s. Err: %s", userID, err)
+		return ""
+	}
+
+	m.usernames[userID] = user.Username
+	return user.Username
+}
+
+// TestConnection tests the bot token and stores the bot user ID
+func (m *Mattermost) TestConnection() error {
+	var user User
+	err := m.SendHTTPRequest("GET", m.ServerURL+apiPath+"/users/me", nil, &user)
+	if err != nil {
+		return err
+	}
+
+	m.BotUserID = user.ID
+	return nil
+}
+
+// GetPosts returns the channel posts created after the time in milliseconds,
+// oldest first
+func (m *Mattermost) GetPosts(since int64) ([]Post, error) {
+	var list PostList
+	path := fmt.Sprintf("%s%s/channels/%s/posts?since=%d", m.ServerURL, apiPath, m.ChannelID, since)
+	err := m.SendHTTPRequest("GET", path, nil, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	// posts edited since are also returned
+	var posts []Post
+	for _, post := range list.Posts {
+		if post.CreateAt > since {
+			posts = append(posts, post)
+		}
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreateAt < posts[j].CreateAt
+	})
+	return posts, nil
+}
+
+// CreatePost sends a post to a channel as the bot
+func (m *Mattermost) CreatePost(post CreatePost) error {
+	return m.SendHTTPRequest("POST", m.ServerURL+apiPath+"/posts", post, nil)
+}
+
+// PostWebhook sends a message via the webhook
+func (m *Mattermost) PostWebhook(msg WebhookMessage) error {
+	json, err := common.JSONEncode(msg)
+	if err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	headers["content-type"] = "application/json"
+
+	resp, err := common.SendHTTPRequest("POST", m.WebhookURL, headers, bytes.NewBuffer(json))
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(resp) != "ok" {
+		return decodeResponse(resp, nil, fmt.Errorf("mattermost webhook error: %s", resp))
+	}
+	return nil
+}
+
+// SendHTTPRequest sends a request authenticated with the bot token
+func (m *Mattermost) SendHTTPRequest(method, path string, data, result interface{}) error {
+	if m.BotToken == "" {
+		return errNoBot
+	}
+
+	headers := make(map[string]string)
+	headers["Authorization"] = "Bearer " + m.BotToken
+
+	body := new(bytes.Buffer)
+	if data != nil {
+		json, err := common.JSONEncode(data)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(json)
+		headers["content-type"] = "application/json"
+	}
+
+	resp, err := common.SendHTTPRequest(method, path, headers, body)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(resp, result, nil)
+}
+
+// decodeResponse returns the API error in the response if there is one,
+// otherwise it decodes the response into the result. The fallback error is
+// returned if the response is not an API error
+func decodeResponse(resp string, result interface{}, fallback error) error {
+	var apiErr APIError
+	if err := common.JSONDecode([]byte(resp), &apiErr); err == nil && apiErr.StatusCode != 0 {
+		return fmt.Errorf("mattermost API error %d: %s", apiErr.StatusCode, apiErr.Message)
+	}
+
+	if fallback != nil || result == nil {
+		return fallback
+	}
+	return common.JSONDecode([]byte(resp), result)
+}
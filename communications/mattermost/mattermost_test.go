@@ -0,0 +1,129 @@
+package mattermost
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+var m Mattermost
+
+func TestSetup(t *testing.T) {
+	cfg := config.GetConfig()
+	cfg.LoadConfig("../../testdata/configtest.json")
+	m.Setup(cfg.GetCommunicationsConfig())
+
+	if m.Name != "Mattermost" || m.WebhookURL == "" || m.BotToken != "" {
+		t.Error("test failed - mattermost Setup() error")
+	}
+}
+
+func TestPushEvent(t *testing.T) {
+	var received WebhookMessage
+	response := "ok"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	mattermost := Mattermost{WebhookURL: server.URL}
+	err := mattermost.PushEvent(base.Event{Type: "ALERT", TradeDetails: "BTCUSD > 10000", GainLoss: "5%"})
+	if err != nil {
+		t.Fatal("test failed - mattermost PushEvent() error", err)
+	}
+
+	if len(received.Attachments) != 1 || received.Attachments[0].Title != "ALERT" ||
+		received.Attachments[0].Color != "#2ECC71" || len(received.Attachments[0].Fields) != 1 {
+		t.Errorf("test failed - mattermost PushEvent() unexpected payload %+v", received)
+	}
+
+	response = `{"id": "web.incoming_webhook.invalid.app_error", "message": "Invalid webhook", "status_code": 400}`
+	if err = mattermost.PushEvent(base.Event{}); err == nil || !strings.Contains(err.Error(), "Invalid webhook") {
+		t.Error("test failed - mattermost PushEvent() should return the API error", err)
+	}
+
+	if err = mattermost.PushEventTo("1", base.Event{}); err != errNoBot {
+		t.Error("test failed - mattermost PushEventTo() without a bot error", err)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	var replies []CreatePost
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"id": "api.context.session_expired.app_error", "message": "Invalid or expired session", "status_code": 401}`))
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v4/users/me":
+			w.Write([]byte(`{"id": "bot", "username": "gct"}`))
+		case r.URL.Path == "/api/v4/users/u1":
+			w.Write([]byte(`{"id": "u1", "username": "alice"}`))
+		case r.URL.Path == "/api/v4/users/u2":
+			w.Write([]byte(`{"id": "u2", "username": "mallory"}`))
+		case r.URL.Path == "/api/v4/channels/c1/posts" && r.URL.Query().Get("since") == "100":
+			w.Write([]byte(`{"order": ["p4", "p3", "p2", "p1"], "posts": {
+				"p1": {"id": "p1", "create_at": 90, "user_id": "u1", "channel_id": "c1", "message": "!help"},
+				"p2": {"id": "p2", "create_at": 110, "user_id": "u2", "channel_id": "c1", "message": "!status"},
+				"p3": {"id": "p3", "create_at": 120, "user_id": "u1", "channel_id": "c1", "message": "!help"},
+				"p4": {"id": "p4", "create_at": 130, "user_id": "bot", "channel_id": "c1", "message": "!help"}
+			}}`))
+		case r.Method == "POST" && r.URL.Path == "/api/v4/posts":
+			var post CreatePost
+			json.NewDecoder(r.Body).Decode(&post)
+			replies = append(replies, post)
+			w.Write([]byte(`{"id": "p5", "channel_id": "c1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found", "status_code": 404}`))
+		}
+	}))
+	defer server.Close()
+
+	mattermost := Mattermost{}
+	mattermost.Setup(config.CommunicationsConfig{MattermostConfig: config.MattermostConfig{
+		Name:            "Mattermost",
+		Enabled:         true,
+		ServerURL:       server.URL + "/",
+		BotToken:        "invalid",
+		ChannelID:       "c1",
+		AuthorisedUsers: []string{"alice"},
+	}})
+
+	if err := mattermost.TestConnection(); err == nil || !strings.Contains(err.Error(), "expired session") {
+		t.Error("test failed - mattermost TestConnection() should return the API error", err)
+	}
+
+	mattermost.BotToken = "token"
+	if err := mattermost.TestConnection(); err != nil || mattermost.BotUserID != "bot" {
+		t.Fatal("test failed - mattermost TestConnection() error", err)
+	}
+
+	mattermost.LastPostTime = 100
+	if err := mattermost.Poll(); err != nil {
+		t.Fatal("test failed - mattermost Poll() error", err)
+	}
+
+	if mattermost.LastPostTime != 130 || len(replies) != 2 ||
+		!strings.Contains(replies[0].Message, "not authorised") ||
+		!strings.Contains(replies[1].Message, "help") {
+		t.Errorf("test failed - mattermost Poll() unexpected replies %+v", replies)
+	}
+
+	if err := mattermost.PushEventTo("c2", base.Event{}); err != errUnknownChannel {
+		t.Error("test failed - mattermost PushEventTo() sent to an unknown channel", err)
+	}
+
+	if err := mattermost.PushEventTo("c1", base.Event{Type: "ALERT"}); err != nil ||
+		len(replies) != 3 || len(replies[2].Props.Attachments) != 1 {
+		t.Error("test failed - mattermost PushEventTo() error", err)
+	}
+}
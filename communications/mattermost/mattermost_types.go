@@ -0,0 +1,66 @@
+package mattermost
+
+// WebhookMessage is the payload posted to a Mattermost incoming webhook
+type WebhookMessage struct {
+	Username    string       `json:"username,omitempty"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// CreatePost is the payload posted to a channel by the bot
+type CreatePost struct {
+	ChannelID string    `json:"channel_id"`
+	Message   string    `json:"message"`
+	Props     PostProps `json:"props,omitempty"`
+}
+
+// PostProps holds the attachments of a post
+type PostProps struct {
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment holds rich message content
+type Attachment struct {
+	Fallback string            `json:"fallback"`
+	Color    string            `json:"color,omitempty"`
+	Title    string            `json:"title,omitempty"`
+	Text     string            `json:"text,omitempty"`
+	Fields   []AttachmentField `json:"fields,omitempty"`
+	Footer   string            `json:"footer,omitempty"`
+}
+
+// AttachmentField holds a named attachment value
+type AttachmentField struct {
+	Short bool   `json:"short"`
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// User holds Mattermost user information
+type User struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// Post holds a channel post
+type Post struct {
+	ID        string `json:"id"`
+	CreateAt  int64  `json:"create_at"`
+	UserID    string `json:"user_id"`
+	ChannelID string `json:"channel_id"`
+	Message   string `json:"message"`
+	Type      string `json:"type"`
+}
+
+// PostList holds channel posts, the order lists the post IDs newest first
+type PostList struct {
+	Order []string        `json:"order"`
+	Posts map[string]Post `json:"posts"`
+}
+
+// APIError holds an error returned by the Mattermost API
+type APIError struct {
+	ID         string `json:"id"`
+	Message    string `json:"message"`
+	StatusCode int    `json:"status_code"`
+}
@@ -4,12 +4,16 @@
 package slack
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,26 +23,14 @@ import (
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
 )
 
-// const declares main slack url and commands that will be supported on client
-// side
+// const declares main slack urls and the bot name
 const (
-	SlackURL = "https://slack.com/api/rtm.start"
-
-	cmdStatus    = "!status"
-	cmdHelp      = "!help"
-	cmdSettings  = "!settings"
-	cmdTicker    = "!ticker"
-	cmdPortfolio = "!portfolio"
-	cmdOrderbook = "!orderbook"
-
-	getHelp = `GoCryptoTrader SlackBot, thank you for using this service!
-	Current commands are:
-	!status 		- Displays current working status of bot
-	!help 			- Displays help text
-	!settings		- Displays current settings
-	!ticker			- Displays recent ANX ticker
-	!portfolio	- Displays portfolio data
-	!orderbook	- Displays current ANX orderbook`
+	SlackURL            = "https://slack.com/api/rtm.start"
+	SlackPostMessageURL = "https://slack.com/api/chat.postMessage"
+	SlackFilesUploadURL = "https://slack.com/api/files.upload"
+
+	botName       = "GoCryptoTrader SlackBot"
+	imageFileName = "chart.png"
 )
 
 // Slack starts a websocket connection and uses https://api.slack.com/rtm real
@@ -56,6 +48,8 @@ type Slack struct {
 	Connected       bool
 	Shutdown        bool
 	sync.Mutex
+
+	commander *base.Commander
 }
 
 // Setup takes in a slack configuration, sets bots target channel and
@@ -66,6 +60,7 @@ func (s *Slack) Setup(config config.CommunicationsConfig) {
 	s.Verbose = config.SlackConfig.Verbose
 	s.TargetChannel = config.SlackConfig.TargetChannel
 	s.VerificationToken = config.SlackConfig.VerificationToken
+	s.commander = base.NewCommander(botName, s.Name, config.SlackConfig.AuthorisedUsers)
 }
 
 // Connect connects to the service
@@ -77,9 +72,170 @@ func (s *Slack) Connect() error {
 	return nil
 }
 
-// PushEvent pushes an event to either a slack channel or specific client
-func (s *Slack) PushEvent(base.Event) error {
-	return errors.New("not yet implemented")
+// postMessageURL and filesUploadURL are the Web API endpoints, replaced in
+// tests
+var (
+	postMessageURL = SlackPostMessageURL
+	filesUploadURL = SlackFilesUploadURL
+)
+
+// PushEvent pushes an event to the target channel as message blocks using
+// chat.postMessage, falling back to a plain RTM message if that fails. The
+// event image is uploaded to the channel after the message
+func (s *Slack) PushEvent(event base.Event) error {
+	blocks, err := base.RenderEvent(base.MediumSlack, event)
+	if err != nil {
+		return err
+	}
+
+	text, err := base.RenderEvent(base.MediumSMS, event)
+	if err != nil {
+		return err
+	}
+
+	channel := s.TargetChannelID
+	if channel == "" {
+		channel = s.TargetChannel
+	}
+
+	err = s.PostMessage(channel, text, blocks)
+	if err != nil {
+		if s.WebsocketConn == nil {
+			return err
+		}
+
+		if s.Verbose {
+			log.Printf("Slack chat.postMessage failed, sending via RTM. Err: %s", err)
+		}
+
+		if err = s.WebsocketSend("message", text); err != nil {
+			return err
+		}
+	}
+	return s.uploadEventImage(channel, event)
+}
+
+// PushEventTo pushes an event to a single channel or direct message
+func (s *Slack) PushEventTo(recipient string, event base.Event) error {
+	blocks, err := base.RenderEvent(base.MediumSlack, event)
+	if err != nil {
+		return err
+	}
+
+	text, err := base.RenderEvent(base.MediumSMS, event)
+	if err != nil {
+		return err
+	}
+
+	if err = s.PostMessage(recipient, text, blocks); err != nil {
+		return err
+	}
+	return s.uploadEventImage(recipient, event)
+}
+
+// uploadEventImage uploads the image of an event, if it has one, titled with
+// its exchange and currency pair
+func (s *Slack) uploadEventImage(channel string, event base.Event) error {
+	if len(event.Image) == 0 {
+		return nil
+	}
+	return s.UploadImage(channel, strings.TrimSpace(event.Exchange+" "+event.Pair), "", event.Image)
+}
+
+// RegisterCommand adds a chat command to the bot
+func (s *Slack) RegisterCommand(cmd base.Command) {
+	s.commander.Register(cmd)
+}
+
+// PostMessage sends a message with optional JSON encoded blocks to a channel
+// via the Web API
+func (s *Slack) PostMessage(channel, text, blocks string) error {
+	values := url.Values{}
+	values.Set("token", s.VerificationToken)
+	values.Set("channel", channel)
+	values.Set("text", text)
+	if blocks != "" {
+		values.Set("blocks", blocks)
+	}
+
+	headers := make(map[string]string)
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+
+	resp, err := common.SendHTTPRequest("POST",
+		postMessageURL,
+		headers,
+		strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+
+	var result PostMessageResponse
+	err = common.JSONDecode([]byte(resp), &result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Ok {
+		return fmt.Errorf("slack chat.postMessage error: %s", result.Error)
+	}
+	return nil
+}
+
+// UploadImage uploads a PNG image with an optional title and comment to a
+// channel via the Web API
+func (s *Slack) UploadImage(channel, title, comment string, image []byte) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"token":           s.VerificationToken,
+		"channels":        channel,
+		"filename":        imageFileName,
+		"filetype":        "png",
+		"title":           title,
+		"initial_comment": comment,
+	}
+
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("file", imageFileName)
+	if err != nil {
+		return err
+	}
+
+	if _, err = part.Write(image); err != nil {
+		return err
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	headers["Content-Type"] = writer.FormDataContentType()
+
+	resp, err := common.SendHTTPRequest("POST", filesUploadURL, headers, &body)
+	if err != nil {
+		return err
+	}
+
+	var result PostMessageResponse
+	err = common.JSONDecode([]byte(resp), &result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Ok {
+		return fmt.Errorf("slack files.upload error: %s", result.Error)
+	}
+	return nil
 }
 
 // BuildURL returns an appended token string with the SlackURL
@@ -275,9 +431,7 @@ func (s *Slack) WebsocketReader() {
 					s.GetUsernameByID(msg.User),
 					msg.User, msg.Text)
 			}
-			if string(msg.Text[0]) == "!" {
-				s.HandleMessage(msg)
-			}
+			s.HandleMessage(msg)
 
 		case "pong":
 			if s.Verbose {
@@ -301,14 +455,20 @@ func (s *Slack) WebsocketKeepAlive() {
 	}
 }
 
-// WebsocketSend sends a message via the websocket connection
+// WebsocketSend sends a message to the target channel via the websocket
+// connection
 func (s *Slack) WebsocketSend(eventType, text string) error {
+	return s.WebsocketSendTo(eventType, s.TargetChannelID, text)
+}
+
+// WebsocketSendTo sends a message to a channel via the websocket connection
+func (s *Slack) WebsocketSendTo(eventType, channel, text string) error {
 	s.Lock()
 	defer s.Unlock()
 	newMessage := SendMessage{
 		ID:      time.Now().Unix(),
 		Type:    eventType,
-		Channel: s.TargetChannelID,
+		Channel: channel,
 		Text:    text,
 	}
 	data, err := json.Marshal(newMessage)
@@ -319,28 +479,21 @@ func (s *Slack) WebsocketSend(eventType, text string) error {
 }
 
 // HandleMessage handles incoming messages and/or commands from slack
-func (s Slack) HandleMessage(msg Message) {
-	switch {
-	case common.StringContains(msg.Text, cmdStatus):
-		s.WebsocketSend("message", s.GetStatus())
-
-	case common.StringContains(msg.Text, cmdHelp):
-		s.WebsocketSend("message", getHelp)
-
-	case common.StringContains(msg.Text, cmdTicker):
-		s.WebsocketSend("message", s.GetTicker("ANX"))
-
-	case common.StringContains(msg.Text, cmdOrderbook):
-		s.WebsocketSend("message", s.GetOrderbook("ANX"))
-
-	case common.StringContains(msg.Text, cmdSettings):
-		s.WebsocketSend("message", s.GetSettings())
+func (s *Slack) HandleMessage(msg Message) {
+	reply, ok := s.commander.HandleReply(msg.Text, msg.Channel, msg.User, s.GetUsernameByID(msg.User))
+	if !ok {
+		return
+	}
 
-	case common.StringContains(msg.Text, cmdPortfolio):
-		s.WebsocketSend("message", s.GetPortfolio())
+	if len(reply.Image) > 0 {
+		if err := s.UploadImage(msg.Channel, "", reply.Text, reply.Image); err != nil {
+			log.Println("slack HandleMessage() error", err)
+		}
+		return
+	}
 
-	default:
-		s.WebsocketSend("message", "GoCryptoTrader SlackBot - Command Unknown!")
+	if err := s.WebsocketSendTo("message", msg.Channel, reply.Text); err != nil {
+		log.Println("slack HandleMessage() error", err)
 	}
 }
 
@@ -1,6 +1,11 @@
 package slack
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
@@ -32,6 +37,68 @@ func TestPushEvent(t *testing.T) {
 	if err == nil {
 		t.Error("test failed - slack PushEvent() error")
 	}
+
+	var values url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		values = r.PostForm
+		if values.Get("channel") == "invalid" {
+			w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok": true, "channel": "C1234", "ts": "1503435956.000247"}`))
+	}))
+	defer server.Close()
+
+	defer func(url string) { postMessageURL = url }(postMessageURL)
+	postMessageURL = server.URL
+
+	err = s.PushEvent(base.Event{Type: "TEST", TradeDetails: "triggered"})
+	if err != nil {
+		t.Fatal("test failed - slack PushEvent() error", err)
+	}
+
+	if values.Get("channel") != s.TargetChannel || values.Get("text") != "TEST: triggered" ||
+		!strings.Contains(values.Get("blocks"), `"header"`) {
+		t.Errorf("test failed - slack PushEvent() unexpected request %v", values)
+	}
+
+	s.TargetChannelID = "invalid"
+	err = s.PushEvent(base.Event{Type: "TEST", TradeDetails: "triggered"})
+	s.TargetChannelID = ""
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Error("test failed - slack PushEvent() should return API errors", err)
+	}
+}
+
+func TestPushEventImage(t *testing.T) {
+	var uploads []url.Values
+	var file []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/upload" {
+			r.ParseMultipartForm(1 << 20)
+			uploads = append(uploads, url.Values(r.MultipartForm.Value))
+			if f, _, err := r.FormFile("file"); err == nil {
+				file, _ = ioutil.ReadAll(f)
+			}
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	defer func(post, upload string) { postMessageURL, filesUploadURL = post, upload }(postMessageURL, filesUploadURL)
+	postMessageURL, filesUploadURL = server.URL+"/post", server.URL+"/upload"
+
+	err := s.PushEventTo("C1234", base.Event{Type: "ALERT", TradeDetails: "triggered"})
+	if err != nil || len(uploads) != 0 {
+		t.Fatal("test failed - slack PushEventTo() uploaded without an image", err)
+	}
+
+	err = s.PushEventTo("C1234", base.Event{Type: "ALERT", Exchange: "Bitstamp", Pair: "BTCUSD", Image: []byte("png")})
+	if err != nil || len(uploads) != 1 || uploads[0].Get("channels") != "C1234" ||
+		uploads[0].Get("title") != "Bitstamp BTCUSD" || string(file) != "png" {
+		t.Errorf("test failed - slack PushEventTo() image upload error %v %v", err, uploads)
+	}
 }
 
 func TestBuildURL(t *testing.T) {
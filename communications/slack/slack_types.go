@@ -18,6 +18,14 @@ type SendMessage struct {
 	Text    string `json:"text"`
 }
 
+// PostMessageResponse is the chat.postMessage and files.upload response
+type PostMessageResponse struct {
+	Ok      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+}
+
 // Message is a response type handling message data
 type Message struct {
 	Channel    string  `json:"channel"`
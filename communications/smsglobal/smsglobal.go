@@ -59,8 +59,12 @@ func (s *SMSGlobal) Connect() error {
 }
 
 // PushEvent pushes an event to a contact list via SMS
-func (s *SMSGlobal) PushEvent(base.Event) error {
-	return errors.New("not yet implemented")
+func (s *SMSGlobal) PushEvent(event base.Event) error {
+	message, err := base.RenderEvent(base.MediumSMS, event)
+	if err != nil {
+		return err
+	}
+	return s.SendMessageToAll(message)
 }
 
 // GetEnabledContacts returns how many SMS contacts are enabled in the
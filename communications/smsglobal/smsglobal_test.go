@@ -28,6 +28,11 @@ func TestPushEvent(t *testing.T) {
 	if err == nil {
 		t.Error("test failed - SMSGlobal PushEvent() error")
 	}
+
+	err = s.PushEvent(base.Event{Type: "TEST", TradeDetails: "triggered"})
+	if err != nil {
+		t.Error("test failed - SMSGlobal PushEvent() error", err)
+	}
 }
 
 func TestGetEnabledContacts(t *testing.T) {
@@ -44,9 +44,14 @@ func (s *SMTPservice) Connect() error {
 	return nil
 }
 
-// PushEvent sends an event to supplied recipient list via SMTP
-func (s *SMTPservice) PushEvent(base.Event) error {
-	return errors.New("not yet implemented")
+// PushEvent sends an event to supplied recipient list via SMTP as a HTML
+// email
+func (s *SMTPservice) PushEvent(event base.Event) error {
+	body, err := base.RenderEvent(base.MediumEmail, event)
+	if err != nil {
+		return err
+	}
+	return s.Send(base.EventSubject(event), body)
 }
 
 // Send sends an email template to the recipient list via your SMTP host when
@@ -5,9 +5,16 @@ package telegram
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"mime/multipart"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
@@ -15,31 +22,36 @@ import (
 )
 
 const (
-	apiURL = "https://api.telegram.org/bot%s/%s"
-
 	methodGetMe       = "getMe"
 	methodGetUpdates  = "getUpdates"
 	methodSendMessage = "sendMessage"
+	methodSendPhoto   = "sendPhoto"
 
-	cmdStart     = "/start"
-	cmdStatus    = "/status"
-	cmdHelp      = "/help"
-	cmdSettings  = "/settings"
-	cmdTicker    = "/ticker"
-	cmdPortfolio = "/portfolio"
-	cmdOrders    = "/orderbooks"
-
-	cmdHelpReply = `GoCryptoTrader TelegramBot, thank you for using this service!
-	Current commands are:
-	/start  		- Will authenticate your ID
-	/status 		- Displays the status of the bot
-	/help 			- Displays current command list
-	/settings 	- Displays current bot settings
-	/ticker 		- Displays current ANX ticker data
-	/portfolio	- Displays your current portfolio
-	/orderbooks - Displays current orderbooks for ANX`
+	// maxCaptionLength is the longest photo caption, longer texts are sent
+	// as a message before the photo
+	maxCaptionLength = 1024
+	imageFileName    = "chart.png"
 
 	talkRoot = "GoCryptoTrader bot"
+	botName  = "GoCryptoTrader TelegramBot"
+
+	cmdStart = "start"
+	cmdPair  = "pair"
+
+	// pollTimeout is the getUpdates long polling timeout in seconds, it must
+	// be shorter than the HTTP client timeout
+	pollTimeout = 3
+
+	// PairingCodeValidity is how long a pairing code can be used for
+	PairingCodeValidity = time.Minute * 10
+)
+
+// apiURL is the bot API endpoint format, replaced in tests
+var apiURL = "https://api.telegram.org/bot%s/%s"
+
+var (
+	errUnknownChat        = errors.New("this chat is not authorised, send /pair <code> using the pairing code from the GoCryptoTrader logs")
+	errInvalidPairingCode = errors.New("invalid or expired pairing code")
 )
 
 // Telegram is the overarching type across this package
@@ -48,14 +60,41 @@ type Telegram struct {
 	Token             string
 	Offset            int64
 	AuthorisedClients []int64
+
+	commander      *base.Commander
+	pairingCode    string
+	pairingExpiry  time.Time
+	persistHandler func([]int64)
+	mtx            sync.Mutex
 }
 
-// Setup takes in a Telegram configuration and sets verification token
+// Setup takes in a Telegram configuration and sets verification token and
+// the chats authorised to use the bot
 func (t *Telegram) Setup(config config.CommunicationsConfig) {
 	t.Name = config.TelegramConfig.Name
 	t.Enabled = config.TelegramConfig.Enabled
 	t.Token = config.TelegramConfig.VerificationToken
 	t.Verbose = config.TelegramConfig.Verbose
+	t.AuthorisedClients = append([]int64(nil), config.TelegramConfig.AuthorisedClients...)
+
+	t.commander = base.NewCommander(botName, t.Name, config.TelegramConfig.AuthorisedUsers)
+	t.commander.Register(base.Command{
+		Name:        cmdStart,
+		Args:        "[code]",
+		Description: "Authorises this chat with a pairing code",
+		MaxArgs:     1,
+		Public:      true,
+		Handler:     t.cmdStart,
+	})
+	t.commander.Register(base.Command{
+		Name:        cmdPair,
+		Args:        "<code>",
+		Description: "Authorises this chat with a pairing code",
+		MinArgs:     1,
+		MaxArgs:     1,
+		Public:      true,
+		Handler:     t.cmdPair,
+	})
 }
 
 // Connect starts an initial connection
@@ -64,15 +103,129 @@ func (t *Telegram) Connect() error {
 		return err
 	}
 	t.Connected = true
+
+	if len(t.GetAuthorisedClients()) == 0 {
+		if _, _, err := t.NewPairingCode(); err != nil {
+			log.Printf("Telegram: unable to create a pairing code. Err: %s", err)
+		}
+	}
+
 	go t.PollerStart()
 	return nil
 }
 
-// PushEvent sends an event to a supplied recipient list via telegram
-func (t *Telegram) PushEvent(event base.Event) error {
+// SetPersistHandler sets the function which stores the authorised chats
+// whenever a chat is paired or removed
+func (t *Telegram) SetPersistHandler(fn func([]int64)) {
+	t.mtx.Lock()
+	t.persistHandler = fn
+	t.mtx.Unlock()
+}
+
+// persist passes the authorised chats to the persist handler
+func (t *Telegram) persist() {
+	t.mtx.Lock()
+	fn := t.persistHandler
+	t.mtx.Unlock()
+
+	if fn != nil {
+		fn(t.GetAuthorisedClients())
+	}
+}
+
+// GetAuthorisedClients returns a copy of the authorised chat IDs
+func (t *Telegram) GetAuthorisedClients() []int64 {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return append([]int64(nil), t.AuthorisedClients...)
+}
+
+// IsAuthorisedClient returns whether or not a chat is authorised
+func (t *Telegram) IsAuthorisedClient(chatID int64) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for i := range t.AuthorisedClients {
+		if t.AuthorisedClients[i] == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAuthorisedClient removes an authorised chat, returning false if the
+// chat was not authorised
+func (t *Telegram) RemoveAuthorisedClient(chatID int64) bool {
+	t.mtx.Lock()
+	var removed bool
 	for i := range t.AuthorisedClients {
-		err := t.SendMessage(fmt.Sprintf("Type: %s Details: %s GainOrLoss: %s",
-			event.Type, event.TradeDetails, event.GainLoss), t.AuthorisedClients[i])
+		if t.AuthorisedClients[i] == chatID {
+			t.AuthorisedClients = append(t.AuthorisedClients[:i], t.AuthorisedClients[i+1:]...)
+			removed = true
+			break
+		}
+	}
+	t.mtx.Unlock()
+
+	if removed {
+		t.persist()
+	}
+	return removed
+}
+
+// NewPairingCode creates a one-time code which authorises the chat it is sent
+// from, replacing any previous code
+func (t *Telegram) NewPairingCode() (string, time.Time, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+
+	code := common.StringToUpper(hex.EncodeToString(b))
+	expiry := time.Now().Add(PairingCodeValidity)
+
+	t.mtx.Lock()
+	t.pairingCode = code
+	t.pairingExpiry = expiry
+	t.mtx.Unlock()
+
+	log.Printf("Telegram: send /pair %s to the bot before %s to authorise a chat.",
+		code, expiry.Format(time.RFC1123))
+	return code, expiry, nil
+}
+
+// Pair authorises a chat if the code matches the current pairing code, the
+// code can only be used once
+func (t *Telegram) Pair(chatID int64, code string) error {
+	t.mtx.Lock()
+	if t.pairingCode == "" || time.Now().After(t.pairingExpiry) ||
+		subtle.ConstantTimeCompare([]byte(common.StringToUpper(code)), []byte(t.pairingCode)) != 1 {
+		t.mtx.Unlock()
+		return errInvalidPairingCode
+	}
+
+	t.pairingCode = ""
+	var exists bool
+	for i := range t.AuthorisedClients {
+		if t.AuthorisedClients[i] == chatID {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		t.AuthorisedClients = append(t.AuthorisedClients, chatID)
+	}
+	t.mtx.Unlock()
+
+	log.Printf("Telegram: chat %d authorised.", chatID)
+	t.persist()
+	return nil
+}
+
+// PushEvent sends an event to the authorised chats via telegram
+func (t *Telegram) PushEvent(event base.Event) error {
+	clients := t.GetAuthorisedClients()
+	for i := range clients {
+		err := t.send(formatEvent(event), event.Image, clients[i])
 		if err != nil {
 			return err
 		}
@@ -80,95 +233,189 @@ func (t *Telegram) PushEvent(event base.Event) error {
 	return nil
 }
 
-// PollerStart starts the long polling sequence
+// PushEventTo sends an event to a single authorised chat
+func (t *Telegram) PushEventTo(recipient string, event base.Event) error {
+	chatID, err := strconv.ParseInt(recipient, 10, 64)
+	if err != nil {
+		return fmt.Errorf("telegram invalid chat ID %s", recipient)
+	}
+
+	if !t.IsAuthorisedClient(chatID) {
+		return errUnknownChat
+	}
+	return t.send(formatEvent(event), event.Image, chatID)
+}
+
+// send sends a message to a chat, or a photo captioned with the message if
+// an image is supplied
+func (t *Telegram) send(text string, image []byte, chatID int64) error {
+	if len(image) == 0 {
+		return t.SendMessage(text, chatID)
+	}
+
+	if len([]rune(text)) > maxCaptionLength {
+		if err := t.SendMessage(text, chatID); err != nil {
+			return err
+		}
+		text = ""
+	}
+	return t.SendPhoto(image, text, chatID)
+}
+
+// RegisterCommand adds a chat command to the bot
+func (t *Telegram) RegisterCommand(cmd base.Command) {
+	t.commander.Register(cmd)
+}
+
+func formatEvent(event base.Event) string {
+	return fmt.Sprintf("Type: %s Details: %s GainOrLoss: %s",
+		event.Type, event.TradeDetails, event.GainLoss)
+}
+
+// PollerStart starts the long polling sequence, API errors are logged and
+// retried with an exponential backoff
 func (t *Telegram) PollerStart() {
-	t.InitialConnect()
+	var backoff time.Duration
+	for {
+		err := t.InitialConnect()
+		if err == nil {
+			break
+		}
+
+		backoff = base.NextBackoff(backoff)
+		log.Printf("Telegram: initial connection failed, retrying in %s. Err: %s", backoff, err)
+		time.Sleep(backoff)
+	}
 
+	backoff = 0
 	for {
 		resp, err := t.GetUpdates()
+		if err == nil && !resp.Ok {
+			err = errors.New(resp.Description)
+		}
+
 		if err != nil {
-			log.Fatal(err)
+			backoff = base.NextBackoff(backoff)
+			log.Printf("Telegram: unable to get updates, retrying in %s. Err: %s", backoff, err)
+			time.Sleep(backoff)
+			continue
 		}
+		backoff = 0
 
 		for i := range resp.Result {
-			if resp.Result[i].UpdateID > t.Offset {
-				if string(resp.Result[i].Message.Text[0]) == "/" {
-					err = t.HandleMessages(resp.Result[i].Message.Text, resp.Result[i].Message.From.ID)
-					if err != nil {
-						log.Fatal(err)
-					}
-				}
-				t.Offset = resp.Result[i].UpdateID
+			if resp.Result[i].UpdateID <= t.Offset {
+				continue
+			}
+			t.Offset = resp.Result[i].UpdateID
+
+			msg := resp.Result[i].Message
+			err = t.HandleMessages(msg.Text, msg.Chat.ID, msg.From.ID, msg.From.UserName)
+			if err != nil {
+				log.Printf("Telegram: unable to handle message from chat %d. Err: %s", msg.Chat.ID, err)
 			}
 		}
 	}
 }
 
-// InitialConnect sets offset, and sends a welcome greeting to any associated
-// IDs
-func (t *Telegram) InitialConnect() {
+// InitialConnect skips the updates received while the bot was offline and
+// sends a greeting to the authorised chats
+func (t *Telegram) InitialConnect() error {
 	resp, err := t.GetUpdates()
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	if !resp.Ok {
-		log.Fatal(resp.Description)
+		return errors.New(resp.Description)
 	}
 
-	warmWelcomeList := make(map[string]int64)
-	for i := range resp.Result {
-		if resp.Result[i].Message.From.ID != 0 {
-			warmWelcomeList[resp.Result[i].Message.From.UserName] = resp.Result[i].Message.From.ID
-		}
+	if len(resp.Result) > 0 {
+		t.Offset = resp.Result[len(resp.Result)-1].UpdateID
 	}
 
-	for userName, ID := range warmWelcomeList {
-		err = t.SendMessage(fmt.Sprintf("GoCryptoTrader bot has connected: Hello, %s!", userName), ID)
+	clients := t.GetAuthorisedClients()
+	for i := range clients {
+		err = t.SendMessage(fmt.Sprintf("%s has connected.", talkRoot), clients[i])
 		if err != nil {
-			log.Fatal(err)
+			log.Printf("Telegram: unable to greet chat %d. Err: %s", clients[i], err)
 		}
 	}
-	if len(resp.Result) == 0 {
-		return
-	}
-	t.Offset = resp.Result[len(resp.Result)-1].UpdateID
+	return nil
 }
 
-// HandleMessages handles incoming message from the long polling routine
-func (t *Telegram) HandleMessages(text string, chatID int64) error {
-	switch {
-	case common.StringContains(text, cmdHelp):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, cmdHelpReply), chatID)
+// HandleMessages handles incoming message from the long polling routine.
+// Commands from chats which are not authorised are rejected unless they pair
+// the chat
+func (t *Telegram) HandleMessages(text string, chatID, userID int64, userName string) error {
+	req, ok := base.ParseCommand(text)
+	if !ok {
+		return nil
+	}
 
-	case common.StringContains(text, cmdStart):
-		return t.SendMessage(fmt.Sprintf("%s: START COMMANDS HERE", talkRoot), chatID)
+	if req.Name != cmdStart && req.Name != cmdPair && !t.IsAuthorisedClient(chatID) {
+		if t.Verbose {
+			log.Printf("Telegram: rejected command %s from chat %d", req.Name, chatID)
+		}
+		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, errUnknownChat), chatID)
+	}
 
-	case common.StringContains(text, cmdOrders):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetOrderbook("ANX")), chatID)
+	reply, _ := t.commander.HandleReply(text, strconv.FormatInt(chatID, 10),
+		strconv.FormatInt(userID, 10), userName)
+	return t.send(fmt.Sprintf("%s: %s", talkRoot, reply.Text), reply.Image, chatID)
+}
 
-	case common.StringContains(text, cmdStatus):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetStatus()), chatID)
+// cmdStart authorises the chat if a pairing code is supplied, as sent by
+// Telegram deep links
+func (t *Telegram) cmdStart(c *base.Commander, req base.CommandRequest) (string, error) {
+	if len(req.Args) == 1 {
+		return t.cmdPair(c, req)
+	}
 
-	case common.StringContains(text, cmdTicker):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetTicker("ANX")), chatID)
+	chatID, err := strconv.ParseInt(req.Chat, 10, 64)
+	if err != nil {
+		return "", err
+	}
 
-	case common.StringContains(text, cmdSettings):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetSettings()), chatID)
+	if t.IsAuthorisedClient(chatID) {
+		return "this chat is authorised and subscribed to event notifications", nil
+	}
+	return "", errUnknownChat
+}
 
-	case common.StringContains(text, cmdPortfolio):
-		return t.SendMessage(fmt.Sprintf("%s: %s", talkRoot, t.GetPortfolio()), chatID)
+// cmdPair authorises the chat with a pairing code
+func (t *Telegram) cmdPair(c *base.Commander, req base.CommandRequest) (string, error) {
+	chatID, err := strconv.ParseInt(req.Chat, 10, 64)
+	if err != nil {
+		return "", err
+	}
 
-	default:
-		return t.SendMessage(fmt.Sprintf("command %s not recognized", text), chatID)
+	if err = t.Pair(chatID, req.Args[0]); err != nil {
+		return "", err
 	}
+	return "this chat is now authorised and subscribed to event notifications", nil
 }
 
-// GetUpdates gets new updates via a long poll connection
+// GetUpdates gets the updates following the offset via a long poll
+// connection
 func (t *Telegram) GetUpdates() (GetUpdateResponse, error) {
 	var newUpdates GetUpdateResponse
 	path := fmt.Sprintf(apiURL, t.Token, methodGetUpdates)
-	return newUpdates, t.SendHTTPRequest(path, nil, &newUpdates)
+
+	params := struct {
+		Offset  int64 `json:"offset,omitempty"`
+		Timeout int   `json:"timeout"`
+	}{
+		Timeout: pollTimeout,
+	}
+	if t.Offset > 0 {
+		params.Offset = t.Offset + 1
+	}
+
+	json, err := common.JSONEncode(&params)
+	if err != nil {
+		return newUpdates, err
+	}
+	return newUpdates, t.SendHTTPRequest(path, json, &newUpdates)
 }
 
 // TestConnection tests bot's supplied authentication token
@@ -216,6 +463,57 @@ func (t *Telegram) SendMessage(text string, chatID int64) error {
 	return nil
 }
 
+// SendPhoto sends a PNG image with an optional caption to a user by their
+// chatID
+func (t *Telegram) SendPhoto(image []byte, caption string, chatID int64) error {
+	path := fmt.Sprintf(apiURL, t.Token, methodSendPhoto)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	err := writer.WriteField("chat_id", strconv.FormatInt(chatID, 10))
+	if err != nil {
+		return err
+	}
+
+	if caption != "" {
+		if err = writer.WriteField("caption", caption); err != nil {
+			return err
+		}
+	}
+
+	part, err := writer.CreateFormFile("photo", imageFileName)
+	if err != nil {
+		return err
+	}
+
+	if _, err = part.Write(image); err != nil {
+		return err
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	headers := make(map[string]string)
+	headers["content-type"] = writer.FormDataContentType()
+
+	resp, err := common.SendHTTPRequest("POST", path, headers, &body)
+	if err != nil {
+		return err
+	}
+
+	result := Message{}
+	err = common.JSONDecode([]byte(resp), &result)
+	if err != nil {
+		return err
+	}
+
+	if !result.Ok {
+		return errors.New(result.Description)
+	}
+	return nil
+}
+
 // SendHTTPRequest sends an authenticated HTTP request
 func (t *Telegram) SendHTTPRequest(path string, json []byte, result interface{}) error {
 	headers := make(map[string]string)
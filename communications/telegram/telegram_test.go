@@ -1,7 +1,13 @@
 package telegram
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
@@ -28,3 +34,130 @@ func PushEvent(t *testing.T) {
 		t.Error("test failed - telegram PushEvent() error", err)
 	}
 }
+
+func TestPair(t *testing.T) {
+	var persisted []int64
+	T.SetPersistHandler(func(clients []int64) { persisted = clients })
+	defer func() {
+		T.SetPersistHandler(nil)
+		T.AuthorisedClients = nil
+	}()
+
+	if err := T.Pair(1337, ""); err != errInvalidPairingCode {
+		t.Error("test failed - telegram Pair() paired without a code")
+	}
+
+	code, expiry, err := T.NewPairingCode()
+	if err != nil || len(code) != 8 || time.Until(expiry) > PairingCodeValidity {
+		t.Fatal("test failed - telegram NewPairingCode() error", err)
+	}
+
+	if err = T.Pair(1337, "WRONG"); err != errInvalidPairingCode {
+		t.Error("test failed - telegram Pair() paired with an invalid code")
+	}
+
+	if err = T.Pair(1337, strings.ToLower(code)); err != nil {
+		t.Fatal("test failed - telegram Pair() error", err)
+	}
+
+	if !T.IsAuthorisedClient(1337) || len(persisted) != 1 || persisted[0] != 1337 {
+		t.Error("test failed - telegram Pair() did not authorise and persist the chat")
+	}
+
+	if err = T.Pair(42, code); err != errInvalidPairingCode {
+		t.Error("test failed - telegram Pair() code should only be used once")
+	}
+
+	T.pairingExpiry = time.Now().Add(-time.Second)
+	T.pairingCode = "EXPIRED"
+	if err = T.Pair(42, "EXPIRED"); err != errInvalidPairingCode {
+		t.Error("test failed - telegram Pair() paired with an expired code")
+	}
+
+	if !T.RemoveAuthorisedClient(1337) || T.RemoveAuthorisedClient(1337) || len(persisted) != 0 {
+		t.Error("test failed - telegram RemoveAuthorisedClient() error")
+	}
+}
+
+func TestHandleMessages(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			ChatID int64  `json:"chat_id"`
+			Text   string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&msg)
+		sent = append(sent, msg.Text)
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	defer func(url string) { apiURL = url }(apiURL)
+	apiURL = server.URL + "/bot%s/%s"
+	defer func() { T.AuthorisedClients = nil }()
+
+	err := T.HandleMessages("hello", 1337, 1, "satoshi")
+	if err != nil || len(sent) != 0 {
+		t.Fatal("test failed - telegram HandleMessages() replied to a message", err)
+	}
+
+	T.HandleMessages("/status", 1337, 1, "satoshi")
+	if len(sent) != 1 || !strings.Contains(sent[0], errUnknownChat.Error()) {
+		t.Fatalf("test failed - telegram HandleMessages() should reject unknown chats %v", sent)
+	}
+
+	code, _, _ := T.NewPairingCode()
+	T.HandleMessages("/start "+code, 1337, 1, "satoshi")
+	if len(sent) != 2 || !strings.Contains(sent[1], "now authorised") || !T.IsAuthorisedClient(1337) {
+		t.Fatalf("test failed - telegram HandleMessages() pairing error %v", sent)
+	}
+
+	T.HandleMessages("/status", 1337, 1, "satoshi")
+	if len(sent) != 3 || !strings.Contains(sent[2], "Online") {
+		t.Errorf("test failed - telegram HandleMessages() authorised chat rejected %v", sent)
+	}
+
+	err = T.PushEventTo("42", base.Event{Type: "TEST"})
+	if err != errUnknownChat {
+		t.Error("test failed - telegram PushEventTo() pushed to an unknown chat", err)
+	}
+
+	err = T.PushEventTo("1337", base.Event{Type: "TEST"})
+	if err != nil || len(sent) != 4 {
+		t.Error("test failed - telegram PushEventTo() error", err)
+	}
+}
+
+func TestPushEventImage(t *testing.T) {
+	var methods, captions []string
+	var photo []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		if strings.HasPrefix(r.Header.Get("content-type"), "multipart/form-data") {
+			file, _, err := r.FormFile("photo")
+			if err == nil {
+				photo, _ = ioutil.ReadAll(file)
+			}
+			captions = append(captions, r.FormValue("caption"))
+		}
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	defer func(url string) { apiURL = url }(apiURL)
+	apiURL = server.URL + "/bot%s/%s"
+	T.AuthorisedClients = []int64{1337}
+	defer func() { T.AuthorisedClients = nil }()
+
+	err := T.PushEventTo("1337", base.Event{Type: "ALERT", TradeDetails: "BTCUSD > 10000", Image: []byte("png")})
+	if err != nil || len(methods) != 1 || methods[0] != methodSendPhoto ||
+		string(photo) != "png" || !strings.Contains(captions[0], "BTCUSD > 10000") {
+		t.Fatalf("test failed - telegram PushEventTo() image error %v %v %v", err, methods, captions)
+	}
+
+	err = T.PushEvent(base.Event{Type: "ALERT", TradeDetails: strings.Repeat("a", maxCaptionLength), Image: []byte("png")})
+	if err != nil || len(methods) != 3 || methods[1] != methodSendMessage ||
+		methods[2] != methodSendPhoto || captions[1] != "" {
+		t.Errorf("test failed - telegram PushEvent() long caption error %v %v %v", err, methods, captions)
+	}
+}
@@ -6,17 +6,20 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/trustfeed/go-crypto-pricefeeder/charts"
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/base"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
 	"github.com/trustfeed/go-crypto-pricefeeder/portfolio"
+	"github.com/trustfeed/go-crypto-pricefeeder/schedule"
 )
 
 // Constants declared here are filename strings and test strings
@@ -31,6 +34,77 @@ const (
 	configPairsLastUpdatedWarningThreshold = 30 // 30 days
 	configDefaultHTTPTimeout               = time.Duration(time.Second * 15)
 	configMaxAuthFailres                   = 3
+	configDefaultAdapterTickerMaxAge       = time.Duration(time.Minute)
+	configDefaultSessionTokenExpiry        = time.Duration(time.Hour)
+	configDefaultAPIRateLimit              = 120 // requests per minute
+	configDefaultLoginMaxFailures          = 5
+	configDefaultLoginLockoutDuration      = time.Duration(time.Minute * 15)
+	configDefaultSinkTopic                 = "prices.{exchange}.{pair}"
+	configDefaultSinkBatchSize             = 100
+	configDefaultSinkBatchInterval         = time.Duration(time.Second)
+	configDefaultSinkBufferSize            = 10000
+	configDefaultWebhookMaxAttempts        = 10
+	configDefaultWebhookInitialBackoff     = time.Duration(time.Second * 5)
+	configDefaultWebhookMaxBackoff         = time.Duration(time.Hour)
+	configDefaultWebhookTimeout            = time.Duration(time.Second * 10)
+	configDefaultWebhookHistorySize        = 1000
+	configDefaultWebhookMaxQueueSize       = 10000
+	configDefaultRedisAddress              = "127.0.0.1:6379"
+	configDefaultRedisKeyPrefix            = "pricefeeder"
+	configDefaultRedisFXRatesTTL           = time.Duration(time.Hour * 24)
+	configDefaultArbitrageThreshold        = 0.5
+	configDefaultArbitrageQuoteCurrency    = "USD"
+	configDefaultArbitrageHistorySize      = 100
+	configDefaultDigestHistory             = time.Duration(time.Hour * 24)
+	configDefaultDigestStaleAfter          = time.Duration(time.Minute * 10)
+	configDefaultDigestTopMovers           = 5
+	configDefaultChartsHistory             = time.Duration(time.Hour * 24)
+	configDefaultChartsPeriod              = time.Duration(time.Hour * 6)
+	configDefaultChartsCandles             = 60
+	configMaxChartsCandles                 = 500
+	configDefaultFXRefreshInterval         = time.Duration(time.Minute * 10)
+	configDefaultFXStaleAfter              = time.Duration(time.Hour)
+	configDefaultFXDeviationTolerance      = 1.0
+
+	SinkTypeFile   = "file"
+	SinkTypeUDP    = "udp"
+	SinkTypeStdout = "stdout"
+	SinkTypeKafka  = "kafka"
+	SinkTypeNATS   = "nats"
+
+	SinkCompressionNone = "none"
+	SinkCompressionGzip = "gzip"
+
+	SinkSASLPlain       = "plain"
+	SinkSASLSCRAMSHA256 = "scram-sha-256"
+	SinkSASLSCRAMSHA512 = "scram-sha-512"
+
+	SinkFormatJSON = "json"
+	SinkFormatCSV  = "csv"
+
+	SinkEventTicker           = "ticker"
+	SinkEventOrderbookSummary = "orderbook_summary"
+	SinkEventTrade            = "trade"
+
+	WebhookEventTicker  = "ticker"
+	WebhookEventTrigger = "event"
+	WebhookEventHealth  = "health"
+
+	NotificationSeverityInfo     = "info"
+	NotificationSeverityWarning  = "warning"
+	NotificationSeverityCritical = "critical"
+
+	DigestSectionMarket    = "market"
+	DigestSectionPortfolio = "portfolio"
+	DigestSectionHealth    = "health"
+
+	APIScopeReadMarket    = "read-market"
+	APIScopeReadPortfolio = "read-portfolio"
+	APIScopeAdmin         = "admin"
+
+	// RedactedSecret replaces secret values in config payloads returned by the
+	// webserver
+	RedactedSecret = "**REDACTED**"
 )
 
 // Variables here are mainly alerts and a configuration object
@@ -51,8 +125,17 @@ var (
 	WarningWebserverCredentialValuesEmpty           = "WARNING -- Webserver support disabled due to empty Username/Password values."
 	WarningWebserverListenAddressInvalid            = "WARNING -- Webserver support disabled due to invalid listen address."
 	WarningWebserverRootWebFolderNotFound           = "WARNING -- Webserver support disabled due to missing web folder."
+	WarningWebserverGRPCListenAddressInvalid        = "WARNING -- Webserver gRPC support disabled due to invalid listen address."
+	WarningSinkInvalid                              = "WARNING -- Sink %s disabled due to invalid config. Error: %s"
+	WarningWebhookInvalid                           = "WARNING -- Webhook %s disabled due to invalid config. Error: %s"
+	WarningRedisInvalid                             = "WARNING -- Redis cache disabled due to invalid config. Error: %s"
+	WarningArbitrageInvalid                         = "WARNING -- Arbitrage monitor disabled due to invalid config. Error: %s"
+	WarningDigestInvalid                            = "WARNING -- Digest reports disabled due to invalid config. Error: %s"
+	WarningChartsInvalid                            = "WARNING -- Price charts disabled due to invalid config. Error: %s"
+	WarningWebserverAPITokenInvalid                 = "WARNING -- Webserver API token %s disabled due to empty token or invalid scopes."
 	WarningExchangeAuthAPIDefaultOrEmptyValues      = "WARNING -- Exchange %s: Authenticated API support disabled due to default/empty APIKey/Secret/ClientID values."
 	WarningCurrencyExchangeProvider                 = "WARNING -- Currency exchange provider invalid valid. Reset to Fixer."
+	WarningFXStaleAfter                             = "WARNING -- FX stale threshold is below the %s refresh interval, setting it to twice the interval."
 	WarningPairsLastUpdatedThresholdExceeded        = "WARNING -- Exchange %s: Last manual update of available currency pairs has exceeded %d days. Manual update required!"
 	Cfg                                             Config
 	IsInitialSetup                                  bool
@@ -69,6 +152,27 @@ type WebserverConfig struct {
 	WebsocketConnectionLimit     int
 	WebsocketMaxAuthFailures     int
 	WebsocketAllowInsecureOrigin bool
+	AdapterTickerMaxAge          time.Duration
+	GRPCEnabled                  bool
+	GRPCListenAddress            string
+	RequireMarketDataAuth        bool
+	SessionTokenExpiry           time.Duration
+	APIRateLimit                 int
+	LoginMaxFailures             int
+	LoginLockoutDuration         time.Duration
+	APITokens                    []APITokenConfig
+}
+
+// APITokenConfig holds a scoped API token for the webserver. Scopes is a comma
+// separated list of read-market, read-portfolio and admin. RateLimit is the
+// maximum number of requests per minute, defaulting to the webserver
+// APIRateLimit
+type APITokenConfig struct {
+	Name      string
+	Enabled   bool
+	Token     string
+	Scopes    string
+	RateLimit int `json:",omitempty"`
 }
 
 // Post holds the bot configuration data
@@ -96,6 +200,13 @@ type Config struct {
 	Portfolio         portfolio.Base       `json:"PortfolioAddresses"`
 	Webserver         WebserverConfig      `json:"Webserver"`
 	Exchanges         []ExchangeConfig     `json:"Exchanges"`
+	Sinks             []SinkConfig         `json:"Sinks"`
+	Webhooks          WebhooksConfig       `json:"Webhooks"`
+	Redis             RedisConfig          `json:"Redis"`
+	Events            []EventConfig        `json:"Events"`
+	Arbitrage         ArbitrageConfig      `json:"Arbitrage"`
+	Digest            DigestConfig         `json:"Digest"`
+	Charts            ChartsConfig         `json:"Charts"`
 
 	// Deprecated config settings, will be removed at a future date
 	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"CurrencyPairFormat,omitempty"`
@@ -127,21 +238,219 @@ type ExchangeConfig struct {
 	RequestCurrencyPairFormat *CurrencyPairFormatConfig `json:"RequestCurrencyPairFormat"`
 }
 
+// SinkConfig holds the settings of an output sink which receives ticker,
+// orderbook summary and trade events. Type is one of file, udp, stdout, kafka
+// or nats and Format is either json (one JSON object per line) or csv.
+// Exchanges, Pairs and Events are optional comma separated filters. File sinks
+// rotate once MaxSize bytes or MaxAge has been reached. Kafka and NATS sinks
+// publish to the comma separated Brokers using the Topic template, sending up
+// to BatchSize messages every BatchInterval and buffering up to BufferSize
+// messages while the brokers are unavailable. TLS enables TLS connections to
+// the brokers, verified against the optional TLSCAFile and authenticated with
+// the optional TLSCertFile and TLSKeyFile client certificate. Kafka sinks
+// authenticate with Username and Password using the SASLMechanism, NATS sinks
+// with either Username and Password or Token
+type SinkConfig struct {
+	Name          string
+	Type          string
+	Enabled       bool
+	Verbose       bool
+	Format        string
+	Path          string        `json:",omitempty"`
+	Address       string        `json:",omitempty"`
+	MaxSize       int64         `json:",omitempty"`
+	MaxAge        time.Duration `json:",omitempty"`
+	Exchanges     string        `json:",omitempty"`
+	Pairs         string        `json:",omitempty"`
+	Events        string        `json:",omitempty"`
+	Brokers       string        `json:",omitempty"`
+	Topic         string        `json:",omitempty"`
+	BatchSize     int           `json:",omitempty"`
+	BatchInterval time.Duration `json:",omitempty"`
+	Compression   string        `json:",omitempty"`
+	BufferSize    int           `json:",omitempty"`
+	TLS           bool          `json:",omitempty"`
+	TLSCAFile     string        `json:",omitempty"`
+	TLSCertFile   string        `json:",omitempty"`
+	TLSKeyFile    string        `json:",omitempty"`
+	TLSSkipVerify bool          `json:",omitempty"`
+	SASLMechanism string        `json:",omitempty"`
+	Username      string        `json:",omitempty"`
+	Password      string        `json:",omitempty"`
+	Token         string        `json:",omitempty"`
+}
+
+// WebhooksConfig holds the outbound webhook delivery settings. Undelivered
+// payloads are kept in the QueuePath file and retried with an exponential
+// backoff between InitialBackoff and MaxBackoff until MaxAttempts is reached
+type WebhooksConfig struct {
+	Enabled        bool
+	QueuePath      string `json:",omitempty"`
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	HistorySize    int
+	MaxQueueSize   int
+	Endpoints      []WebhookConfig
+}
+
+// WebhookConfig holds a webhook URL and the secret used to sign its payloads.
+// Events is an optional comma separated list of ticker, event and health
+type WebhookConfig struct {
+	Name    string
+	Enabled bool
+	URL     string
+	Secret  string
+	Events  string        `json:",omitempty"`
+	Timeout time.Duration `json:",omitempty"`
+}
+
+// RedisConfig holds the settings of the optional Redis shared price cache.
+// Tickers, reference prices and FX rates are mirrored into hashes under
+// KeyPrefix, ticker and reference price hashes expire once they are older
+// than TickerTTL (defaulting to the webserver adapter ticker max age) and the
+// FX rates hash after FXRatesTTL. Changes are published on pub/sub channels
+// named after the hash keys and WarmStart restores the in-memory stores from
+// Redis on startup
+type RedisConfig struct {
+	Enabled    bool
+	Address    string
+	Password   string `json:",omitempty"`
+	Database   int
+	KeyPrefix  string
+	TickerTTL  time.Duration
+	FXRatesTTL time.Duration
+	WarmStart  bool
+}
+
+// ChartsConfig holds the price chart settings. Charts are drawn from the
+// market history kept for History, covering Period by default with up to
+// Candles candles. Style is candlestick or line and Volume adds a volume
+// panel. AlertCharts attaches a chart of the exchange currency pair to
+// alerts sent to mediums supporting images
+type ChartsConfig struct {
+	Enabled     bool
+	AlertCharts bool
+	Style       string `json:",omitempty"`
+	Width       int    `json:",omitempty"`
+	Height      int    `json:",omitempty"`
+	Volume      bool
+	History     time.Duration `json:",omitempty"`
+	Period      time.Duration `json:",omitempty"`
+	Candles     int           `json:",omitempty"`
+}
+
+// DigestConfig holds the scheduled reports sent via the communication
+// mediums. Market data is kept for History, pairs not updated within
+// StaleAfter are reported as stale and the TopMovers pairs with the largest
+// price change are listed
+type DigestConfig struct {
+	Enabled    bool
+	History    time.Duration `json:",omitempty"`
+	StaleAfter time.Duration `json:",omitempty"`
+	TopMovers  int           `json:",omitempty"`
+	Reports    []DigestReportConfig
+}
+
+// DigestReportConfig is a report sent on Schedule, a cron expression such as
+// "0 8 * * *" evaluated in Location. Sections is any of market, portfolio and
+// health, defaulting to all of them, and Pairs limits the market summary.
+// Template is a Go text template overriding the default report template
+type DigestReportConfig struct {
+	Name       string
+	Schedule   string
+	Location   string   `json:",omitempty"`
+	Sections   []string `json:",omitempty"`
+	Pairs      []string `json:",omitempty"`
+	Template   string   `json:",omitempty"`
+	Recipients []DigestRecipientConfig
+}
+
+// DigestRecipientConfig is a communication medium and optional recipient a
+// report is sent to, Template overrides the report template for the
+// recipient
+type DigestRecipientConfig struct {
+	Medium    string
+	Recipient string `json:",omitempty"`
+	Template  string `json:",omitempty"`
+}
+
+// ArbitrageConfig holds the cross-exchange arbitrage monitor settings. The best
+// bid and ask of each pair are compared across exchanges after taker fees,
+// using DefaultTakerFee (a percentage) for exchanges without a taker fee, and
+// fiat quoted prices are converted to QuoteCurrency. Spreads of at least
+// Threshold percent are recorded as opportunities, tickers older than
+// MaxTickerAge (defaulting to the webserver adapter ticker max age) are
+// ignored and the last HistorySize closed opportunities are kept. Notify
+// pushes opened and closed opportunities to the communication mediums
+type ArbitrageConfig struct {
+	Enabled         bool
+	Threshold       float64
+	DefaultTakerFee float64
+	QuoteCurrency   string
+	MaxTickerAge    time.Duration
+	HistorySize     int
+	Notify          bool
+}
+
+// EventConfig holds a persisted price event. Item is one of price, last, bid,
+// ask, high, low, volume, spread or pct_change and Condition is an operator
+// and value such as ">,6500", or an expression over any exchange ticker such as
+// "bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50" which ignores the exchange,
+// pair, asset, item and window. Window is the lookback of pct_change events.
+// One-shot events are marked Executed once triggered, repeating events trigger
+// again while the condition holds once Cooldown has elapsed since
+// LastTriggered (a Unix timestamp). Owner, Medium and Recipient identify the
+// chat user and chat of NOTIFY events created from a communication medium.
+// Severity is used to route the notification and defaults to warning
+type EventConfig struct {
+	ID            int
+	Exchange      string
+	Pair          pair.CurrencyPair
+	Asset         string
+	Item          string
+	Condition     string
+	Window        time.Duration `json:",omitempty"`
+	Action        string
+	Repeat        bool
+	Cooldown      time.Duration `json:",omitempty"`
+	Executed      bool
+	LastTriggered int64  `json:",omitempty"`
+	Owner         string `json:",omitempty"`
+	Medium        string `json:",omitempty"`
+	Recipient     string `json:",omitempty"`
+	Severity      string `json:",omitempty"`
+}
+
 // CurrencyConfig holds all the information needed for currency related manipulation
 type CurrencyConfig struct {
-	ForexProviders      []base.Settings           `json:"ForexProviders"`
-	Cryptocurrencies    string                    `json:"Cryptocurrencies"`
-	CurrencyPairFormat  *CurrencyPairFormatConfig `json:"CurrencyPairFormat"`
-	FiatDisplayCurrency string
+	ForexProviders       []base.Settings           `json:"ForexProviders"`
+	Cryptocurrencies     string                    `json:"Cryptocurrencies"`
+	CurrencyPairFormat   *CurrencyPairFormatConfig `json:"CurrencyPairFormat"`
+	FiatDisplayCurrency  string
+	FXRefreshInterval    time.Duration
+	FXStaleAfter         time.Duration
+	FXConsensus          bool
+	FXDeviationTolerance float64
 }
 
 // CommunicationsConfig holds all the information needed for each
 // enabled communication package
 type CommunicationsConfig struct {
-	SlackConfig     SlackConfig     `json:"Slack"`
-	SMSGlobalConfig SMSGlobalConfig `json:"SMSGlobal"`
-	SMTPConfig      SMTPConfig      `json:"SMTP"`
-	TelegramConfig  TelegramConfig  `json:"Telegram"`
+	SlackConfig      SlackConfig      `json:"Slack"`
+	SMSGlobalConfig  SMSGlobalConfig  `json:"SMSGlobal"`
+	SMTPConfig       SMTPConfig       `json:"SMTP"`
+	TelegramConfig   TelegramConfig   `json:"Telegram"`
+	DiscordConfig    DiscordConfig    `json:"Discord"`
+	MattermostConfig MattermostConfig `json:"Mattermost"`
+
+	// Notifications routes, deduplicates and throttles the events broadcast
+	// to the mediums
+	Notifications NotificationsConfig `json:"Notifications"`
+
+	// EventTemplates overrides the Go templates used to format events, keyed
+	// by medium (slack, email or sms)
+	EventTemplates map[string]string `json:"EventTemplates,omitempty"`
 }
 
 // SlackConfig holds all variables to start and run the Slack package
@@ -151,6 +460,10 @@ type SlackConfig struct {
 	Verbose           bool   `json:"Verbose"`
 	TargetChannel     string `json:"TargetChannel"`
 	VerificationToken string `json:"VerificationToken"`
+
+	// AuthorisedUsers restricts chat commands to the listed user IDs or
+	// names, every user is authorised if empty
+	AuthorisedUsers []string `json:"AuthorisedUsers,omitempty"`
 }
 
 // SMSContact stores the SMS contact info
@@ -189,6 +502,115 @@ type TelegramConfig struct {
 	Enabled           bool   `json:"Enabled"`
 	Verbose           bool   `json:"Verbose"`
 	VerificationToken string `json:"VerificationToken"`
+
+	// AuthorisedUsers restricts chat commands to the listed user IDs or
+	// usernames, every user is authorised if empty
+	AuthorisedUsers []string `json:"AuthorisedUsers,omitempty"`
+
+	// AuthorisedClients are the chat IDs paired with the bot, commands from
+	// other chats are rejected
+	AuthorisedClients []int64 `json:"AuthorisedClients,omitempty"`
+}
+
+// DiscordConfig holds all variables to start and run the Discord package
+type DiscordConfig struct {
+	Name       string `json:"Name"`
+	Enabled    bool   `json:"Enabled"`
+	Verbose    bool   `json:"Verbose"`
+	WebhookURL string `json:"WebhookURL"`
+
+	// BotToken and ChannelID enable chat commands sent to the channel, they
+	// are also required to send alerts to a channel other than the webhook's
+	BotToken  string `json:"BotToken,omitempty"`
+	ChannelID string `json:"ChannelID,omitempty"`
+
+	// AuthorisedUsers restricts chat commands to the listed user IDs or
+	// usernames, every user is authorised if empty
+	AuthorisedUsers []string `json:"AuthorisedUsers,omitempty"`
+}
+
+// MattermostConfig holds all variables to start and run the Mattermost
+// package
+type MattermostConfig struct {
+	Name       string `json:"Name"`
+	Enabled    bool   `json:"Enabled"`
+	Verbose    bool   `json:"Verbose"`
+	WebhookURL string `json:"WebhookURL"`
+
+	// ServerURL, BotToken and ChannelID enable chat commands sent to the
+	// channel, they are also required to send alerts to a channel other than
+	// the webhook's
+	ServerURL string `json:"ServerURL,omitempty"`
+	BotToken  string `json:"BotToken,omitempty"`
+	ChannelID string `json:"ChannelID,omitempty"`
+
+	// AuthorisedUsers restricts chat commands to the listed user IDs or
+	// usernames, every user is authorised if empty
+	AuthorisedUsers []string `json:"AuthorisedUsers,omitempty"`
+}
+
+// NotificationsConfig holds the rules applied to events broadcast to the
+// communication mediums. Events are sent to the targets of every matching
+// route, or to every medium if no route matches. Identical events sent within
+// DedupWindow are dropped, RateLimits cap the events sent to a medium,
+// QuietHours suppress events below the bypass severity and Escalations resend
+// events which are not acknowledged in time
+type NotificationsConfig struct {
+	Enabled     bool
+	DedupWindow time.Duration                  `json:",omitempty"`
+	Routes      []NotificationRouteConfig      `json:",omitempty"`
+	RateLimits  []NotificationRateLimitConfig  `json:",omitempty"`
+	QuietHours  QuietHoursConfig               `json:",omitempty"`
+	Escalations []NotificationEscalationConfig `json:",omitempty"`
+}
+
+// NotificationTargetConfig is a communication medium and an optional
+// recipient, such as a chat or channel ID, events are sent to every recipient
+// of the medium if it is empty
+type NotificationTargetConfig struct {
+	Medium    string
+	Recipient string `json:",omitempty"`
+}
+
+// NotificationRouteConfig sends the events matching every set filter to its
+// targets. Pairs are matched ignoring delimiters
+type NotificationRouteConfig struct {
+	Name        string
+	EventTypes  []string `json:",omitempty"`
+	MinSeverity string   `json:",omitempty"`
+	Exchanges   []string `json:",omitempty"`
+	Pairs       []string `json:",omitempty"`
+	Targets     []NotificationTargetConfig
+}
+
+// NotificationRateLimitConfig limits a medium to MaxEvents every Interval
+type NotificationRateLimitConfig struct {
+	Medium    string
+	MaxEvents int
+	Interval  time.Duration
+}
+
+// QuietHoursConfig suppresses events sent to the listed mediums, or every
+// medium if empty, between Start and End (15:04 format) in Location unless
+// they are at least BypassSeverity
+type QuietHoursConfig struct {
+	Enabled        bool
+	Start          string
+	End            string
+	Location       string   `json:",omitempty"`
+	Mediums        []string `json:",omitempty"`
+	BypassSeverity string   `json:",omitempty"`
+}
+
+// NotificationEscalationConfig sends matching events to its targets if they
+// are not acknowledged on Medium within Timeout
+type NotificationEscalationConfig struct {
+	Name        string
+	EventTypes  []string `json:",omitempty"`
+	MinSeverity string   `json:",omitempty"`
+	Medium      string
+	Timeout     time.Duration
+	Targets     []NotificationTargetConfig
 }
 
 // GetCurrencyConfig returns currency configurations
@@ -211,6 +633,13 @@ func (c *Config) UpdateCommunicationsConfig(config CommunicationsConfig) {
 	m.Unlock()
 }
 
+// UpdateEventsConfig sets the persisted events
+func (c *Config) UpdateEventsConfig(events []EventConfig) {
+	m.Lock()
+	c.Events = events
+	m.Unlock()
+}
+
 // CheckCommunicationsConfig checks to see if the variables are set correctly
 // from config.json
 func (c *Config) CheckCommunicationsConfig() error {
@@ -229,7 +658,7 @@ func (c *Config) CheckCommunicationsConfig() error {
 	}
 
 	if c.Communications.SMSGlobalConfig.Name == "" {
-		if c.SMS.Contacts != nil {
+		if c.SMS != nil && c.SMS.Contacts != nil {
 			c.Communications.SMSGlobalConfig = SMSGlobalConfig{
 				Name:     "SMSGlobal",
 				Enabled:  c.SMS.Enabled,
@@ -280,10 +709,26 @@ func (c *Config) CheckCommunicationsConfig() error {
 		}
 	}
 
+	if c.Communications.DiscordConfig.Name == "" {
+		c.Communications.DiscordConfig = DiscordConfig{
+			Name:       "Discord",
+			WebhookURL: "https://discordapp.com/api/webhooks/id/token",
+		}
+	}
+
+	if c.Communications.MattermostConfig.Name == "" {
+		c.Communications.MattermostConfig = MattermostConfig{
+			Name:       "Mattermost",
+			WebhookURL: "https://mattermost.example.com/hooks/id",
+		}
+	}
+
 	if c.Communications.SlackConfig.Name != "Slack" ||
 		c.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		c.Communications.SMTPConfig.Name != "SMTP" ||
-		c.Communications.TelegramConfig.Name != "Telegram" {
+		c.Communications.TelegramConfig.Name != "Telegram" ||
+		c.Communications.DiscordConfig.Name != "Discord" ||
+		c.Communications.MattermostConfig.Name != "Mattermost" {
 		return errors.New("Communications config name/s not set correctly")
 	}
 	if c.Communications.SlackConfig.Enabled {
@@ -312,9 +757,122 @@ func (c *Config) CheckCommunicationsConfig() error {
 			return errors.New("Telegram enabled in config but variable data not set")
 		}
 	}
+	if c.Communications.DiscordConfig.Enabled {
+		discord := c.Communications.DiscordConfig
+		if !isHTTPURL(discord.WebhookURL) {
+			return errors.New("Discord enabled in config but webhook URL is invalid")
+		}
+		if discord.BotToken != "" && discord.ChannelID == "" {
+			return errors.New("Discord bot token set in config but channel ID not set")
+		}
+	}
+	if c.Communications.MattermostConfig.Enabled {
+		mattermost := c.Communications.MattermostConfig
+		if !isHTTPURL(mattermost.WebhookURL) {
+			return errors.New("Mattermost enabled in config but webhook URL is invalid")
+		}
+		if mattermost.BotToken != "" &&
+			(!isHTTPURL(mattermost.ServerURL) || mattermost.ChannelID == "") {
+			return errors.New("Mattermost bot token set in config but server URL or channel ID not set")
+		}
+	}
+	if c.Communications.Notifications.Enabled {
+		if err := checkNotificationsConfig(&c.Communications.Notifications); err != nil {
+			return fmt.Errorf("Notifications config error: %s", err)
+		}
+	}
+	return nil
+}
+
+// checkNotificationsConfig validates the notification rules
+func checkNotificationsConfig(cfg *NotificationsConfig) error {
+	if cfg.DedupWindow < 0 {
+		return errors.New("dedup window is negative")
+	}
+
+	for i := range cfg.Routes {
+		if err := checkNotificationSeverity(cfg.Routes[i].MinSeverity); err != nil {
+			return fmt.Errorf("route %s %s", cfg.Routes[i].Name, err)
+		}
+		if err := checkNotificationTargets(cfg.Routes[i].Targets); err != nil {
+			return fmt.Errorf("route %s %s", cfg.Routes[i].Name, err)
+		}
+	}
+
+	for i := range cfg.RateLimits {
+		if cfg.RateLimits[i].Medium == "" || cfg.RateLimits[i].MaxEvents <= 0 ||
+			cfg.RateLimits[i].Interval <= 0 {
+			return fmt.Errorf("rate limit %d requires a medium, max events and interval", i)
+		}
+	}
+
+	if cfg.QuietHours.Enabled {
+		quiet := cfg.QuietHours
+		if _, err := time.Parse("15:04", quiet.Start); err != nil {
+			return fmt.Errorf("invalid quiet hours start %s", quiet.Start)
+		}
+		if _, err := time.Parse("15:04", quiet.End); err != nil {
+			return fmt.Errorf("invalid quiet hours end %s", quiet.End)
+		}
+		if _, err := time.LoadLocation(quiet.Location); err != nil {
+			return fmt.Errorf("invalid quiet hours location %s", quiet.Location)
+		}
+		if quiet.BypassSeverity == "" {
+			cfg.QuietHours.BypassSeverity = NotificationSeverityCritical
+		} else if err := checkNotificationSeverity(quiet.BypassSeverity); err != nil {
+			return fmt.Errorf("quiet hours %s", err)
+		}
+	}
+
+	for i := range cfg.Escalations {
+		escalation := cfg.Escalations[i]
+		if escalation.Medium == "" || escalation.Timeout <= 0 {
+			return fmt.Errorf("escalation %s requires a medium and timeout", escalation.Name)
+		}
+		if err := checkNotificationSeverity(escalation.MinSeverity); err != nil {
+			return fmt.Errorf("escalation %s %s", escalation.Name, err)
+		}
+		if err := checkNotificationTargets(escalation.Targets); err != nil {
+			return fmt.Errorf("escalation %s %s", escalation.Name, err)
+		}
+	}
 	return nil
 }
 
+// checkNotificationSeverity returns an error if the severity is set and not
+// a supported severity
+func checkNotificationSeverity(severity string) error {
+	switch common.StringToLower(severity) {
+	case "", NotificationSeverityInfo, NotificationSeverityWarning, NotificationSeverityCritical:
+		return nil
+	}
+	return fmt.Errorf("unsupported severity %s", severity)
+}
+
+// checkNotificationTargets returns an error if there are no targets or a
+// target has no medium
+func checkNotificationTargets(targets []NotificationTargetConfig) error {
+	if len(targets) == 0 {
+		return errors.New("has no targets")
+	}
+
+	for i := range targets {
+		if targets[i].Medium == "" {
+			return errors.New("has a target without a medium")
+		}
+	}
+	return nil
+}
+
+// isHTTPURL returns whether or not the string is an absolute HTTP(S) URL
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // SupportsPair returns true or not whether the exchange supports the supplied
 // pair
 func (c *Config) SupportsPair(exchName string, p pair.CurrencyPair) (bool, error) {
@@ -515,6 +1073,24 @@ func (c *Config) CheckExchangeConfigValues() error {
 	return nil
 }
 
+// isValidListenAddress checks that the supplied address contains a valid port
+func isValidListenAddress(address string) bool {
+	if !common.StringContains(address, ":") {
+		return false
+	}
+
+	portStr := common.SplitStrings(address, ":")[1]
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return false
+	}
+
+	if port < 1 || port > 65355 {
+		return false
+	}
+	return true
+}
+
 // CheckWebserverConfigValues checks information before webserver starts and
 // returns an error if values are incorrect.
 func (c *Config) CheckWebserverConfigValues() error {
@@ -522,18 +1098,13 @@ func (c *Config) CheckWebserverConfigValues() error {
 		return errors.New(WarningWebserverCredentialValuesEmpty)
 	}
 
-	if !common.StringContains(c.Webserver.ListenAddress, ":") {
+	if !isValidListenAddress(c.Webserver.ListenAddress) {
 		return errors.New(WarningWebserverListenAddressInvalid)
 	}
 
-	portStr := common.SplitStrings(c.Webserver.ListenAddress, ":")[1]
-	port, err := strconv.Atoi(portStr)
-	if err != nil {
-		return errors.New(WarningWebserverListenAddressInvalid)
-	}
-
-	if port < 1 || port > 65355 {
-		return errors.New(WarningWebserverListenAddressInvalid)
+	if c.Webserver.GRPCEnabled && !isValidListenAddress(c.Webserver.GRPCListenAddress) {
+		log.Println(WarningWebserverGRPCListenAddressInvalid)
+		c.Webserver.GRPCEnabled = false
 	}
 
 	if c.Webserver.WebsocketConnectionLimit <= 0 {
@@ -544,9 +1115,493 @@ func (c *Config) CheckWebserverConfigValues() error {
 		c.Webserver.WebsocketMaxAuthFailures = 3
 	}
 
+	if c.Webserver.AdapterTickerMaxAge <= 0 {
+		c.Webserver.AdapterTickerMaxAge = configDefaultAdapterTickerMaxAge
+	}
+
+	if c.Webserver.SessionTokenExpiry <= 0 {
+		c.Webserver.SessionTokenExpiry = configDefaultSessionTokenExpiry
+	}
+
+	if c.Webserver.APIRateLimit <= 0 {
+		c.Webserver.APIRateLimit = configDefaultAPIRateLimit
+	}
+
+	if c.Webserver.LoginMaxFailures <= 0 {
+		c.Webserver.LoginMaxFailures = configDefaultLoginMaxFailures
+	}
+
+	if c.Webserver.LoginLockoutDuration <= 0 {
+		c.Webserver.LoginLockoutDuration = configDefaultLoginLockoutDuration
+	}
+
+	for i := range c.Webserver.APITokens {
+		if !c.Webserver.APITokens[i].Enabled {
+			continue
+		}
+
+		if c.Webserver.APITokens[i].Token == "" || !IsValidAPIScopes(c.Webserver.APITokens[i].Scopes) {
+			log.Printf(WarningWebserverAPITokenInvalid, c.Webserver.APITokens[i].Name)
+			c.Webserver.APITokens[i].Enabled = false
+		}
+	}
+
+	return nil
+}
+
+// checkSinkConfig validates a sink config and sets its default format
+func checkSinkConfig(sink *SinkConfig) error {
+	if sink.Format == "" {
+		sink.Format = SinkFormatJSON
+	}
+
+	sink.Format = common.StringToLower(sink.Format)
+	if sink.Format != SinkFormatJSON && sink.Format != SinkFormatCSV {
+		return fmt.Errorf("unsupported format %s", sink.Format)
+	}
+
+	switch common.StringToLower(sink.Type) {
+	case SinkTypeFile:
+		if sink.Path == "" {
+			return errors.New("file sink path is empty")
+		}
+
+		if sink.MaxSize < 0 || sink.MaxAge < 0 {
+			return errors.New("file sink rotation size and age can't be negative")
+		}
+	case SinkTypeUDP:
+		if !isValidListenAddress(sink.Address) {
+			return fmt.Errorf("invalid UDP address %s", sink.Address)
+		}
+	case SinkTypeStdout:
+	case SinkTypeKafka, SinkTypeNATS:
+		err := checkBrokerSinkConfig(sink)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported sink type %s", sink.Type)
+	}
+
+	if sink.Events != "" {
+		for _, event := range common.SplitStrings(sink.Events, ",") {
+			switch common.StringToLower(event) {
+			case SinkEventTicker, SinkEventOrderbookSummary, SinkEventTrade:
+			default:
+				return fmt.Errorf("unsupported event %s", event)
+			}
+		}
+	}
+	return nil
+}
+
+// checkBrokerSinkConfig validates a Kafka or NATS sink config and sets its
+// default topic, batching and buffer values
+func checkBrokerSinkConfig(sink *SinkConfig) error {
+	if sink.Brokers == "" {
+		return errors.New("brokers is empty")
+	}
+
+	for _, broker := range common.SplitStrings(sink.Brokers, ",") {
+		if !isValidListenAddress(broker) {
+			return fmt.Errorf("invalid broker address %s", broker)
+		}
+	}
+
+	if sink.Topic == "" {
+		sink.Topic = configDefaultSinkTopic
+	}
+
+	sink.Compression = common.StringToLower(sink.Compression)
+	if sink.Compression == "" {
+		sink.Compression = SinkCompressionNone
+	}
+
+	if sink.Compression != SinkCompressionNone && sink.Compression != SinkCompressionGzip {
+		return fmt.Errorf("unsupported compression %s", sink.Compression)
+	}
+
+	if sink.BatchSize <= 0 {
+		sink.BatchSize = configDefaultSinkBatchSize
+	}
+
+	if sink.BatchInterval <= 0 {
+		sink.BatchInterval = configDefaultSinkBatchInterval
+	}
+
+	if sink.BufferSize < sink.BatchSize {
+		sink.BufferSize = configDefaultSinkBufferSize
+		if sink.BufferSize < sink.BatchSize {
+			sink.BufferSize = sink.BatchSize
+		}
+	}
+	return checkBrokerSinkAuth(sink)
+}
+
+// checkBrokerSinkAuth validates the TLS and authentication settings of a Kafka
+// or NATS sink. Setting any TLS file enables TLS and Kafka sinks with a
+// Username default to the plain SASL mechanism
+func checkBrokerSinkAuth(sink *SinkConfig) error {
+	if (sink.TLSCertFile == "") != (sink.TLSKeyFile == "") {
+		return errors.New("TLS certificate and key files must be set together")
+	}
+
+	if sink.TLSCAFile != "" || sink.TLSCertFile != "" {
+		sink.TLS = true
+	}
+
+	if sink.Password != "" && sink.Username == "" {
+		return errors.New("password set without a username")
+	}
+
+	if common.StringToLower(sink.Type) == SinkTypeNATS {
+		if sink.SASLMechanism != "" {
+			return errors.New("SASL mechanisms are only supported by Kafka sinks")
+		}
+
+		if sink.Token != "" && sink.Username != "" {
+			return errors.New("token and username can't be set together")
+		}
+		return nil
+	}
+
+	if sink.Token != "" {
+		return errors.New("token authentication is only supported by NATS sinks")
+	}
+
+	sink.SASLMechanism = common.StringToLower(sink.SASLMechanism)
+	if sink.SASLMechanism == "" && sink.Username != "" {
+		sink.SASLMechanism = SinkSASLPlain
+	}
+
+	switch sink.SASLMechanism {
+	case "":
+	case SinkSASLPlain, SinkSASLSCRAMSHA256, SinkSASLSCRAMSHA512:
+		if sink.Username == "" || sink.Password == "" {
+			return fmt.Errorf("SASL mechanism %s requires a username and password",
+				sink.SASLMechanism)
+		}
+	default:
+		return fmt.Errorf("unsupported SASL mechanism %s", sink.SASLMechanism)
+	}
+	return nil
+}
+
+// CheckSinksConfigValues checks the output sinks and disables those with an
+// invalid config
+func (c *Config) CheckSinksConfigValues() {
+	for i := range c.Sinks {
+		if !c.Sinks[i].Enabled {
+			continue
+		}
+
+		if c.Sinks[i].Name == "" {
+			c.Sinks[i].Name = fmt.Sprintf("Sink #%d", i)
+		}
+
+		err := checkSinkConfig(&c.Sinks[i])
+		if err != nil {
+			log.Printf(WarningSinkInvalid, c.Sinks[i].Name, err)
+			c.Sinks[i].Enabled = false
+		}
+	}
+}
+
+// checkWebhookConfig validates a webhook config and sets its default timeout
+func checkWebhookConfig(webhook *WebhookConfig) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %s", webhook.URL)
+	}
+
+	if webhook.Secret == "" {
+		return errors.New("secret is empty")
+	}
+
+	if webhook.Events != "" {
+		for _, event := range common.SplitStrings(webhook.Events, ",") {
+			switch common.StringToLower(event) {
+			case WebhookEventTicker, WebhookEventTrigger, WebhookEventHealth:
+			default:
+				return fmt.Errorf("unsupported event %s", event)
+			}
+		}
+	}
+
+	if webhook.Timeout <= 0 {
+		webhook.Timeout = configDefaultWebhookTimeout
+	}
 	return nil
 }
 
+// CheckRedisConfigValues sets the Redis cache defaults and disables the cache
+// if the config is invalid
+func (c *Config) CheckRedisConfigValues() {
+	if !c.Redis.Enabled {
+		return
+	}
+
+	if c.Redis.Address == "" {
+		c.Redis.Address = configDefaultRedisAddress
+	}
+
+	if c.Redis.KeyPrefix == "" {
+		c.Redis.KeyPrefix = configDefaultRedisKeyPrefix
+	}
+
+	if c.Redis.TickerTTL <= 0 {
+		c.Redis.TickerTTL = c.Webserver.AdapterTickerMaxAge
+		if c.Redis.TickerTTL <= 0 {
+			c.Redis.TickerTTL = configDefaultAdapterTickerMaxAge
+		}
+	}
+
+	if c.Redis.FXRatesTTL <= 0 {
+		c.Redis.FXRatesTTL = configDefaultRedisFXRatesTTL
+	}
+
+	var err error
+	switch {
+	case !isValidListenAddress(c.Redis.Address):
+		err = fmt.Errorf("invalid address %s", c.Redis.Address)
+	case c.Redis.Database < 0:
+		err = fmt.Errorf("invalid database %d", c.Redis.Database)
+	}
+
+	if err != nil {
+		log.Printf(WarningRedisInvalid, err)
+		c.Redis.Enabled = false
+	}
+}
+
+// CheckArbitrageConfigValues sets the arbitrage monitor defaults and disables
+// the monitor if the config is invalid
+func (c *Config) CheckArbitrageConfigValues() {
+	if !c.Arbitrage.Enabled {
+		return
+	}
+
+	if c.Arbitrage.Threshold == 0 {
+		c.Arbitrage.Threshold = configDefaultArbitrageThreshold
+	}
+
+	if c.Arbitrage.QuoteCurrency == "" {
+		c.Arbitrage.QuoteCurrency = c.Currency.FiatDisplayCurrency
+		if c.Arbitrage.QuoteCurrency == "" {
+			c.Arbitrage.QuoteCurrency = configDefaultArbitrageQuoteCurrency
+		}
+	}
+	c.Arbitrage.QuoteCurrency = common.StringToUpper(c.Arbitrage.QuoteCurrency)
+
+	if c.Arbitrage.MaxTickerAge <= 0 {
+		c.Arbitrage.MaxTickerAge = c.Webserver.AdapterTickerMaxAge
+		if c.Arbitrage.MaxTickerAge <= 0 {
+			c.Arbitrage.MaxTickerAge = configDefaultAdapterTickerMaxAge
+		}
+	}
+
+	if c.Arbitrage.HistorySize <= 0 {
+		c.Arbitrage.HistorySize = configDefaultArbitrageHistorySize
+	}
+
+	var err error
+	switch {
+	case c.Arbitrage.Threshold < 0:
+		err = fmt.Errorf("invalid threshold %v", c.Arbitrage.Threshold)
+	case c.Arbitrage.DefaultTakerFee < 0 || c.Arbitrage.DefaultTakerFee >= 100:
+		err = fmt.Errorf("invalid default taker fee %v", c.Arbitrage.DefaultTakerFee)
+	}
+
+	if err != nil {
+		log.Printf(WarningArbitrageInvalid, err)
+		c.Arbitrage.Enabled = false
+	}
+}
+
+// CheckChartsConfigValues sets the price chart defaults and disables charts
+// if the config is invalid
+func (c *Config) CheckChartsConfigValues() {
+	if !c.Charts.Enabled {
+		return
+	}
+
+	if c.Charts.Style == "" {
+		c.Charts.Style = charts.StyleCandlestick
+	}
+
+	if c.Charts.Width == 0 {
+		c.Charts.Width = charts.DefaultWidth
+	}
+
+	if c.Charts.Height == 0 {
+		c.Charts.Height = charts.DefaultHeight
+	}
+
+	if c.Charts.History <= 0 {
+		c.Charts.History = configDefaultChartsHistory
+	}
+
+	if c.Charts.Period <= 0 {
+		c.Charts.Period = configDefaultChartsPeriod
+		if c.Charts.Period > c.Charts.History {
+			c.Charts.Period = c.Charts.History
+		}
+	}
+
+	if c.Charts.Candles <= 0 {
+		c.Charts.Candles = configDefaultChartsCandles
+	}
+
+	var err error
+	c.Charts.Style = common.StringToLower(c.Charts.Style)
+	switch {
+	case c.Charts.Style != charts.StyleCandlestick && c.Charts.Style != charts.StyleLine:
+		err = fmt.Errorf("unsupported style %s", c.Charts.Style)
+	case c.Charts.Width < charts.MinWidth || c.Charts.Width > charts.MaxWidth:
+		err = fmt.Errorf("width must be between %d and %d", charts.MinWidth, charts.MaxWidth)
+	case c.Charts.Height < charts.MinHeight || c.Charts.Height > charts.MaxHeight:
+		err = fmt.Errorf("height must be between %d and %d", charts.MinHeight, charts.MaxHeight)
+	case c.Charts.Period > c.Charts.History:
+		err = errors.New("period exceeds the history")
+	case c.Charts.Candles > configMaxChartsCandles:
+		err = fmt.Errorf("candles must not exceed %d", configMaxChartsCandles)
+	}
+
+	if err != nil {
+		log.Printf(WarningChartsInvalid, err)
+		c.Charts.Enabled = false
+	}
+}
+
+// CheckDigestConfigValues sets the digest report defaults and disables the
+// reports if the config is invalid
+func (c *Config) CheckDigestConfigValues() {
+	if !c.Digest.Enabled {
+		return
+	}
+
+	if c.Digest.History <= 0 {
+		c.Digest.History = configDefaultDigestHistory
+	}
+
+	if c.Digest.StaleAfter <= 0 {
+		c.Digest.StaleAfter = configDefaultDigestStaleAfter
+	}
+
+	if c.Digest.TopMovers <= 0 {
+		c.Digest.TopMovers = configDefaultDigestTopMovers
+	}
+
+	for i := range c.Digest.Reports {
+		err := checkDigestReportConfig(&c.Digest.Reports[i])
+		if err != nil {
+			log.Printf(WarningDigestInvalid, fmt.Errorf("report %s %s", c.Digest.Reports[i].Name, err))
+			c.Digest.Enabled = false
+			return
+		}
+	}
+}
+
+// checkDigestReportConfig validates a digest report and sets its default
+// sections
+func checkDigestReportConfig(report *DigestReportConfig) error {
+	if _, err := schedule.Parse(report.Schedule); err != nil {
+		return err
+	}
+
+	if _, err := time.LoadLocation(report.Location); err != nil {
+		return fmt.Errorf("invalid location %s", report.Location)
+	}
+
+	if len(report.Sections) == 0 {
+		report.Sections = []string{DigestSectionMarket, DigestSectionPortfolio, DigestSectionHealth}
+	}
+
+	for i := range report.Sections {
+		report.Sections[i] = common.StringToLower(report.Sections[i])
+		switch report.Sections[i] {
+		case DigestSectionMarket, DigestSectionPortfolio, DigestSectionHealth:
+		default:
+			return fmt.Errorf("unsupported section %s", report.Sections[i])
+		}
+	}
+
+	if len(report.Recipients) == 0 {
+		return errors.New("has no recipients")
+	}
+
+	for i := range report.Recipients {
+		if report.Recipients[i].Medium == "" {
+			return errors.New("has a recipient without a medium")
+		}
+	}
+	return nil
+}
+
+// CheckWebhooksConfigValues sets the webhook delivery defaults and disables
+// webhooks with an invalid config
+func (c *Config) CheckWebhooksConfigValues() {
+	if c.Webhooks.MaxAttempts <= 0 {
+		c.Webhooks.MaxAttempts = configDefaultWebhookMaxAttempts
+	}
+
+	if c.Webhooks.InitialBackoff <= 0 {
+		c.Webhooks.InitialBackoff = configDefaultWebhookInitialBackoff
+	}
+
+	if c.Webhooks.MaxBackoff < c.Webhooks.InitialBackoff {
+		c.Webhooks.MaxBackoff = configDefaultWebhookMaxBackoff
+		if c.Webhooks.MaxBackoff < c.Webhooks.InitialBackoff {
+			c.Webhooks.MaxBackoff = c.Webhooks.InitialBackoff
+		}
+	}
+
+	if c.Webhooks.HistorySize <= 0 {
+		c.Webhooks.HistorySize = configDefaultWebhookHistorySize
+	}
+
+	if c.Webhooks.MaxQueueSize <= 0 {
+		c.Webhooks.MaxQueueSize = configDefaultWebhookMaxQueueSize
+	}
+
+	for i := range c.Webhooks.Endpoints {
+		if !c.Webhooks.Endpoints[i].Enabled {
+			continue
+		}
+
+		if c.Webhooks.Endpoints[i].Name == "" {
+			c.Webhooks.Endpoints[i].Name = fmt.Sprintf("Webhook #%d", i)
+		}
+
+		err := checkWebhookConfig(&c.Webhooks.Endpoints[i])
+		if err != nil {
+			log.Printf(WarningWebhookInvalid, c.Webhooks.Endpoints[i].Name, err)
+			c.Webhooks.Endpoints[i].Enabled = false
+		}
+	}
+}
+
+// IsValidAPIScopes returns whether or not the comma separated scopes are
+// non-empty and supported
+func IsValidAPIScopes(scopes string) bool {
+	if scopes == "" {
+		return false
+	}
+
+	for _, scope := range common.SplitStrings(scopes, ",") {
+		switch common.StringToLower(scope) {
+		case APIScopeReadMarket, APIScopeReadPortfolio, APIScopeAdmin:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // CheckCurrencyConfigValues checks to see if the currency config values are correct or not
 func (c *Config) CheckCurrencyConfigValues() error {
 	if len(c.Currency.ForexProviders) == 0 {
@@ -574,6 +1629,10 @@ func (c *Config) CheckCurrencyConfigValues() error {
 	count := 0
 	for i := range c.Currency.ForexProviders {
 		if c.Currency.ForexProviders[i].Enabled == true {
+			if c.Currency.ForexProviders[i].Name == "ECB" {
+				count++
+				continue
+			}
 			if c.Currency.ForexProviders[i].APIKey == "Key" {
 				log.Printf("WARNING -- %s forex provider API key not set. Please set this in your config.json file", c.Currency.ForexProviders[i].Name)
 				c.Currency.ForexProviders[i].Enabled = false
@@ -596,9 +1655,32 @@ func (c *Config) CheckCurrencyConfigValues() error {
 				c.Currency.ForexProviders[x].PrimaryProvider = true
 				log.Printf("WARNING -- No forex providers set, defaulting to free provider CurrencyConverterAPI.")
 			}
+			if c.Currency.ForexProviders[x].Name == "ECB" {
+				c.Currency.ForexProviders[x].Enabled = true
+				log.Printf("WARNING -- No forex providers set, enabling free provider ECB as a fallback.")
+			}
 		}
 	}
 
+	primary := -1
+	for i := range c.Currency.ForexProviders {
+		if !c.Currency.ForexProviders[i].Enabled {
+			continue
+		}
+		if c.Currency.ForexProviders[i].PrimaryProvider {
+			primary = i
+			break
+		}
+		if primary == -1 {
+			primary = i
+		}
+	}
+
+	if primary != -1 && !c.Currency.ForexProviders[primary].PrimaryProvider {
+		log.Printf("WARNING -- No primary forex provider set, defaulting to %s.", c.Currency.ForexProviders[primary].Name)
+		c.Currency.ForexProviders[primary].PrimaryProvider = true
+	}
+
 	if len(c.Currency.Cryptocurrencies) == 0 {
 		if len(c.Cryptocurrencies) != 0 {
 			c.Currency.Cryptocurrencies = c.Cryptocurrencies
@@ -628,6 +1710,23 @@ func (c *Config) CheckCurrencyConfigValues() error {
 			c.Currency.FiatDisplayCurrency = "USD"
 		}
 	}
+
+	if c.Currency.FXRefreshInterval <= 0 {
+		c.Currency.FXRefreshInterval = configDefaultFXRefreshInterval
+	}
+
+	if c.Currency.FXStaleAfter <= 0 {
+		c.Currency.FXStaleAfter = configDefaultFXStaleAfter
+	}
+
+	if c.Currency.FXStaleAfter < c.Currency.FXRefreshInterval {
+		log.Printf(WarningFXStaleAfter, c.Currency.FXRefreshInterval)
+		c.Currency.FXStaleAfter = c.Currency.FXRefreshInterval * 2
+	}
+
+	if c.Currency.FXDeviationTolerance <= 0 {
+		c.Currency.FXDeviationTolerance = configDefaultFXDeviationTolerance
+	}
 	return nil
 }
 
@@ -804,7 +1903,9 @@ func (c *Config) SaveConfig(configPath string) error {
 		return err
 	}
 
+	m.Lock()
 	payload, err := json.MarshalIndent(c, "", " ")
+	m.Unlock()
 	if err != nil {
 		return err
 	}
@@ -858,6 +1959,13 @@ func (c *Config) CheckConfig() error {
 		return err
 	}
 
+	c.CheckSinksConfigValues()
+	c.CheckWebhooksConfigValues()
+	c.CheckRedisConfigValues()
+	c.CheckArbitrageConfigValues()
+	c.CheckDigestConfigValues()
+	c.CheckChartsConfigValues()
+
 	if c.GlobalHTTPTimeout <= 0 {
 		log.Printf("Global HTTP Timeout value not set, defaulting to %v.", configDefaultHTTPTimeout)
 		c.GlobalHTTPTimeout = configDefaultHTTPTimeout
@@ -876,8 +1984,186 @@ func (c *Config) LoadConfig(configPath string) error {
 	return c.CheckConfig()
 }
 
+// redactSecret returns the redacted placeholder for non-empty secrets
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return RedactedSecret
+}
+
+// restoreSecret returns the current secret if the supplied secret is the
+// redacted placeholder
+func restoreSecret(secret, current string) string {
+	if secret != RedactedSecret {
+		return secret
+	}
+	return current
+}
+
+// RedactSecrets returns a copy of the config with all API keys, passwords and
+// tokens replaced by the redacted placeholder
+func (c *Config) RedactSecrets() Config {
+	m.Lock()
+	defer m.Unlock()
+
+	redacted := *c
+	redacted.Webserver.AdminPassword = redactSecret(c.Webserver.AdminPassword)
+	redacted.Webserver.APITokens = make([]APITokenConfig, len(c.Webserver.APITokens))
+	for i := range c.Webserver.APITokens {
+		redacted.Webserver.APITokens[i] = c.Webserver.APITokens[i]
+		redacted.Webserver.APITokens[i].Token = redactSecret(c.Webserver.APITokens[i].Token)
+	}
+
+	redacted.Exchanges = make([]ExchangeConfig, len(c.Exchanges))
+	for i := range c.Exchanges {
+		redacted.Exchanges[i] = c.Exchanges[i]
+		redacted.Exchanges[i].APIKey = redactSecret(c.Exchanges[i].APIKey)
+		redacted.Exchanges[i].APISecret = redactSecret(c.Exchanges[i].APISecret)
+		redacted.Exchanges[i].ClientID = redactSecret(c.Exchanges[i].ClientID)
+	}
+
+	redacted.Currency.ForexProviders = make([]base.Settings, len(c.Currency.ForexProviders))
+	for i := range c.Currency.ForexProviders {
+		redacted.Currency.ForexProviders[i] = c.Currency.ForexProviders[i]
+		redacted.Currency.ForexProviders[i].APIKey = redactSecret(c.Currency.ForexProviders[i].APIKey)
+	}
+
+	redacted.Webhooks.Endpoints = make([]WebhookConfig, len(c.Webhooks.Endpoints))
+	for i := range c.Webhooks.Endpoints {
+		redacted.Webhooks.Endpoints[i] = c.Webhooks.Endpoints[i]
+		redacted.Webhooks.Endpoints[i].Secret = redactSecret(c.Webhooks.Endpoints[i].Secret)
+	}
+
+	redacted.Sinks = make([]SinkConfig, len(c.Sinks))
+	for i := range c.Sinks {
+		redacted.Sinks[i] = c.Sinks[i]
+		redacted.Sinks[i].Password = redactSecret(c.Sinks[i].Password)
+		redacted.Sinks[i].Token = redactSecret(c.Sinks[i].Token)
+	}
+
+	redacted.Redis.Password = redactSecret(c.Redis.Password)
+
+	comms := &redacted.Communications
+	comms.SlackConfig.VerificationToken = redactSecret(comms.SlackConfig.VerificationToken)
+	comms.SMSGlobalConfig.Password = redactSecret(comms.SMSGlobalConfig.Password)
+	comms.SMTPConfig.AccountPassword = redactSecret(comms.SMTPConfig.AccountPassword)
+	comms.TelegramConfig.VerificationToken = redactSecret(comms.TelegramConfig.VerificationToken)
+	comms.DiscordConfig.WebhookURL = redactSecret(comms.DiscordConfig.WebhookURL)
+	comms.DiscordConfig.BotToken = redactSecret(comms.DiscordConfig.BotToken)
+	comms.MattermostConfig.WebhookURL = redactSecret(comms.MattermostConfig.WebhookURL)
+	comms.MattermostConfig.BotToken = redactSecret(comms.MattermostConfig.BotToken)
+
+	if c.SMS != nil {
+		sms := *c.SMS
+		sms.Password = redactSecret(sms.Password)
+		redacted.SMS = &sms
+	}
+	return redacted
+}
+
+// RestoreRedactedSecrets replaces redacted placeholders in newCfg with the
+// current secrets so a redacted config payload can be saved without losing
+// credentials. Placeholders without a matching current value are cleared
+func (c *Config) RestoreRedactedSecrets(newCfg *Config) {
+	m.Lock()
+	defer m.Unlock()
+
+	newCfg.Webserver.AdminPassword = restoreSecret(newCfg.Webserver.AdminPassword,
+		c.Webserver.AdminPassword)
+	for i := range newCfg.Webserver.APITokens {
+		var current string
+		for x := range c.Webserver.APITokens {
+			if c.Webserver.APITokens[x].Name == newCfg.Webserver.APITokens[i].Name {
+				current = c.Webserver.APITokens[x].Token
+				break
+			}
+		}
+		newCfg.Webserver.APITokens[i].Token = restoreSecret(newCfg.Webserver.APITokens[i].Token,
+			current)
+	}
+
+	for i := range newCfg.Exchanges {
+		var current ExchangeConfig
+		for x := range c.Exchanges {
+			if c.Exchanges[x].Name == newCfg.Exchanges[i].Name {
+				current = c.Exchanges[x]
+				break
+			}
+		}
+		newCfg.Exchanges[i].APIKey = restoreSecret(newCfg.Exchanges[i].APIKey, current.APIKey)
+		newCfg.Exchanges[i].APISecret = restoreSecret(newCfg.Exchanges[i].APISecret, current.APISecret)
+		newCfg.Exchanges[i].ClientID = restoreSecret(newCfg.Exchanges[i].ClientID, current.ClientID)
+	}
+
+	for i := range newCfg.Currency.ForexProviders {
+		var current string
+		for x := range c.Currency.ForexProviders {
+			if c.Currency.ForexProviders[x].Name == newCfg.Currency.ForexProviders[i].Name {
+				current = c.Currency.ForexProviders[x].APIKey
+				break
+			}
+		}
+		newCfg.Currency.ForexProviders[i].APIKey = restoreSecret(newCfg.Currency.ForexProviders[i].APIKey,
+			current)
+	}
+
+	for i := range newCfg.Webhooks.Endpoints {
+		var current string
+		for x := range c.Webhooks.Endpoints {
+			if c.Webhooks.Endpoints[x].Name == newCfg.Webhooks.Endpoints[i].Name {
+				current = c.Webhooks.Endpoints[x].Secret
+				break
+			}
+		}
+		newCfg.Webhooks.Endpoints[i].Secret = restoreSecret(newCfg.Webhooks.Endpoints[i].Secret,
+			current)
+	}
+
+	for i := range newCfg.Sinks {
+		var current SinkConfig
+		for x := range c.Sinks {
+			if c.Sinks[x].Name == newCfg.Sinks[i].Name {
+				current = c.Sinks[x]
+				break
+			}
+		}
+		newCfg.Sinks[i].Password = restoreSecret(newCfg.Sinks[i].Password, current.Password)
+		newCfg.Sinks[i].Token = restoreSecret(newCfg.Sinks[i].Token, current.Token)
+	}
+
+	newCfg.Redis.Password = restoreSecret(newCfg.Redis.Password, c.Redis.Password)
+
+	comms := &newCfg.Communications
+	comms.SlackConfig.VerificationToken = restoreSecret(comms.SlackConfig.VerificationToken,
+		c.Communications.SlackConfig.VerificationToken)
+	comms.SMSGlobalConfig.Password = restoreSecret(comms.SMSGlobalConfig.Password,
+		c.Communications.SMSGlobalConfig.Password)
+	comms.SMTPConfig.AccountPassword = restoreSecret(comms.SMTPConfig.AccountPassword,
+		c.Communications.SMTPConfig.AccountPassword)
+	comms.TelegramConfig.VerificationToken = restoreSecret(comms.TelegramConfig.VerificationToken,
+		c.Communications.TelegramConfig.VerificationToken)
+	comms.DiscordConfig.WebhookURL = restoreSecret(comms.DiscordConfig.WebhookURL,
+		c.Communications.DiscordConfig.WebhookURL)
+	comms.DiscordConfig.BotToken = restoreSecret(comms.DiscordConfig.BotToken,
+		c.Communications.DiscordConfig.BotToken)
+	comms.MattermostConfig.WebhookURL = restoreSecret(comms.MattermostConfig.WebhookURL,
+		c.Communications.MattermostConfig.WebhookURL)
+	comms.MattermostConfig.BotToken = restoreSecret(comms.MattermostConfig.BotToken,
+		c.Communications.MattermostConfig.BotToken)
+
+	if newCfg.SMS != nil {
+		var current string
+		if c.SMS != nil {
+			current = c.SMS.Password
+		}
+		newCfg.SMS.Password = restoreSecret(newCfg.SMS.Password, current)
+	}
+}
+
 // UpdateConfig updates the config with a supplied config file
 func (c *Config) UpdateConfig(configPath string, newCfg Config) error {
+	c.RestoreRedactedSecrets(&newCfg)
 	err := newCfg.CheckConfig()
 	if err != nil {
 		return err
@@ -891,6 +2177,11 @@ func (c *Config) UpdateConfig(configPath string, newCfg Config) error {
 	c.Communications = newCfg.Communications
 	c.Webserver = newCfg.Webserver
 	c.Exchanges = newCfg.Exchanges
+	c.Sinks = newCfg.Sinks
+	c.Webhooks = newCfg.Webhooks
+	c.Redis = newCfg.Redis
+	c.Events = newCfg.Events
+	c.Arbitrage = newCfg.Arbitrage
 
 	err = c.SaveConfig(configPath)
 	if err != nil {
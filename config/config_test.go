@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
@@ -361,6 +362,366 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 	}
 }
 
+func TestRedactSecrets(t *testing.T) {
+	var cfg Config
+	cfg.Webserver.AdminPassword = "Password"
+	cfg.Webserver.APITokens = []APITokenConfig{{Name: "test", Token: "token"}}
+	cfg.Exchanges = []ExchangeConfig{{Name: "Bitfinex", APIKey: "key", APISecret: "secret"}}
+	cfg.Communications.SMTPConfig.AccountPassword = "smtp"
+	cfg.Communications.DiscordConfig.WebhookURL = "https://discordapp.com/api/webhooks/1/secret"
+	cfg.Webhooks.Endpoints = []WebhookConfig{{Name: "hook", Secret: "hmac"}}
+	cfg.Redis.Password = "redis"
+	cfg.Sinks = []SinkConfig{{Name: "kafka", Username: "user", Password: "sasl"}}
+
+	redacted := cfg.RedactSecrets()
+	if redacted.Webserver.AdminPassword != RedactedSecret ||
+		redacted.Webhooks.Endpoints[0].Secret != RedactedSecret ||
+		redacted.Redis.Password != RedactedSecret ||
+		redacted.Sinks[0].Password != RedactedSecret ||
+		redacted.Sinks[0].Username != "user" ||
+		redacted.Webserver.APITokens[0].Token != RedactedSecret ||
+		redacted.Exchanges[0].APIKey != RedactedSecret ||
+		redacted.Exchanges[0].APISecret != RedactedSecret ||
+		redacted.Exchanges[0].ClientID != "" ||
+		redacted.Communications.SMTPConfig.AccountPassword != RedactedSecret ||
+		redacted.Communications.DiscordConfig.WebhookURL != RedactedSecret ||
+		redacted.Communications.DiscordConfig.BotToken != "" {
+		t.Error("Test failed. RedactSecrets did not redact all secrets")
+	}
+
+	if cfg.Exchanges[0].APIKey != "key" || cfg.Webserver.APITokens[0].Token != "token" {
+		t.Error("Test failed. RedactSecrets modified the original config")
+	}
+
+	redacted.Exchanges[0].APIKey = "newkey"
+	redacted.Exchanges = append(redacted.Exchanges,
+		ExchangeConfig{Name: "Kraken", APIKey: RedactedSecret})
+	cfg.RestoreRedactedSecrets(&redacted)
+	if redacted.Webserver.AdminPassword != "Password" ||
+		redacted.Webserver.APITokens[0].Token != "token" ||
+		redacted.Webhooks.Endpoints[0].Secret != "hmac" ||
+		redacted.Redis.Password != "redis" ||
+		redacted.Sinks[0].Password != "sasl" ||
+		redacted.Exchanges[0].APIKey != "newkey" ||
+		redacted.Exchanges[0].APISecret != "secret" ||
+		redacted.Exchanges[1].APIKey != "" ||
+		redacted.Communications.SMTPConfig.AccountPassword != "smtp" ||
+		redacted.Communications.DiscordConfig.WebhookURL != cfg.Communications.DiscordConfig.WebhookURL {
+		t.Error("Test failed. RestoreRedactedSecrets did not restore secrets")
+	}
+}
+
+func TestCheckCommunicationsConfig(t *testing.T) {
+	var cfg Config
+	err := cfg.CheckCommunicationsConfig()
+	if err != nil || cfg.Communications.DiscordConfig.Name != "Discord" ||
+		cfg.Communications.MattermostConfig.Name != "Mattermost" {
+		t.Fatal("Test failed. CheckCommunicationsConfig did not populate defaults", err)
+	}
+
+	cfg.Communications.DiscordConfig.Enabled = true
+	cfg.Communications.DiscordConfig.WebhookURL = "discordapp.com/api/webhooks/1/secret"
+	if err = cfg.CheckCommunicationsConfig(); err == nil {
+		t.Error("Test failed. CheckCommunicationsConfig accepted an invalid Discord webhook URL")
+	}
+
+	cfg.Communications.DiscordConfig.WebhookURL = "https://discordapp.com/api/webhooks/1/secret"
+	cfg.Communications.DiscordConfig.BotToken = "token"
+	if err = cfg.CheckCommunicationsConfig(); err == nil {
+		t.Error("Test failed. CheckCommunicationsConfig accepted a Discord bot without a channel")
+	}
+
+	cfg.Communications.DiscordConfig.ChannelID = "1"
+	cfg.Communications.MattermostConfig.Enabled = true
+	cfg.Communications.MattermostConfig.BotToken = "token"
+	cfg.Communications.MattermostConfig.ChannelID = "1"
+	if err = cfg.CheckCommunicationsConfig(); err == nil {
+		t.Error("Test failed. CheckCommunicationsConfig accepted a Mattermost bot without a server URL")
+	}
+
+	cfg.Communications.MattermostConfig.ServerURL = "https://mattermost.example.com"
+	if err = cfg.CheckCommunicationsConfig(); err != nil {
+		t.Error("Test failed. CheckCommunicationsConfig error", err)
+	}
+}
+
+func TestCheckNotificationsConfig(t *testing.T) {
+	target := []NotificationTargetConfig{{Medium: "Slack"}}
+	for _, cfg := range []NotificationsConfig{
+		{Routes: []NotificationRouteConfig{{Name: "empty"}}},
+		{Routes: []NotificationRouteConfig{{Name: "severity", MinSeverity: "urgent", Targets: target}}},
+		{RateLimits: []NotificationRateLimitConfig{{Medium: "SMSGlobal", MaxEvents: 1}}},
+		{QuietHours: QuietHoursConfig{Enabled: true, Start: "22:00", End: "7am"}},
+		{QuietHours: QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Location: "Mars/Olympus"}},
+		{Escalations: []NotificationEscalationConfig{{Name: "timeout", Medium: "Slack", Targets: target}}},
+	} {
+		if err := checkNotificationsConfig(&cfg); err == nil {
+			t.Errorf("Test failed. checkNotificationsConfig accepted %+v", cfg)
+		}
+	}
+
+	cfg := NotificationsConfig{
+		Routes:     []NotificationRouteConfig{{Name: "critical", MinSeverity: "Critical", Targets: target}},
+		QuietHours: QuietHoursConfig{Enabled: true, Start: "22:00", End: "07:00", Location: "Australia/Sydney"},
+	}
+	err := checkNotificationsConfig(&cfg)
+	if err != nil || cfg.QuietHours.BypassSeverity != NotificationSeverityCritical {
+		t.Error("Test failed. checkNotificationsConfig error", err)
+	}
+}
+
+func TestCheckSinksConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Sinks = []SinkConfig{
+		{Name: "jsonl", Type: SinkTypeFile, Enabled: true, Path: "feed.jsonl"},
+		{Name: "csv", Type: SinkTypeFile, Enabled: true, Format: "CSV", Path: "feed.csv", Events: "ticker,trade"},
+		{Name: "udp", Type: SinkTypeUDP, Enabled: true, Address: "127.0.0.1:9999"},
+		{Name: "stdout", Type: SinkTypeStdout, Enabled: true},
+		{Name: "nopath", Type: SinkTypeFile, Enabled: true},
+		{Name: "badaddress", Type: SinkTypeUDP, Enabled: true, Address: "localhost"},
+		{Name: "badformat", Type: SinkTypeStdout, Enabled: true, Format: "xml"},
+		{Name: "badtype", Type: "kafka", Enabled: true},
+		{Name: "badevent", Type: SinkTypeStdout, Enabled: true, Events: "ticker,candle"},
+		{Type: SinkTypeStdout, Enabled: true},
+		{Name: "kafka", Type: SinkTypeKafka, Enabled: true, Brokers: "localhost:9092,localhost:9093", Compression: "GZIP"},
+		{Name: "nats", Type: SinkTypeNATS, Enabled: true, Brokers: "localhost:4222", BatchSize: 20000},
+		{Name: "nobrokers", Type: SinkTypeKafka, Enabled: true},
+		{Name: "badcompression", Type: SinkTypeNATS, Enabled: true, Brokers: "localhost:4222", Compression: "lz4"},
+		{Name: "kafkasasl", Type: SinkTypeKafka, Enabled: true, Brokers: "localhost:9093", Username: "user", Password: "pass", TLSCAFile: "ca.pem"},
+		{Name: "natstoken", Type: SinkTypeNATS, Enabled: true, Brokers: "localhost:4222", Token: "token", TLS: true},
+		{Name: "badsasl", Type: SinkTypeKafka, Enabled: true, Brokers: "localhost:9093", Username: "user", Password: "pass", SASLMechanism: "gssapi"},
+		{Name: "natsusertoken", Type: SinkTypeNATS, Enabled: true, Brokers: "localhost:4222", Username: "user", Token: "token"},
+		{Name: "nokeyfile", Type: SinkTypeKafka, Enabled: true, Brokers: "localhost:9093", TLSCertFile: "client.pem"},
+		{Name: "kafkatoken", Type: SinkTypeKafka, Enabled: true, Brokers: "localhost:9093", Token: "token"},
+	}
+
+	cfg.CheckSinksConfigValues()
+	for i := 0; i < 4; i++ {
+		if !cfg.Sinks[i].Enabled {
+			t.Errorf("Test failed. CheckSinksConfigValues disabled valid sink %s",
+				cfg.Sinks[i].Name)
+		}
+	}
+
+	for i := 4; i < 9; i++ {
+		if cfg.Sinks[i].Enabled {
+			t.Errorf("Test failed. CheckSinksConfigValues did not disable invalid sink %s",
+				cfg.Sinks[i].Name)
+		}
+	}
+
+	if cfg.Sinks[0].Format != SinkFormatJSON || cfg.Sinks[1].Format != SinkFormatCSV {
+		t.Error("Test failed. CheckSinksConfigValues did not set the sink format")
+	}
+
+	if cfg.Sinks[9].Name == "" {
+		t.Error("Test failed. CheckSinksConfigValues did not set the sink name")
+	}
+
+	kafka := cfg.Sinks[10]
+	if !kafka.Enabled || kafka.Topic != configDefaultSinkTopic ||
+		kafka.Compression != SinkCompressionGzip ||
+		kafka.BatchSize != configDefaultSinkBatchSize ||
+		kafka.BatchInterval != configDefaultSinkBatchInterval ||
+		kafka.BufferSize != configDefaultSinkBufferSize {
+		t.Errorf("Test failed. CheckSinksConfigValues unexpected Kafka sink %v", kafka)
+	}
+
+	if !cfg.Sinks[11].Enabled || cfg.Sinks[11].BufferSize != 20000 {
+		t.Error("Test failed. CheckSinksConfigValues did not raise the buffer size to the batch size")
+	}
+
+	if cfg.Sinks[12].Enabled || cfg.Sinks[13].Enabled {
+		t.Error("Test failed. CheckSinksConfigValues did not disable invalid broker sinks")
+	}
+
+	kafka = cfg.Sinks[14]
+	if !kafka.Enabled || !kafka.TLS || kafka.SASLMechanism != SinkSASLPlain {
+		t.Errorf("Test failed. CheckSinksConfigValues unexpected authenticated Kafka sink %v", kafka)
+	}
+
+	if !cfg.Sinks[15].Enabled {
+		t.Error("Test failed. CheckSinksConfigValues disabled a NATS sink using a token")
+	}
+
+	for i := 16; i < len(cfg.Sinks); i++ {
+		if cfg.Sinks[i].Enabled {
+			t.Errorf("Test failed. CheckSinksConfigValues did not disable invalid broker sink %s",
+				cfg.Sinks[i].Name)
+		}
+	}
+}
+
+func TestCheckWebhooksConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Webhooks.MaxBackoff = time.Second
+	cfg.Webhooks.Endpoints = []WebhookConfig{
+		{Name: "valid", Enabled: true, URL: "https://example.com/hook", Secret: "secret", Events: "ticker,health"},
+		{Name: "noscheme", Enabled: true, URL: "example.com/hook", Secret: "secret"},
+		{Name: "nosecret", Enabled: true, URL: "https://example.com/hook"},
+		{Name: "badevent", Enabled: true, URL: "https://example.com/hook", Secret: "secret", Events: "orderbook"},
+	}
+
+	cfg.CheckWebhooksConfigValues()
+	if cfg.Webhooks.MaxAttempts != configDefaultWebhookMaxAttempts ||
+		cfg.Webhooks.InitialBackoff != configDefaultWebhookInitialBackoff ||
+		cfg.Webhooks.MaxBackoff != configDefaultWebhookMaxBackoff ||
+		cfg.Webhooks.HistorySize != configDefaultWebhookHistorySize ||
+		cfg.Webhooks.MaxQueueSize != configDefaultWebhookMaxQueueSize {
+		t.Error("Test failed. CheckWebhooksConfigValues did not set the defaults")
+	}
+
+	if !cfg.Webhooks.Endpoints[0].Enabled ||
+		cfg.Webhooks.Endpoints[0].Timeout != configDefaultWebhookTimeout {
+		t.Error("Test failed. CheckWebhooksConfigValues disabled a valid webhook")
+	}
+
+	for i := 1; i < len(cfg.Webhooks.Endpoints); i++ {
+		if cfg.Webhooks.Endpoints[i].Enabled {
+			t.Errorf("Test failed. CheckWebhooksConfigValues did not disable invalid webhook %s",
+				cfg.Webhooks.Endpoints[i].Name)
+		}
+	}
+}
+
+func TestCheckRedisConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Redis.Enabled = true
+	cfg.Webserver.AdapterTickerMaxAge = time.Second * 30
+
+	cfg.CheckRedisConfigValues()
+	if !cfg.Redis.Enabled ||
+		cfg.Redis.Address != configDefaultRedisAddress ||
+		cfg.Redis.KeyPrefix != configDefaultRedisKeyPrefix ||
+		cfg.Redis.TickerTTL != time.Second*30 ||
+		cfg.Redis.FXRatesTTL != configDefaultRedisFXRatesTTL {
+		t.Error("Test failed. CheckRedisConfigValues did not set the defaults")
+	}
+
+	cfg.Redis.Address = "localhost"
+	cfg.CheckRedisConfigValues()
+	if cfg.Redis.Enabled {
+		t.Error("Test failed. CheckRedisConfigValues did not disable an invalid address")
+	}
+
+	cfg.Redis = RedisConfig{Enabled: true, Database: -1}
+	cfg.CheckRedisConfigValues()
+	if cfg.Redis.Enabled {
+		t.Error("Test failed. CheckRedisConfigValues did not disable an invalid database")
+	}
+}
+
+func TestCheckArbitrageConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Arbitrage.Enabled = true
+	cfg.Currency.FiatDisplayCurrency = "aud"
+	cfg.Webserver.AdapterTickerMaxAge = time.Second * 30
+
+	cfg.CheckArbitrageConfigValues()
+	if !cfg.Arbitrage.Enabled ||
+		cfg.Arbitrage.Threshold != configDefaultArbitrageThreshold ||
+		cfg.Arbitrage.QuoteCurrency != "AUD" ||
+		cfg.Arbitrage.MaxTickerAge != time.Second*30 ||
+		cfg.Arbitrage.HistorySize != configDefaultArbitrageHistorySize {
+		t.Error("Test failed. CheckArbitrageConfigValues did not set the defaults")
+	}
+
+	cfg.Arbitrage.DefaultTakerFee = 100
+	cfg.CheckArbitrageConfigValues()
+	if cfg.Arbitrage.Enabled {
+		t.Error("Test failed. CheckArbitrageConfigValues did not disable an invalid taker fee")
+	}
+
+	cfg.Arbitrage = ArbitrageConfig{Enabled: true, Threshold: -1}
+	cfg.CheckArbitrageConfigValues()
+	if cfg.Arbitrage.Enabled {
+		t.Error("Test failed. CheckArbitrageConfigValues did not disable an invalid threshold")
+	}
+}
+
+func TestCheckChartsConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Charts = ChartsConfig{Enabled: true, Style: "Line", History: time.Hour * 4}
+	cfg.CheckChartsConfigValues()
+	if !cfg.Charts.Enabled ||
+		cfg.Charts.Style != "line" ||
+		cfg.Charts.Width != 640 ||
+		cfg.Charts.Height != 360 ||
+		cfg.Charts.Period != time.Hour*4 ||
+		cfg.Charts.Candles != configDefaultChartsCandles {
+		t.Error("Test failed. CheckChartsConfigValues did not set the defaults")
+	}
+
+	for _, charts := range []ChartsConfig{
+		{Enabled: true, Style: "pie"},
+		{Enabled: true, Width: 10},
+		{Enabled: true, Height: 5000},
+		{Enabled: true, History: time.Hour, Period: time.Hour * 2},
+		{Enabled: true, Candles: configMaxChartsCandles + 1},
+	} {
+		cfg.Charts = charts
+		cfg.CheckChartsConfigValues()
+		if cfg.Charts.Enabled {
+			t.Errorf("Test failed. CheckChartsConfigValues did not disable an invalid config %+v", charts)
+		}
+	}
+}
+
+func TestCheckDigestConfigValues(t *testing.T) {
+	var cfg Config
+	cfg.Digest = DigestConfig{
+		Enabled: true,
+		Reports: []DigestReportConfig{
+			{
+				Name:       "daily",
+				Schedule:   "0 8 * * *",
+				Location:   "UTC",
+				Sections:   []string{"Market"},
+				Recipients: []DigestRecipientConfig{{Medium: "Slack"}},
+			},
+			{
+				Name:       "hourly",
+				Schedule:   "@hourly",
+				Recipients: []DigestRecipientConfig{{Medium: "Telegram"}},
+			},
+		},
+	}
+
+	cfg.CheckDigestConfigValues()
+	if !cfg.Digest.Enabled ||
+		cfg.Digest.History != configDefaultDigestHistory ||
+		cfg.Digest.StaleAfter != configDefaultDigestStaleAfter ||
+		cfg.Digest.TopMovers != configDefaultDigestTopMovers ||
+		cfg.Digest.Reports[0].Sections[0] != DigestSectionMarket ||
+		len(cfg.Digest.Reports[1].Sections) != 3 {
+		t.Error("Test failed. CheckDigestConfigValues did not set the defaults")
+	}
+
+	for _, report := range []DigestReportConfig{
+		{Name: "schedule", Schedule: "0 25 * * *", Recipients: []DigestRecipientConfig{{Medium: "Slack"}}},
+		{Name: "location", Schedule: "@daily", Location: "Nowhere/Special", Recipients: []DigestRecipientConfig{{Medium: "Slack"}}},
+		{Name: "section", Schedule: "@daily", Sections: []string{"weather"}, Recipients: []DigestRecipientConfig{{Medium: "Slack"}}},
+		{Name: "recipients", Schedule: "@daily"},
+		{Name: "medium", Schedule: "@daily", Recipients: []DigestRecipientConfig{{Recipient: "bob"}}},
+	} {
+		cfg.Digest = DigestConfig{Enabled: true, Reports: []DigestReportConfig{report}}
+		cfg.CheckDigestConfigValues()
+		if cfg.Digest.Enabled {
+			t.Errorf("Test failed. CheckDigestConfigValues did not disable an invalid %s", report.Name)
+		}
+	}
+}
+
+func TestIsValidAPIScopes(t *testing.T) {
+	if !IsValidAPIScopes("read-market,read-portfolio,admin") {
+		t.Error("Test failed. IsValidAPIScopes returned false for valid scopes")
+	}
+
+	if IsValidAPIScopes("") || IsValidAPIScopes("read-market,trade") {
+		t.Error("Test failed. IsValidAPIScopes returned true for invalid scopes")
+	}
+}
+
 func TestCheckWebserverConfigValues(t *testing.T) {
 	checkWebserverConfigValues := GetConfig()
 	err := checkWebserverConfigValues.LoadConfig(ConfigTestFile)
@@ -401,6 +762,52 @@ func TestCheckWebserverConfigValues(t *testing.T) {
 		)
 	}
 
+	checkWebserverConfigValues.Webserver.AdapterTickerMaxAge = 0
+	checkWebserverConfigValues.CheckWebserverConfigValues()
+	if checkWebserverConfigValues.Webserver.AdapterTickerMaxAge != configDefaultAdapterTickerMaxAge {
+		t.Error(
+			"Test failed. checkWebserverConfigValues.CheckWebserverConfigValues error",
+		)
+	}
+
+	checkWebserverConfigValues.Webserver.SessionTokenExpiry = 0
+	checkWebserverConfigValues.Webserver.APIRateLimit = -1
+	checkWebserverConfigValues.Webserver.LoginMaxFailures = 0
+	checkWebserverConfigValues.Webserver.LoginLockoutDuration = 0
+	checkWebserverConfigValues.CheckWebserverConfigValues()
+	if checkWebserverConfigValues.Webserver.SessionTokenExpiry != configDefaultSessionTokenExpiry ||
+		checkWebserverConfigValues.Webserver.APIRateLimit != configDefaultAPIRateLimit ||
+		checkWebserverConfigValues.Webserver.LoginMaxFailures != configDefaultLoginMaxFailures ||
+		checkWebserverConfigValues.Webserver.LoginLockoutDuration != configDefaultLoginLockoutDuration {
+		t.Error(
+			"Test failed. checkWebserverConfigValues.CheckWebserverConfigValues error",
+		)
+	}
+
+	checkWebserverConfigValues.Webserver.APITokens = []APITokenConfig{
+		{Name: "valid", Enabled: true, Token: "abc", Scopes: "read-market,admin"},
+		{Name: "empty", Enabled: true, Scopes: "read-market"},
+		{Name: "scopes", Enabled: true, Token: "abc", Scopes: "read-market,trade"},
+	}
+	checkWebserverConfigValues.CheckWebserverConfigValues()
+	if !checkWebserverConfigValues.Webserver.APITokens[0].Enabled ||
+		checkWebserverConfigValues.Webserver.APITokens[1].Enabled ||
+		checkWebserverConfigValues.Webserver.APITokens[2].Enabled {
+		t.Error(
+			"Test failed. checkWebserverConfigValues.CheckWebserverConfigValues API token error",
+		)
+	}
+	checkWebserverConfigValues.Webserver.APITokens = nil
+
+	checkWebserverConfigValues.Webserver.GRPCEnabled = true
+	checkWebserverConfigValues.Webserver.GRPCListenAddress = "LOLOLOL"
+	checkWebserverConfigValues.CheckWebserverConfigValues()
+	if checkWebserverConfigValues.Webserver.GRPCEnabled {
+		t.Error(
+			"Test failed. checkWebserverConfigValues.CheckWebserverConfigValues error",
+		)
+	}
+
 	checkWebserverConfigValues.Webserver.ListenAddress = ":0"
 	err = checkWebserverConfigValues.CheckWebserverConfigValues()
 	if err == nil {
@@ -434,6 +841,39 @@ func TestCheckWebserverConfigValues(t *testing.T) {
 	}
 }
 
+func TestCheckCurrencyConfigValues(t *testing.T) {
+	var cfg Config
+	err := cfg.CheckCurrencyConfigValues()
+	if err != nil {
+		t.Fatal("Test failed. CheckCurrencyConfigValues error", err)
+	}
+
+	if cfg.Currency.FXRefreshInterval != configDefaultFXRefreshInterval ||
+		cfg.Currency.FXStaleAfter != configDefaultFXStaleAfter ||
+		cfg.Currency.FXDeviationTolerance != configDefaultFXDeviationTolerance {
+		t.Error("Test failed. CheckCurrencyConfigValues did not set the FX refresh defaults")
+	}
+
+	cfg.Currency.FXRefreshInterval = time.Hour * 2
+	cfg.CheckCurrencyConfigValues()
+	if cfg.Currency.FXStaleAfter != time.Hour*4 {
+		t.Error("Test failed. CheckCurrencyConfigValues accepted a stale threshold below the refresh interval")
+	}
+
+	for i := range cfg.Currency.ForexProviders {
+		cfg.Currency.ForexProviders[i].Enabled = false
+		cfg.Currency.ForexProviders[i].PrimaryProvider = false
+		if cfg.Currency.ForexProviders[i].Name == "ECB" {
+			cfg.Currency.ForexProviders[i].Enabled = true
+		}
+	}
+
+	cfg.CheckCurrencyConfigValues()
+	if cfg.GetPrimaryForexProvider() != "ECB" {
+		t.Error("Test failed. CheckCurrencyConfigValues did not default the primary provider to ECB")
+	}
+}
+
 func TestRetrieveConfigCurrencyPairs(t *testing.T) {
 	cfg := GetConfig()
 	err := cfg.LoadConfig(ConfigTestFile)
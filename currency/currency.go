@@ -3,6 +3,8 @@ package currency
 import (
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider"
@@ -27,12 +29,23 @@ var (
 
 	BaseCurrency string
 	FXProviders  *forexprovider.ForexProviders
+
+	// FXConsensus queries every enabled provider and uses the median rate,
+	// flagging provider rates which deviate from it by more than
+	// FXDeviationTolerance percent
+	FXConsensus          bool
+	FXDeviationTolerance float64
+
+	fxMtx sync.RWMutex
 )
 
 // SetDefaults sets the default currency provider and settings for
 // currency conversion used outside of the bot setting
 func SetDefaults() {
+	fxMtx.Lock()
 	FXRates = make(map[string]float64)
+	fxUpdated = make(map[string]time.Time)
+	fxMtx.Unlock()
 	BaseCurrency = DefaultBaseCurrency
 
 	FXProviders = forexprovider.NewDefaultFXProvider()
@@ -43,31 +56,49 @@ func SetDefaults() {
 	}
 }
 
-// SeedCurrencyData returns rates correlated with suported currencies
+// SeedCurrencyData returns rates correlated with suported currencies. The
+// existing rates are kept if the providers fail
 func SeedCurrencyData(currencies string) error {
-	if FXRates == nil {
-		FXRates = make(map[string]float64)
-	}
-
 	if FXProviders == nil {
 		FXProviders = forexprovider.NewDefaultFXProvider()
 	}
 
+	if FXConsensus {
+		return seedConsensusData(currencies)
+	}
+
 	newRates, err := FXProviders.GetCurrencyData(BaseCurrency, currencies)
 	if err != nil {
+		recordFXRefresh(err)
 		return err
 	}
 
-	for key, value := range newRates {
-		FXRates[key] = value
-	}
-
+	SetExchangeRates(newRates, time.Now())
+	recordFXRefresh(nil)
 	return nil
 }
 
-// GetExchangeRates returns the currency exchange rates
+// GetExchangeRates returns a copy of the currency exchange rates
 func GetExchangeRates() map[string]float64 {
-	return FXRates
+	fxMtx.RLock()
+	defer fxMtx.RUnlock()
+
+	if FXRates == nil {
+		return nil
+	}
+
+	rates := make(map[string]float64, len(FXRates))
+	for key, value := range FXRates {
+		rates[key] = value
+	}
+	return rates
+}
+
+// HasExchangeRates returns whether any currency exchange rates are held
+func HasExchangeRates() bool {
+	fxMtx.RLock()
+	defer fxMtx.RUnlock()
+	return len(FXRates) > 0
 }
 
 // IsDefaultCurrency checks if the currency passed in matches the default fiat
@@ -156,10 +187,13 @@ func ConvertCurrency(amount float64, from, to string) (float64, error) {
 		to = "RUB"
 	}
 
-	if len(FXRates) == 0 {
+	if !HasExchangeRates() {
 		SeedCurrencyData(from + "," + to)
 	}
 
+	fxMtx.RLock()
+	defer fxMtx.RUnlock()
+
 	// Need to extract the base currency to see if we actually got it from the Forex API
 	// Fixer free API sets the base currency to EUR
 	baseCurr := extractBaseCurrency()
@@ -26,7 +26,7 @@ func (fxp IFXProviders) GetCurrencyData(baseCurrency, symbols string) (map[strin
 			if err != nil {
 				log.Println(err)
 				for y := range fxp {
-					if !fxp[y].IsPrimaryProvider() && fxp[x].IsEnabled() {
+					if !fxp[y].IsPrimaryProvider() && fxp[y].IsEnabled() {
 						rates, err = fxp[y].GetRates(baseCurrency, symbols)
 						if err != nil {
 							log.Println(err)
@@ -1 +1,80 @@
 package base
+
+import (
+	"errors"
+	"testing"
+)
+
+type testProvider struct {
+	Base
+	rates map[string]float64
+	err   error
+}
+
+func (p *testProvider) Setup(config Settings) {}
+
+func (p *testProvider) GetRates(baseCurrency, symbols string) (map[string]float64, error) {
+	return p.rates, p.err
+}
+
+func newTestProvider(name string, enabled, primary bool, rates map[string]float64, err error) *testProvider {
+	p := &testProvider{rates: rates, err: err}
+	p.Name, p.Enabled, p.PrimaryProvider = name, enabled, primary
+	return p
+}
+
+func TestGetCurrencyData(t *testing.T) {
+	fxp := IFXProviders{
+		newTestProvider("Primary", true, true, nil, errors.New("rate limited")),
+		newTestProvider("Disabled", false, false, map[string]float64{"USDAUD": 2}, nil),
+		newTestProvider("Fallback", true, false, map[string]float64{"USDAUD": 1.35}, nil),
+	}
+
+	rates, err := fxp.GetCurrencyData("USD", "AUD")
+	if err != nil || rates["USDAUD"] != 1.35 {
+		t.Errorf("test failed - GetCurrencyData() used a disabled fallback %v %v", rates, err)
+	}
+}
+
+func TestGetConsensusData(t *testing.T) {
+	fxp := IFXProviders{
+		newTestProvider("CurrencyConverter", true, true, map[string]float64{"USDAUD": 1.5, "USDEUR": 0.75}, nil),
+		newTestProvider("CurrencyLayer", true, false, map[string]float64{"USDAUD": 1.5, "USDEUR": 0.875}, nil),
+		newTestProvider("Fixer", true, false, map[string]float64{"EURAUD": 1.875, "EURUSD": 1.25}, nil),
+		newTestProvider("OpenExchangeRates", true, false, nil, errors.New("invalid key")),
+		newTestProvider("Disabled", false, false, map[string]float64{"USDAUD": 5}, nil),
+	}
+
+	consensus, err := fxp.GetConsensusData("USD", "AUD,EUR", 10)
+	if err != nil {
+		t.Fatal("test failed - GetConsensusData() error", err)
+	}
+
+	if len(consensus.Providers) != 4 || consensus.Providers[3].Error != "invalid key" {
+		t.Errorf("test failed - GetConsensusData() provider rates %+v", consensus.Providers)
+	}
+
+	if consensus.Providers[2].Rates["USDAUD"] != 1.5 || consensus.Providers[2].Rates["USDEUR"] != 0.8 ||
+		len(consensus.Providers[2].Rates) != 2 {
+		t.Errorf("test failed - GetConsensusData() did not rebase rates %v", consensus.Providers[2].Rates)
+	}
+
+	if consensus.Rates["USDAUD"] != 1.5 || consensus.Rates["USDEUR"] != 0.8 {
+		t.Errorf("test failed - GetConsensusData() median rates %v", consensus.Rates)
+	}
+
+	if len(consensus.Deviations) != 0 {
+		t.Errorf("test failed - GetConsensusData() unexpected deviations %v", consensus.Deviations)
+	}
+
+	consensus, _ = fxp.GetConsensusData("USD", "AUD,EUR", 5)
+	if len(consensus.Deviations) != 2 || consensus.Deviations[0].Provider != "CurrencyConverter" ||
+		consensus.Deviations[1].Provider != "CurrencyLayer" || consensus.Deviations[0].Currency != "USDEUR" {
+		t.Errorf("test failed - GetConsensusData() deviations %+v", consensus.Deviations)
+	}
+
+	fxp = IFXProviders{newTestProvider("Failing", true, true, nil, errors.New("timeout"))}
+	if _, err = fxp.GetConsensusData("USD", "AUD", 1); err == nil {
+		t.Error("test failed - GetConsensusData() expected an error")
+	}
+}
@@ -0,0 +1,161 @@
+package base
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProviderRates holds the rates returned by a provider, or the error if the
+// provider failed
+type ProviderRates struct {
+	Name  string             `json:"name"`
+	Rates map[string]float64 `json:"rates,omitempty"`
+	Error string             `json:"error,omitempty"`
+}
+
+// Deviation is a provider rate which differs from the median rate by more
+// than the tolerance. Deviation is a percentage of the median
+type Deviation struct {
+	Provider  string  `json:"provider"`
+	Currency  string  `json:"currency"`
+	Rate      float64 `json:"rate"`
+	Median    float64 `json:"median"`
+	Deviation float64 `json:"deviation"`
+}
+
+// Consensus holds the median rate of each currency across the providers
+// along with the rates of each provider for auditing
+type Consensus struct {
+	Rates      map[string]float64
+	Providers  []ProviderRates
+	Deviations []Deviation
+}
+
+// GetConsensusData queries every enabled provider concurrently and returns
+// the median of each rate. Provider rates which differ from the median by
+// more than tolerance percent are flagged as deviations
+func (fxp IFXProviders) GetConsensusData(baseCurrency, symbols string, tolerance float64) (Consensus, error) {
+	var enabled IFXProviders
+	for x := range fxp {
+		if fxp[x].IsEnabled() {
+			enabled = append(enabled, fxp[x])
+		}
+	}
+
+	if len(enabled) == 0 {
+		return Consensus{}, errors.New("ForexProvider error GetConsensusData() no providers enabled")
+	}
+
+	consensus := Consensus{
+		Rates:     make(map[string]float64),
+		Providers: make([]ProviderRates, len(enabled)),
+	}
+
+	var wg sync.WaitGroup
+	for x := range enabled {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			result := ProviderRates{Name: enabled[x].GetName()}
+			rates, err := enabled[x].GetRates(baseCurrency, symbols)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Rates = rebaseRates(rates, baseCurrency)
+			}
+			consensus.Providers[x] = result
+		}(x)
+	}
+	wg.Wait()
+
+	samples := make(map[string][]float64)
+	for _, p := range consensus.Providers {
+		for key, value := range p.Rates {
+			samples[key] = append(samples[key], value)
+		}
+	}
+
+	if len(samples) == 0 {
+		return consensus, errors.New("ForexProvider error GetConsensusData() failed to aquire data")
+	}
+
+	for key, values := range samples {
+		consensus.Rates[key] = median(values)
+	}
+
+	for _, p := range consensus.Providers {
+		for key, value := range p.Rates {
+			m := consensus.Rates[key]
+			if m == 0 {
+				continue
+			}
+
+			deviation := math.Abs(value-m) / m * 100
+			if deviation > tolerance {
+				consensus.Deviations = append(consensus.Deviations, Deviation{
+					Provider:  p.Name,
+					Currency:  key,
+					Rate:      value,
+					Median:    m,
+					Deviation: deviation,
+				})
+			}
+		}
+	}
+
+	sort.Slice(consensus.Deviations, func(i, j int) bool {
+		if consensus.Deviations[i].Currency != consensus.Deviations[j].Currency {
+			return consensus.Deviations[i].Currency < consensus.Deviations[j].Currency
+		}
+		return consensus.Deviations[i].Provider < consensus.Deviations[j].Provider
+	})
+	return consensus, nil
+}
+
+// rebaseRates converts rates quoted against another base currency, such as
+// the EUR rates of the free Fixer API, to the requested base currency. Rates
+// which cannot be converted are dropped so they are not compared against
+// rates of a different base
+func rebaseRates(rates map[string]float64, baseCurrency string) map[string]float64 {
+	baseCurrency = strings.ToUpper(baseCurrency)
+	rebased := make(map[string]float64, len(rates))
+	for key, value := range rates {
+		if len(key) != 6 {
+			continue
+		}
+
+		if key[:3] == baseCurrency {
+			rebased[key] = value
+			continue
+		}
+
+		baseRate, ok := rates[key[:3]+baseCurrency]
+		if !ok || baseRate == 0 {
+			continue
+		}
+
+		// The rate of the provider base against the requested base, such as
+		// EURUSD with USD requested, is inverted
+		if key[3:] == baseCurrency {
+			rebased[baseCurrency+key[:3]] = 1 / baseRate
+			continue
+		}
+		rebased[baseCurrency+key[3:]] = value / baseRate
+	}
+	return rebased
+}
+
+// median returns the median of the values
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
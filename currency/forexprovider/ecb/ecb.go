@@ -0,0 +1,175 @@
+// Package ecb is a forex provider using the European Central Bank euro
+// foreign exchange reference rates, which are free and need no API key.
+// The reference rates are quoted against EUR and published on working days,
+// rates against any other base currency are computed as cross rates
+package ecb
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/base"
+)
+
+// const declarations consist of endpoints
+const (
+	APIEndpointDaily   = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+	APIEndpointHistory = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-hist-90d.xml"
+
+	referenceCurrency = "EUR"
+	dateLayout        = "2006-01-02"
+)
+
+var (
+	dailyURL   = APIEndpointDaily
+	historyURL = APIEndpointHistory
+
+	errNoRates = errors.New("ECB reference rates document contains no rates")
+)
+
+// ECB stores the struct for the European Central Bank reference rates
+type ECB struct {
+	base.Base
+}
+
+// Setup sets appropriate values for ECB
+func (e *ECB) Setup(config base.Settings) {
+	e.Name = config.Name
+	e.Enabled = config.Enabled
+	e.RESTPollingDelay = config.RESTPollingDelay
+	e.Verbose = config.Verbose
+	e.PrimaryProvider = config.PrimaryProvider
+}
+
+// GetRates is a wrapper function to return the latest rates of the symbols
+// against the base currency
+func (e *ECB) GetRates(baseCurrency, symbols string) (map[string]float64, error) {
+	rates, err := e.GetDailyRates()
+	if err != nil {
+		return nil, err
+	}
+	return CrossRates(rates, baseCurrency, symbols)
+}
+
+// GetHistoricalRates returns the rates of the symbols against the base
+// currency published on or most recently before the date. Only the last 90
+// days are available
+func (e *ECB) GetHistoricalRates(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	history, err := e.GetHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	for x := range history {
+		if !history[x].Date.After(date) {
+			return CrossRates(history[x], baseCurrency, symbols)
+		}
+	}
+	return nil, fmt.Errorf("ECB reference rates unavailable for %s", date.Format(dateLayout))
+}
+
+// GetDailyRates returns the latest EUR reference rates
+func (e *ECB) GetDailyRates() (ReferenceRates, error) {
+	days, err := e.fetch(dailyURL)
+	if err != nil {
+		return ReferenceRates{}, err
+	}
+	return days[0], nil
+}
+
+// GetHistory returns the EUR reference rates of the last 90 days, newest
+// first
+func (e *ECB) GetHistory() ([]ReferenceRates, error) {
+	return e.fetch(historyURL)
+}
+
+// fetch requests and parses a reference rates document
+func (e *ECB) fetch(path string) ([]ReferenceRates, error) {
+	if e.Verbose {
+		log.Println("Raw URL: ", path)
+	}
+
+	resp, err := common.SendHTTPRequest("GET", path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReferenceRates([]byte(resp))
+}
+
+// ParseReferenceRates parses a reference rates document, returning the rates
+// of each day newest first
+func ParseReferenceRates(data []byte) ([]ReferenceRates, error) {
+	var envelope Envelope
+	err := xml.Unmarshal(data, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []ReferenceRates
+	for _, d := range envelope.Cube.Days {
+		date, err := time.Parse(dateLayout, d.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		rates := ReferenceRates{
+			Date:  date,
+			Rates: map[string]float64{referenceCurrency: 1},
+		}
+		for _, r := range d.Rates {
+			if r.Rate <= 0 {
+				continue
+			}
+			rates.Rates[strings.ToUpper(r.Currency)] = r.Rate
+		}
+		days = append(days, rates)
+	}
+
+	if len(days) == 0 {
+		return nil, errNoRates
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Date.After(days[j].Date) })
+	return days, nil
+}
+
+// CrossRates computes the rates of the symbols against the base currency
+// from the EUR reference rates, keyed by the base currency and symbol, such
+// as USDAUD. Symbols without a reference rate are skipped and all reference
+// currencies are returned if no symbols are supplied
+func CrossRates(rates ReferenceRates, baseCurrency, symbols string) (map[string]float64, error) {
+	baseCurrency = strings.ToUpper(baseCurrency)
+	baseRate, ok := rates.Rates[baseCurrency]
+	if !ok {
+		return nil, fmt.Errorf("ECB reference rates do not include base currency %s", baseCurrency)
+	}
+
+	var currencies []string
+	if symbols == "" {
+		for c := range rates.Rates {
+			currencies = append(currencies, c)
+		}
+	} else {
+		currencies = common.SplitStrings(strings.ToUpper(symbols), ",")
+	}
+
+	result := make(map[string]float64)
+	for _, c := range currencies {
+		rate, ok := rates.Rates[c]
+		if !ok {
+			continue
+		}
+		result[baseCurrency+c] = rate / baseRate
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("ECB reference rates do not include %s", symbols)
+	}
+	return result, nil
+}
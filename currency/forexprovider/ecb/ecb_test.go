@@ -0,0 +1,92 @@
+package ecb
+
+import (
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestServer() *httptest.Server {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	dailyURL = server.URL + "/eurofxref-daily.xml"
+	historyURL = server.URL + "/eurofxref-hist-90d.xml"
+	return server
+}
+
+func TestParseReferenceRates(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/eurofxref-hist-90d.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	days, err := ParseReferenceRates(data)
+	if err != nil {
+		t.Fatal("test failed - ParseReferenceRates() error", err)
+	}
+
+	if len(days) != 3 || days[0].Date != time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC) ||
+		days[2].Rates["USD"] != 1.1573 || days[0].Rates["EUR"] != 1 {
+		t.Errorf("test failed - ParseReferenceRates() unexpected result %v", days)
+	}
+
+	if _, err = ParseReferenceRates([]byte("<html></html>")); err != errNoRates {
+		t.Error("test failed - ParseReferenceRates() expected errNoRates got", err)
+	}
+
+	if _, err = ParseReferenceRates([]byte("{}")); err == nil {
+		t.Error("test failed - ParseReferenceRates() accepted an invalid document")
+	}
+}
+
+func TestGetRates(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	var e ECB
+	rates, err := e.GetRates("USD", "AUD,EUR,JPY,XYZ")
+	if err != nil {
+		t.Fatal("test failed - GetRates() error", err)
+	}
+
+	if len(rates) != 3 || math.Abs(rates["USDAUD"]-1.5411/1.1675) > 1e-9 ||
+		math.Abs(rates["USDEUR"]-1/1.1675) > 1e-9 {
+		t.Errorf("test failed - GetRates() unexpected cross rates %v", rates)
+	}
+
+	rates, err = e.GetRates("EUR", "")
+	if err != nil || len(rates) != 8 || rates["EURGBP"] != 0.87528 {
+		t.Errorf("test failed - GetRates() unexpected EUR rates %v %v", rates, err)
+	}
+
+	if _, err = e.GetRates("XYZ", "AUD"); err == nil {
+		t.Error("test failed - GetRates() accepted an unknown base currency")
+	}
+
+	if _, err = e.GetRates("USD", "XYZ"); err == nil {
+		t.Error("test failed - GetRates() accepted only unknown symbols")
+	}
+}
+
+func TestGetHistoricalRates(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	var e ECB
+	// 2018-06-02 is a Saturday, the rates of the Friday apply
+	rates, err := e.GetHistoricalRates(time.Date(2018, 6, 2, 12, 0, 0, 0, time.UTC), "EUR", "USD")
+	if err != nil || rates["EURUSD"] != 1.1675 {
+		t.Errorf("test failed - GetHistoricalRates() unexpected result %v %v", rates, err)
+	}
+
+	rates, err = e.GetHistoricalRates(time.Date(2018, 5, 31, 0, 0, 0, 0, time.UTC), "JPY", "USD")
+	if err != nil || math.Abs(rates["JPYUSD"]-1.1699/127.4) > 1e-9 {
+		t.Errorf("test failed - GetHistoricalRates() unexpected result %v %v", rates, err)
+	}
+
+	if _, err = e.GetHistoricalRates(time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC), "EUR", "USD"); err == nil {
+		t.Error("test failed - GetHistoricalRates() returned rates outside the history")
+	}
+}
@@ -0,0 +1,31 @@
+package ecb
+
+import "time"
+
+// Envelope stores the reference rate XML document
+type Envelope struct {
+	Subject string `xml:"subject"`
+	Sender  string `xml:"Sender>name"`
+	Cube    struct {
+		Days []Day `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// Day stores the reference rates published on a day
+type Day struct {
+	Time  string `xml:"time,attr"`
+	Rates []Rate `xml:"Cube"`
+}
+
+// Rate stores the EUR reference rate of a currency
+type Rate struct {
+	Currency string  `xml:"currency,attr"`
+	Rate     float64 `xml:"rate,attr"`
+}
+
+// ReferenceRates stores the EUR reference rates of a day, keyed by currency
+// code
+type ReferenceRates struct {
+	Date  time.Time
+	Rates map[string]float64
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/base"
 	currencyconverter "github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/currencyconverterapi"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/currencylayer"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/ecb"
 	fixer "github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/fixer.io"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/openexchangerates"
 )
@@ -19,7 +20,7 @@ type ForexProviders struct {
 
 // GetAvailableForexProviders returns a list of supported forex providers
 func GetAvailableForexProviders() []string {
-	return []string{"CurrencyConverter", "CurrencyLayer", "Fixer", "OpenExchangeRates"}
+	return []string{"CurrencyConverter", "CurrencyLayer", "Fixer", "OpenExchangeRates", "ECB"}
 }
 
 // NewDefaultFXProvider returns the default forex provider (currencyconverterAPI)
@@ -59,6 +60,11 @@ func StartFXService(fxProviders []base.Settings) *ForexProviders {
 			OpenExchangeRatesP.Setup(fxProviders[i])
 			fxp.IFXProviders = append(fxp.IFXProviders, OpenExchangeRatesP)
 		}
+		if fxProviders[i].Name == "ECB" && fxProviders[i].Enabled {
+			ecbP := new(ecb.ECB)
+			ecbP.Setup(fxProviders[i])
+			fxp.IFXProviders = append(fxp.IFXProviders, ecbP)
+		}
 	}
 	if len(fxp.IFXProviders) == 0 {
 		log.Fatal("No foreign exchange providers enabled")
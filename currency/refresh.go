@@ -0,0 +1,147 @@
+package currency
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/base"
+)
+
+var (
+	fxUpdated     map[string]time.Time
+	fxLastAttempt time.Time
+	fxLastSuccess time.Time
+	fxLastError   error
+
+	fxProviderRates []base.ProviderRates
+	fxDeviations    []base.Deviation
+)
+
+// FXStatus is the state of the exchange rates. Updated holds the time each
+// rate was last fetched, StaleRates lists the rates older than the stale
+// threshold. Providers and Deviations hold the rates of each provider from
+// the last consensus refresh
+type FXStatus struct {
+	Base        string               `json:"base"`
+	Rates       map[string]float64   `json:"rates"`
+	Updated     map[string]time.Time `json:"updated"`
+	LastAttempt time.Time            `json:"lastAttempt"`
+	LastSuccess time.Time            `json:"lastSuccess"`
+	LastError   string               `json:"lastError,omitempty"`
+	Stale       bool                 `json:"stale"`
+	StaleRates  []string             `json:"staleRates"`
+	Providers   []base.ProviderRates `json:"providers,omitempty"`
+	Deviations  []base.Deviation     `json:"deviations,omitempty"`
+}
+
+// SetExchangeRates merges rates into the exchange rates, recording when they
+// were fetched. Rates restored without a fetch time are passed a zero time
+// and reported as stale until refreshed
+func SetExchangeRates(rates map[string]float64, updated time.Time) {
+	fxMtx.Lock()
+	defer fxMtx.Unlock()
+
+	if FXRates == nil {
+		FXRates = make(map[string]float64)
+	}
+
+	if fxUpdated == nil {
+		fxUpdated = make(map[string]time.Time)
+	}
+
+	for key, value := range rates {
+		FXRates[key] = value
+		fxUpdated[key] = updated
+	}
+}
+
+// recordFXRefresh records the outcome of fetching rates from the providers
+func recordFXRefresh(err error) {
+	fxMtx.Lock()
+	defer fxMtx.Unlock()
+
+	fxLastAttempt = time.Now()
+	fxLastError = err
+	if err == nil {
+		fxLastSuccess = fxLastAttempt
+	}
+}
+
+// seedConsensusData seeds the median rates of every enabled provider,
+// keeping the provider rates and deviations for auditing
+func seedConsensusData(currencies string) error {
+	consensus, err := FXProviders.GetConsensusData(BaseCurrency, currencies, FXDeviationTolerance)
+
+	fxMtx.Lock()
+	fxProviderRates, fxDeviations = consensus.Providers, consensus.Deviations
+	fxMtx.Unlock()
+
+	if err != nil {
+		recordFXRefresh(err)
+		return err
+	}
+
+	for _, d := range consensus.Deviations {
+		log.Printf("Currency: %s %s rate %f deviates %.2f%% from the median %f.",
+			d.Provider, d.Currency, d.Rate, d.Deviation, d.Median)
+	}
+
+	SetExchangeRates(consensus.Rates, time.Now())
+	recordFXRefresh(nil)
+	return nil
+}
+
+// RefreshRates re-fetches every exchange rate held along with the enabled
+// fiat currencies. The last good rates are served if the providers fail
+func RefreshRates() error {
+	fxMtx.RLock()
+	currencies := append([]string{}, FiatCurrencies...)
+	for key := range FXRates {
+		if len(key) > 3 && !common.StringDataCompare(currencies, key[3:]) {
+			currencies = append(currencies, key[3:])
+		}
+	}
+	fxMtx.RUnlock()
+
+	if len(currencies) == 0 {
+		currencies = common.SplitStrings(DefaultCurrencies, ",")
+	}
+	return SeedCurrencyData(common.JoinStrings(currencies, ","))
+}
+
+// GetFXStatus returns the exchange rates with their fetch times, reporting
+// the rates not fetched within staleAfter as stale
+func GetFXStatus(staleAfter time.Duration, now time.Time) FXStatus {
+	fxMtx.RLock()
+	defer fxMtx.RUnlock()
+
+	status := FXStatus{
+		Base:        BaseCurrency,
+		Rates:       make(map[string]float64, len(FXRates)),
+		Updated:     make(map[string]time.Time, len(FXRates)),
+		LastAttempt: fxLastAttempt,
+		LastSuccess: fxLastSuccess,
+		StaleRates:  []string{},
+		Providers:   fxProviderRates,
+		Deviations:  fxDeviations,
+	}
+
+	if fxLastError != nil {
+		status.LastError = fxLastError.Error()
+	}
+
+	for key, value := range FXRates {
+		status.Rates[key] = value
+		updated := fxUpdated[key]
+		status.Updated[key] = updated
+		if now.Sub(updated) > staleAfter {
+			status.StaleRates = append(status.StaleRates, key)
+		}
+	}
+
+	sort.Strings(status.StaleRates)
+	status.Stale = len(FXRates) == 0 || len(status.StaleRates) > 0
+	return status
+}
@@ -0,0 +1,108 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider/base"
+)
+
+type testFXProvider struct {
+	base.Base
+	rates   map[string]float64
+	err     error
+	symbols string
+}
+
+func (p *testFXProvider) Setup(config base.Settings) {}
+
+func (p *testFXProvider) GetRates(baseCurrency, symbols string) (map[string]float64, error) {
+	p.symbols = symbols
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.rates, nil
+}
+
+func TestRefreshRates(t *testing.T) {
+	backupRates, backupProviders, backupFiat := FXRates, FXProviders, FiatCurrencies
+	defer func() {
+		FXRates, FXProviders, FiatCurrencies = backupRates, backupProviders, backupFiat
+	}()
+
+	provider := &testFXProvider{rates: map[string]float64{"USDAUD": 1.35, "USDEUR": 0.85}}
+	provider.Enabled, provider.PrimaryProvider = true, true
+	FXProviders = &forexprovider.ForexProviders{IFXProviders: base.IFXProviders{provider}}
+	FXRates, FiatCurrencies = nil, []string{"AUD"}
+
+	restored := time.Now().Add(-time.Hour)
+	SetExchangeRates(map[string]float64{"USDJPY": 110}, time.Time{})
+	if status := GetFXStatus(time.Minute, restored); !status.Stale || len(status.StaleRates) != 1 {
+		t.Error("test failed - GetFXStatus() restored rates not stale")
+	}
+
+	if err := RefreshRates(); err != nil {
+		t.Fatal("test failed - RefreshRates() error", err)
+	}
+
+	if provider.symbols != "AUD,JPY" {
+		t.Errorf("test failed - RefreshRates() requested %s", provider.symbols)
+	}
+
+	now := time.Now()
+	status := GetFXStatus(time.Minute, now)
+	if status.Rates["USDAUD"] != 1.35 || status.LastError != "" || status.LastSuccess.IsZero() ||
+		len(status.StaleRates) != 1 || status.StaleRates[0] != "USDJPY" {
+		t.Errorf("test failed - GetFXStatus() unexpected status %+v", status)
+	}
+
+	provider.rates["USDAUD"] = 1.5
+	if GetExchangeRates()["USDAUD"] != 1.35 {
+		t.Error("test failed - GetExchangeRates() did not return a copy")
+	}
+
+	provider.err = errors.New("rate limited")
+	if err := RefreshRates(); err == nil {
+		t.Error("test failed - RefreshRates() expected an error")
+	}
+
+	status = GetFXStatus(time.Minute, now.Add(time.Minute*2))
+	if status.Rates["USDAUD"] != 1.35 || status.LastError == "" || !status.Stale ||
+		len(status.StaleRates) != 3 {
+		t.Errorf("test failed - GetFXStatus() last good rates not served %+v", status)
+	}
+
+	if amount, err := ConvertCurrency(1, "USD", "AUD"); err != nil || amount != 1.35 {
+		t.Errorf("test failed - ConvertCurrency() returned %f %v", amount, err)
+	}
+}
+
+func TestRefreshRatesConsensus(t *testing.T) {
+	backupRates, backupProviders, backupFiat, backupBase := FXRates, FXProviders, FiatCurrencies, BaseCurrency
+	defer func() {
+		FXRates, FXProviders, FiatCurrencies, BaseCurrency = backupRates, backupProviders, backupFiat, backupBase
+		FXConsensus, FXDeviationTolerance = false, 0
+	}()
+
+	var providers base.IFXProviders
+	for _, rate := range []float64{1.25, 1.5, 2} {
+		provider := &testFXProvider{rates: map[string]float64{"USDAUD": rate}}
+		provider.Name, provider.Enabled = "Test", true
+		providers = append(providers, provider)
+	}
+	FXProviders = &forexprovider.ForexProviders{IFXProviders: providers}
+	FXRates, FiatCurrencies, BaseCurrency = nil, []string{"AUD"}, "USD"
+	FXConsensus, FXDeviationTolerance = true, 20
+
+	if err := RefreshRates(); err != nil {
+		t.Fatal("test failed - RefreshRates() error", err)
+	}
+
+	status := GetFXStatus(time.Minute, time.Now())
+	if status.Rates["USDAUD"] != 1.5 || len(status.Providers) != 3 ||
+		len(status.Deviations) != 1 || status.Deviations[0].Rate != 2 {
+		t.Errorf("test failed - RefreshRates() consensus status %+v", status)
+	}
+}
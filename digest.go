@@ -0,0 +1,255 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/digest"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/schedule"
+)
+
+// Const vars for the digest reports
+const (
+	marketHistoryFeedBufferSize = 4096
+	digestEventType             = "DIGEST"
+)
+
+var (
+	// marketHistory records the feed for the digest reports and price charts
+	marketHistory *digest.Collector
+
+	errDigestConversion = errors.New("digest prices are only converted between fiat currencies")
+)
+
+// digestState holds the previous run of a report, the market summary covers
+// the period since the previous run and the portfolio change is calculated
+// from its value
+type digestState struct {
+	lastRun        time.Time
+	portfolioValue *float64
+}
+
+var (
+	digestStates   = make(map[string]*digestState)
+	digestStateMtx sync.Mutex
+)
+
+// digestTemplates holds the parsed report template and recipient templates
+type digestTemplates struct {
+	report     *template.Template
+	recipients []*template.Template
+}
+
+// StartDigests starts collecting the feed and sends each report on its
+// schedule
+func StartDigests() {
+	if !bot.Config.Digest.Enabled {
+		return
+	}
+
+	startMarketHistory()
+
+	for i := range bot.Config.Digest.Reports {
+		report := bot.Config.Digest.Reports[i]
+		templates, err := parseDigestTemplates(report)
+		if err != nil {
+			log.Printf("Digest: report %s disabled, invalid template. Err: %s", report.Name, err)
+			continue
+		}
+
+		s, err := schedule.Parse(report.Schedule)
+		if err != nil {
+			log.Printf("Digest: report %s disabled, invalid schedule. Err: %s", report.Name, err)
+			continue
+		}
+
+		loc, err := time.LoadLocation(report.Location)
+		if err != nil {
+			log.Printf("Digest: report %s disabled, invalid location. Err: %s", report.Name, err)
+			continue
+		}
+
+		go digestScheduler(report, s, loc, templates)
+		log.Printf("Digest: report %s scheduled %q, next at %s.", report.Name,
+			report.Schedule, s.Next(time.Now().In(loc)))
+	}
+}
+
+// parseDigestTemplates parses the templates of a report and its recipients,
+// recipients without a template use the report template
+func parseDigestTemplates(report config.DigestReportConfig) (digestTemplates, error) {
+	var templates digestTemplates
+	var err error
+	templates.report, err = digest.ParseTemplate(report.Name, report.Template)
+	if err != nil {
+		return templates, err
+	}
+
+	for _, recipient := range report.Recipients {
+		tmpl := templates.report
+		if recipient.Template != "" {
+			tmpl, err = digest.ParseTemplate(report.Name+recipient.Medium, recipient.Template)
+			if err != nil {
+				return templates, err
+			}
+		}
+		templates.recipients = append(templates.recipients, tmpl)
+	}
+	return templates, nil
+}
+
+// startMarketHistory starts recording the feed, keeping the longest history
+// required by the digest reports and price charts
+func startMarketHistory() {
+	if marketHistory != nil {
+		return
+	}
+
+	var history time.Duration
+	if bot.Config.Digest.Enabled {
+		history = bot.Config.Digest.History
+	}
+
+	if bot.Config.Charts.Enabled && bot.Config.Charts.History > history {
+		history = bot.Config.Charts.History
+	}
+
+	marketHistory = digest.NewCollector(history)
+	go marketHistoryDispatcher(SubscribeFeed(marketHistoryFeedBufferSize))
+}
+
+// marketHistoryDispatcher records the ticker updates of the feed
+func marketHistoryDispatcher(feed chan WebsocketEvent) {
+	for evt := range feed {
+		t, ok := evt.Data.(ticker.Price)
+		if !ok {
+			continue
+		}
+		marketHistory.RecordTicker(evt.Exchange, evt.AssetType, t, time.Now())
+	}
+}
+
+// digestScheduler sends a report each time its schedule occurs
+func digestScheduler(report config.DigestReportConfig, s *schedule.Schedule, loc *time.Location, templates digestTemplates) {
+	for {
+		next := s.Next(time.Now().In(loc))
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		<-timer.C
+		sendDigest(report, templates, time.Now().In(loc))
+	}
+}
+
+// sendDigest builds a report and pushes it to each recipient
+func sendDigest(report config.DigestReportConfig, templates digestTemplates, now time.Time) {
+	r := buildDigestReport(report, now)
+
+	for i, recipient := range report.Recipients {
+		text, err := digest.Render(templates.recipients[i], r)
+		if err != nil {
+			log.Printf("Digest: failed to render report %s for %s. Err: %s",
+				report.Name, recipient.Medium, err)
+			continue
+		}
+
+		if bot.Comms == nil {
+			log.Println(text)
+			continue
+		}
+
+		event := base.Event{
+			Type:         digestEventType,
+			TradeDetails: text,
+			Severity:     base.SeverityInfo,
+		}
+
+		if recipient.Recipient == "" {
+			err = bot.Comms.PushEventToMedium(recipient.Medium, event)
+		} else {
+			err = bot.Comms.PushEventTo(recipient.Medium, recipient.Recipient, event)
+		}
+
+		if err != nil {
+			log.Printf("Digest: failed to send report %s to %s. Err: %s",
+				report.Name, recipient.Medium, err)
+		}
+	}
+}
+
+// buildDigestReport collects the enabled sections of a report, covering the
+// period since the previous run or the digest history for the first run
+func buildDigestReport(report config.DigestReportConfig, now time.Time) *digest.Report {
+	digestStateMtx.Lock()
+	defer digestStateMtx.Unlock()
+
+	state, ok := digestStates[report.Name]
+	if !ok {
+		state = &digestState{lastRun: now.Add(-bot.Config.Digest.History)}
+		digestStates[report.Name] = state
+	}
+
+	r := &digest.Report{
+		Name:      report.Name,
+		Generated: now,
+		Since:     state.lastRun.In(now.Location()),
+	}
+
+	for _, section := range report.Sections {
+		switch section {
+		case config.DigestSectionMarket:
+			all := marketHistory.Markets(r.Since, now, nil)
+			r.Market = &digest.MarketSection{
+				Pairs:  marketHistory.Markets(r.Since, now, report.Pairs),
+				Movers: digest.TopMovers(all, bot.Config.Digest.TopMovers),
+			}
+		case config.DigestSectionPortfolio:
+			r.Portfolio = digestPortfolio(state)
+		case config.DigestSectionHealth:
+			health := marketHistory.Health(now, bot.Config.Digest.StaleAfter)
+			r.Health = &health
+		}
+	}
+
+	state.lastRun = now
+	return r
+}
+
+// digestPortfolio values the portfolio in the fiat display currency and
+// records the value for the next report
+func digestPortfolio(state *digestState) *digest.PortfolioSection {
+	balances := make(map[string]float64)
+	if bot.Portfolio != nil {
+		for _, coin := range bot.Portfolio.GetPortfolioSummary().Totals {
+			balances[common.StringToUpper(coin.Coin)] += coin.Balance
+		}
+	}
+
+	target := bot.Config.Currency.FiatDisplayCurrency
+	section := digest.NewPortfolioSection(target, balances, func(coin string) (float64, bool) {
+		return marketHistory.Price(coin, target, convertDigestPrice)
+	}, state.portfolioValue)
+
+	value := section.Value
+	state.portfolioValue = &value
+	return section
+}
+
+// convertDigestPrice converts prices quoted in fiat currencies, crypto
+// quoted prices are not converted
+func convertDigestPrice(amount float64, from, to string) (float64, error) {
+	if !currency.IsFiatCurrency(from) || !currency.IsFiatCurrency(to) {
+		return 0, errDigestConversion
+	}
+	return currency.ConvertCurrency(amount, from, to)
+}
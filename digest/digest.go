@@ -0,0 +1,392 @@
+// Package digest collects the market data and feeder health summarised by
+// the scheduled digest reports and renders the reports from Go templates.
+// The market history is also used to draw price charts
+package digest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/charts"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// bucketSize is the resolution of the market history
+const bucketSize = time.Minute
+
+// marketKey identifies an exchange currency pair
+type marketKey struct {
+	Exchange  string
+	AssetType string
+	Base      string
+	Quote     string
+}
+
+// bucket holds the prices of a market during a bucketSize period
+type bucket struct {
+	start                       time.Time
+	open, high, low, close, vol float64
+}
+
+// market holds the price history of an exchange currency pair
+type market struct {
+	pair    string
+	buckets []bucket
+	updated time.Time
+}
+
+// healthKey identifies an exchange component updating a currency pair
+type healthKey struct {
+	Exchange  string
+	Component string
+	AssetType string
+	Pair      string
+}
+
+// Collector records the ticker updates and exchange failures summarised by
+// the reports. Price history older than the history duration is discarded
+type Collector struct {
+	history  time.Duration
+	markets  map[marketKey]*market
+	failures map[healthKey]Failure
+	mtx      sync.Mutex
+}
+
+// NewCollector returns a collector keeping the price history for the
+// duration
+func NewCollector(history time.Duration) *Collector {
+	return &Collector{
+		history:  history,
+		markets:  make(map[marketKey]*market),
+		failures: make(map[healthKey]Failure),
+	}
+}
+
+// RecordTicker adds the last price of a ticker update to the market history
+func (c *Collector) RecordTicker(exchangeName, assetType string, t ticker.Price, now time.Time) {
+	if t.Last <= 0 {
+		return
+	}
+
+	key := marketKey{
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Base:      t.Pair.FirstCurrency.Upper().String(),
+		Quote:     t.Pair.SecondCurrency.Upper().String(),
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	m, ok := c.markets[key]
+	if !ok {
+		m = &market{pair: t.Pair.Pair().String()}
+		c.markets[key] = m
+	}
+	m.updated = now
+
+	start := now.Truncate(bucketSize)
+	if n := len(m.buckets); n > 0 && m.buckets[n-1].start.Equal(start) {
+		b := &m.buckets[n-1]
+		if t.Last > b.high {
+			b.high = t.Last
+		}
+		if t.Last < b.low {
+			b.low = t.Last
+		}
+		b.close, b.vol = t.Last, t.Volume
+	} else {
+		m.buckets = append(m.buckets, bucket{
+			start: start,
+			open:  t.Last,
+			high:  t.Last,
+			low:   t.Last,
+			close: t.Last,
+			vol:   t.Volume,
+		})
+	}
+
+	cutoff := now.Add(-c.history)
+	var expired int
+	for expired < len(m.buckets) && m.buckets[expired].start.Before(cutoff) {
+		expired++
+	}
+	m.buckets = m.buckets[expired:]
+}
+
+// RecordHealth records the result of an exchange update, failures are
+// reported until the component succeeds again
+func (c *Collector) RecordHealth(exchangeName, component, assetType, pair string, err error, now time.Time) {
+	key := healthKey{
+		Exchange:  exchangeName,
+		Component: component,
+		AssetType: assetType,
+		Pair:      pair,
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if err == nil {
+		delete(c.failures, key)
+		return
+	}
+
+	failure, ok := c.failures[key]
+	if !ok {
+		failure = Failure{
+			Exchange:  exchangeName,
+			Component: component,
+			AssetType: assetType,
+			Pair:      pair,
+			Since:     now,
+		}
+	}
+	failure.Error = err.Error()
+	c.failures[key] = failure
+}
+
+// normalisePair removes the delimiters of a currency pair
+func normalisePair(p string) string {
+	return common.StringToUpper(strings.NewReplacer("-", "", "_", "", "/", "").Replace(p))
+}
+
+// Markets summarises the price history between since and until of the
+// markets trading the pairs, or every market if no pairs are supplied,
+// sorted by pair and exchange
+func (c *Collector) Markets(since, until time.Time, pairs []string) []MarketSummary {
+	wanted := make(map[string]bool)
+	for i := range pairs {
+		wanted[normalisePair(pairs[i])] = true
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var summaries []MarketSummary
+	for key, m := range c.markets {
+		if len(wanted) > 0 && !wanted[normalisePair(key.Base+key.Quote)] {
+			continue
+		}
+
+		var s MarketSummary
+		var found bool
+		for _, b := range m.buckets {
+			if b.start.Before(since.Truncate(bucketSize)) || b.start.After(until) {
+				continue
+			}
+
+			if !found {
+				s = MarketSummary{
+					Exchange:  key.Exchange,
+					AssetType: key.AssetType,
+					Pair:      m.pair,
+					Open:      b.open,
+					High:      b.high,
+					Low:       b.low,
+				}
+				found = true
+			}
+
+			if b.high > s.High {
+				s.High = b.high
+			}
+			if b.low < s.Low {
+				s.Low = b.low
+			}
+			s.Close, s.Volume = b.close, b.vol
+		}
+
+		if !found {
+			continue
+		}
+
+		if s.Open > 0 {
+			s.Change = (s.Close - s.Open) / s.Open * 100
+		}
+		summaries = append(summaries, s)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pair != summaries[j].Pair {
+			return summaries[i].Pair < summaries[j].Pair
+		}
+		if summaries[i].Exchange != summaries[j].Exchange {
+			return summaries[i].Exchange < summaries[j].Exchange
+		}
+		return summaries[i].AssetType < summaries[j].AssetType
+	})
+	return summaries
+}
+
+// TopMovers returns up to n summaries with the largest absolute price change
+func TopMovers(summaries []MarketSummary, n int) []MarketSummary {
+	movers := make([]MarketSummary, 0, len(summaries))
+	for i := range summaries {
+		if summaries[i].Change != 0 {
+			movers = append(movers, summaries[i])
+		}
+	}
+
+	sort.SliceStable(movers, func(i, j int) bool {
+		return abs(movers[i].Change) > abs(movers[j].Change)
+	})
+
+	if len(movers) > n {
+		movers = movers[:n]
+	}
+	return movers
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Health returns the failing exchange components and the markets which have
+// not been updated within staleAfter
+func (c *Collector) Health(now time.Time, staleAfter time.Duration) HealthSummary {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var health HealthSummary
+	for _, failure := range c.failures {
+		health.Failures = append(health.Failures, failure)
+	}
+
+	for key, m := range c.markets {
+		if now.Sub(m.updated) > staleAfter {
+			health.Stale = append(health.Stale, StalePair{
+				Exchange:    key.Exchange,
+				AssetType:   key.AssetType,
+				Pair:        m.pair,
+				LastUpdated: m.updated,
+			})
+		}
+	}
+
+	sort.Slice(health.Failures, func(i, j int) bool {
+		a, b := health.Failures[i], health.Failures[j]
+		return a.Exchange+a.Component+a.AssetType+a.Pair < b.Exchange+b.Component+b.AssetType+b.Pair
+	})
+	sort.Slice(health.Stale, func(i, j int) bool {
+		a, b := health.Stale[i], health.Stale[j]
+		return a.Exchange+a.AssetType+a.Pair < b.Exchange+b.AssetType+b.Pair
+	})
+	return health
+}
+
+// Price returns the most recent last price of the coin in the target
+// currency. Prices quoted in other currencies are converted if convert
+// supports the quote currency
+func (c *Collector) Price(coin, target string, convert func(amount float64, from, to string) (float64, error)) (float64, bool) {
+	coin, target = common.StringToUpper(coin), common.StringToUpper(target)
+	if coin == target {
+		return 1, true
+	}
+
+	c.mtx.Lock()
+	var candidates []*market
+	quotes := make(map[*market]string)
+	for key, m := range c.markets {
+		if key.Base == coin && len(m.buckets) > 0 {
+			candidates = append(candidates, m)
+			quotes[m] = key.Quote
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		// prefer the target quote currency, then the most recent update
+		ti, tj := quotes[candidates[i]] == target, quotes[candidates[j]] == target
+		if ti != tj {
+			return ti
+		}
+		return candidates[i].updated.After(candidates[j].updated)
+	})
+
+	prices := make([]float64, len(candidates))
+	for i := range candidates {
+		prices[i] = candidates[i].buckets[len(candidates[i].buckets)-1].close
+	}
+	c.mtx.Unlock()
+
+	for i := range candidates {
+		quote := quotes[candidates[i]]
+		if quote == target {
+			return prices[i], true
+		}
+
+		if convert == nil {
+			continue
+		}
+
+		if price, err := convert(prices[i], quote, target); err == nil && price > 0 {
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+// Candles returns the price history between since and until of the pair on
+// each exchange trading it, or only the named exchange, aggregated into
+// candles of the interval. Series are sorted by exchange and named after it
+func (c *Collector) Candles(p, exchangeName, assetType string, since, until time.Time, interval time.Duration) []charts.Series {
+	if interval < bucketSize {
+		interval = bucketSize
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var series []charts.Series
+	for key, m := range c.markets {
+		if normalisePair(key.Base+key.Quote) != normalisePair(p) || key.AssetType != assetType ||
+			(exchangeName != "" && !strings.EqualFold(key.Exchange, exchangeName)) {
+			continue
+		}
+
+		s := charts.Series{Name: key.Exchange}
+		for _, b := range m.buckets {
+			if b.start.Before(since.Truncate(bucketSize)) || b.start.After(until) {
+				continue
+			}
+
+			start := b.start.Truncate(interval)
+			n := len(s.Candles)
+			if n == 0 || !s.Candles[n-1].Time.Equal(start) {
+				s.Candles = append(s.Candles, charts.Candle{
+					Time:   start,
+					Open:   b.open,
+					High:   b.high,
+					Low:    b.low,
+					Close:  b.close,
+					Volume: b.vol,
+				})
+				continue
+			}
+
+			candle := &s.Candles[n-1]
+			if b.high > candle.High {
+				candle.High = b.high
+			}
+			if b.low < candle.Low {
+				candle.Low = b.low
+			}
+			candle.Close, candle.Volume = b.close, b.vol
+		}
+
+		if len(s.Candles) > 0 {
+			series = append(series, s)
+		}
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Name < series[j].Name
+	})
+	return series
+}
@@ -0,0 +1,186 @@
+package digest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func newTestCollector(now time.Time) *Collector {
+	c := NewCollector(time.Hour * 24)
+	btc := pair.NewCurrencyPair("BTC", "USD")
+	eth := pair.NewCurrencyPair("ETH", "USD")
+
+	c.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 10000, Volume: 10}, now.Add(-time.Hour*2))
+	c.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 10500, Volume: 12}, now.Add(-time.Hour))
+	c.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 9500, Volume: 13}, now.Add(-time.Hour+time.Second))
+	c.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 11000, Volume: 15}, now)
+	c.RecordTicker("Kraken", ticker.Spot, ticker.Price{Pair: eth, Last: 500}, now.Add(-time.Hour*2))
+	c.RecordTicker("Kraken", ticker.Spot, ticker.Price{Pair: eth, Last: 400}, now.Add(-time.Minute*30))
+	return c
+}
+
+func TestCollectorMarkets(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCollector(now)
+
+	markets := c.Markets(now.Add(-time.Hour*24), now, nil)
+	if len(markets) != 2 || markets[0].Pair != "BTCUSD" || markets[1].Pair != "ETHUSD" {
+		t.Fatalf("test failed - Markets() unexpected result %v", markets)
+	}
+
+	btc := markets[0]
+	if btc.Open != 10000 || btc.Close != 11000 || btc.High != 11000 || btc.Low != 9500 ||
+		btc.Volume != 15 || btc.Change != 10 {
+		t.Errorf("test failed - Markets() BTCUSD summary error %+v", btc)
+	}
+
+	markets = c.Markets(now.Add(-time.Minute*90), now, []string{"btc-usd"})
+	if len(markets) != 1 || markets[0].Open != 10500 {
+		t.Errorf("test failed - Markets() filter error %v", markets)
+	}
+
+	movers := TopMovers(c.Markets(now.Add(-time.Hour*24), now, nil), 1)
+	if len(movers) != 1 || movers[0].Pair != "ETHUSD" || movers[0].Change != -20 {
+		t.Errorf("test failed - TopMovers() error %v", movers)
+	}
+}
+
+func TestCollectorHealth(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCollector(now)
+
+	c.RecordHealth("Kraken", "ticker", ticker.Spot, "ETHUSD", errors.New("timeout"), now.Add(-time.Minute))
+	c.RecordHealth("Kraken", "ticker", ticker.Spot, "ETHUSD", errors.New("rate limited"), now)
+	c.RecordHealth("Bitstamp", "orderbook", ticker.Spot, "BTCUSD", errors.New("timeout"), now)
+	c.RecordHealth("Bitstamp", "orderbook", ticker.Spot, "BTCUSD", nil, now)
+
+	health := c.Health(now, time.Minute*10)
+	if len(health.Failures) != 1 || health.Failures[0].Error != "rate limited" ||
+		!health.Failures[0].Since.Equal(now.Add(-time.Minute)) {
+		t.Errorf("test failed - Health() failures error %v", health.Failures)
+	}
+
+	if len(health.Stale) != 1 || health.Stale[0].Exchange != "Kraken" {
+		t.Errorf("test failed - Health() stale pairs error %v", health.Stale)
+	}
+}
+
+func TestCollectorPrice(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCollector(now)
+
+	if price, ok := c.Price("btc", "USD", nil); !ok || price != 11000 {
+		t.Error("test failed - Price() expected 11000 got", price)
+	}
+
+	convert := func(amount float64, from, to string) (float64, error) {
+		if from == "USD" && to == "AUD" {
+			return amount * 1.5, nil
+		}
+		return 0, errors.New("unsupported")
+	}
+
+	if price, ok := c.Price("ETH", "AUD", convert); !ok || price != 600 {
+		t.Error("test failed - Price() expected converted price 600 got", price)
+	}
+
+	if _, ok := c.Price("LTC", "USD", convert); ok {
+		t.Error("test failed - Price() returned a price for an unknown coin")
+	}
+}
+
+func TestCollectorCandles(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCollector(now)
+	c.RecordTicker("Kraken", ticker.Spot, ticker.Price{Pair: pair.NewCurrencyPair("XBT", "USD"), Last: 10800}, now)
+	c.RecordTicker("Kraken", ticker.Spot, ticker.Price{Pair: pair.NewCurrencyPair("BTC", "USD"), Last: 10900, Volume: 3}, now)
+
+	series := c.Candles("BTC-USD", "", ticker.Spot, now.Add(-time.Hour*3), now, time.Hour*2)
+	if len(series) != 2 || series[0].Name != "Bitstamp" || series[1].Name != "Kraken" {
+		t.Fatalf("test failed - Candles() unexpected series %v", series)
+	}
+
+	// 10:00 and 11:00 are aggregated into the 10:00 candle
+	candles := series[0].Candles
+	if len(candles) != 2 || !candles[0].Time.Equal(now.Add(-time.Hour*2)) ||
+		candles[0].Open != 10000 || candles[0].High != 10500 || candles[0].Low != 9500 ||
+		candles[0].Close != 9500 || candles[1].Close != 11000 || candles[1].Volume != 15 {
+		t.Errorf("test failed - Candles() aggregation error %+v", candles)
+	}
+
+	series = c.Candles("BTCUSD", "kraken", ticker.Spot, now.Add(-time.Hour), now, 0)
+	if len(series) != 1 || len(series[0].Candles) != 1 || series[0].Candles[0].Close != 10900 {
+		t.Errorf("test failed - Candles() exchange filter error %v", series)
+	}
+
+	if series = c.Candles("BTCUSD", "", "FUTURES", now.Add(-time.Hour), now, 0); len(series) != 0 {
+		t.Error("test failed - Candles() asset type filter error")
+	}
+}
+
+func TestRender(t *testing.T) {
+	now := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCollector(now)
+	markets := c.Markets(now.Add(-time.Hour*24), now, nil)
+
+	previous := 10000.0
+	report := &Report{
+		Name:      "daily",
+		Generated: now,
+		Since:     now.Add(-time.Hour * 24),
+		Market:    &MarketSection{Pairs: markets, Movers: TopMovers(markets, 5)},
+		Portfolio: NewPortfolioSection("USD", map[string]float64{"BTC": 1, "XRP": 10},
+			func(coin string) (float64, bool) { return c.Price(coin, "USD", nil) }, &previous),
+		Health: &HealthSummary{},
+	}
+
+	if report.Portfolio.Value != 11000 || report.Portfolio.ChangePct != 10 ||
+		len(report.Portfolio.Coins) != 2 || report.Portfolio.Coins[1].Priced {
+		t.Errorf("test failed - NewPortfolioSection() error %+v", report.Portfolio)
+	}
+
+	tmpl, err := ParseTemplate("daily", "")
+	if err != nil {
+		t.Fatal("test failed - ParseTemplate() error", err)
+	}
+
+	text, err := Render(tmpl, report)
+	if err != nil {
+		t.Fatal("test failed - Render() error", err)
+	}
+
+	for _, expected := range []string{
+		"Bitstamp BTCUSD SPOT: open 10000.00 close 11000.00 high 11000.00 low 9500.00 volume 15.00 (+10.00%)",
+		"Kraken ETHUSD -20.00%",
+		"Portfolio value: 11000.00 USD (1000.00 USD, +10.00%)",
+		"XRP: 10.00 (no price)",
+		"All exchanges healthy.",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("test failed - Render() missing %q in:\n%s", expected, text)
+		}
+	}
+
+	report.Market, report.Health = nil, nil
+	if text, _ = Render(tmpl, report); strings.Contains(text, "Market") || strings.Contains(text, "health") {
+		t.Error("test failed - Render() included a disabled section")
+	}
+
+	tmpl, err = ParseTemplate("custom", "{{.Name}} {{price .Portfolio.Value}}")
+	if err != nil {
+		t.Fatal("test failed - ParseTemplate() error", err)
+	}
+
+	if text, err = Render(tmpl, report); err != nil || text != "daily 11000.00" {
+		t.Errorf("test failed - Render() custom template got %q", text)
+	}
+
+	if _, err = ParseTemplate("invalid", "{{.Name"); err == nil {
+		t.Error("test failed - ParseTemplate() accepted an invalid template")
+	}
+}
@@ -0,0 +1,190 @@
+package digest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplate is used by reports and recipients without a template
+const DefaultTemplate = `{{.Name}} report {{.Generated.Format "2006-01-02 15:04 MST"}}
+{{- with .Market}}
+
+Market since {{$.Since.Format "2006-01-02 15:04"}}:
+{{- range .Pairs}}
+{{.Exchange}} {{.Pair}} {{.AssetType}}: open {{price .Open}} close {{price .Close}} high {{price .High}} low {{price .Low}} volume {{price .Volume}} ({{pct .Change}})
+{{- else}}
+No market data.
+{{- end}}
+{{- if .Movers}}
+
+Top movers:
+{{- range .Movers}}
+{{.Exchange}} {{.Pair}} {{pct .Change}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- with .Portfolio}}
+
+Portfolio value: {{price .Value}} {{.Currency}}{{if .HasChange}} ({{price .Change}} {{.Currency}}, {{pct .ChangePct}}){{end}}
+{{- range .Coins}}
+{{.Coin}}: {{price .Balance}}{{if .Priced}} = {{price .Value}} {{$.Portfolio.Currency}}{{else}} (no price){{end}}
+{{- end}}
+{{- end}}
+{{- with .Health}}
+
+Feeder health:
+{{- range .Failures}}
+{{.Exchange}} {{.Component}} {{.Pair}} {{.AssetType}} failing since {{.Since.Format "15:04"}}: {{.Error}}
+{{- end}}
+{{- range .Stale}}
+{{.Exchange}} {{.Pair}} {{.AssetType}} stale since {{.LastUpdated.Format "15:04"}}
+{{- end}}
+{{- if and (not .Failures) (not .Stale)}}
+All exchanges healthy.
+{{- end}}
+{{- end}}`
+
+// MarketSummary is the price movement of an exchange currency pair during
+// the report period
+type MarketSummary struct {
+	Exchange  string
+	AssetType string
+	Pair      string
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Volume    float64
+	Change    float64 // percentage
+}
+
+// MarketSection holds the configured pairs and the top movers across every
+// exchange
+type MarketSection struct {
+	Pairs  []MarketSummary
+	Movers []MarketSummary
+}
+
+// CoinValue is a portfolio coin balance valued in the portfolio currency
+type CoinValue struct {
+	Coin    string
+	Balance float64
+	Value   float64
+	Priced  bool
+}
+
+// PortfolioSection is the portfolio value and its change since the previous
+// report
+type PortfolioSection struct {
+	Currency  string
+	Value     float64
+	Change    float64
+	ChangePct float64
+	HasChange bool
+	Coins     []CoinValue
+}
+
+// Failure is an exchange component which failed its most recent update
+type Failure struct {
+	Exchange  string
+	Component string
+	AssetType string
+	Pair      string
+	Error     string
+	Since     time.Time
+}
+
+// StalePair is a market which has not been updated recently
+type StalePair struct {
+	Exchange    string
+	AssetType   string
+	Pair        string
+	LastUpdated time.Time
+}
+
+// HealthSummary holds the failing exchanges and stale pairs
+type HealthSummary struct {
+	Failures []Failure
+	Stale    []StalePair
+}
+
+// Report is the data a report template is executed with, sections which are
+// not enabled are nil
+type Report struct {
+	Name      string
+	Generated time.Time
+	Since     time.Time
+	Market    *MarketSection
+	Portfolio *PortfolioSection
+	Health    *HealthSummary
+}
+
+// NewPortfolioSection values the balances using the price lookup and
+// calculates the change from the previous value if there is one
+func NewPortfolioSection(currencyCode string, balances map[string]float64, price func(coin string) (float64, bool), previous *float64) *PortfolioSection {
+	section := &PortfolioSection{Currency: currencyCode}
+	for coin, balance := range balances {
+		value := CoinValue{Coin: coin, Balance: balance}
+		if p, ok := price(coin); ok {
+			value.Value = balance * p
+			value.Priced = true
+			section.Value += value.Value
+		}
+		section.Coins = append(section.Coins, value)
+	}
+
+	sort.Slice(section.Coins, func(i, j int) bool {
+		if section.Coins[i].Value != section.Coins[j].Value {
+			return section.Coins[i].Value > section.Coins[j].Value
+		}
+		return section.Coins[i].Coin < section.Coins[j].Coin
+	})
+
+	if previous != nil {
+		section.HasChange = true
+		section.Change = section.Value - *previous
+		if *previous != 0 {
+			section.ChangePct = section.Change / *previous * 100
+		}
+	}
+	return section
+}
+
+// formatPrice formats a price with more decimals for small values
+func formatPrice(v float64) string {
+	if math.Abs(v) >= 1 || v == 0 {
+		return fmt.Sprintf("%.2f", v)
+	}
+	return fmt.Sprintf("%.8f", v)
+}
+
+// formatPercentage formats a signed percentage
+func formatPercentage(v float64) string {
+	return fmt.Sprintf("%+.2f%%", v)
+}
+
+// ParseTemplate parses a report template, an empty template returns the
+// default template
+func ParseTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+
+	return template.New(name).Funcs(template.FuncMap{
+		"price": formatPrice,
+		"pct":   formatPercentage,
+	}).Parse(text)
+}
+
+// Render executes the template with the report
+func Render(tmpl *template.Template, report *Report) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
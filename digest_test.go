@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/communications"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/digest"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/portfolio"
+)
+
+func TestSendDigest(t *testing.T) {
+	backupConfig, backupComms, backupPortfolio := bot.Config, bot.Comms, bot.Portfolio
+	defer func() {
+		bot.Config, bot.Comms, bot.Portfolio = backupConfig, backupComms, backupPortfolio
+		marketHistory = nil
+		digestStates = make(map[string]*digestState)
+	}()
+
+	bot.Config = &config.Config{}
+	bot.Config.Currency.FiatDisplayCurrency = "USD"
+	bot.Config.Digest = config.DigestConfig{
+		Enabled:    true,
+		History:    time.Hour * 24,
+		StaleAfter: time.Minute * 10,
+		TopMovers:  5,
+	}
+	bot.Portfolio = &portfolio.Base{Addresses: []portfolio.Address{
+		{Address: "1JCe8z4jJVNXSjohjM4i9Hh813dLCNx2Sy", CoinType: "BTC", Balance: 2,
+			Description: portfolio.PortfolioAddressPersonal},
+	}}
+
+	comm := &testRecipientComm{
+		Base:   base.Base{Name: "Telegram", Enabled: true, Connected: true},
+		pushed: make(map[string][]base.Event),
+	}
+	bot.Comms = &communications.Communications{IComm: base.IComm{comm}}
+
+	now := time.Date(2018, 6, 1, 8, 0, 0, 0, time.UTC)
+	btc := pair.NewCurrencyPair("BTC", "USD")
+	marketHistory = digest.NewCollector(bot.Config.Digest.History)
+	marketHistory.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 10000}, now.Add(-time.Hour))
+	marketHistory.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 10500}, now)
+	marketHistory.RecordHealth("Kraken", "ticker", ticker.Spot, "ETHUSD", errors.New("timeout"), now)
+
+	report := config.DigestReportConfig{
+		Name:     "daily",
+		Schedule: "@daily",
+		Sections: []string{config.DigestSectionMarket, config.DigestSectionPortfolio, config.DigestSectionHealth},
+		Pairs:    []string{"BTC-USD"},
+		Recipients: []config.DigestRecipientConfig{
+			{Medium: "Telegram", Recipient: "100"},
+			{Medium: "Telegram", Recipient: "200", Template: "{{.Name}}: {{price .Portfolio.Value}} {{pct .Portfolio.ChangePct}}"},
+		},
+	}
+
+	templates, err := parseDigestTemplates(report)
+	if err != nil {
+		t.Fatal("Test failed. parseDigestTemplates error", err)
+	}
+
+	sendDigest(report, templates, now)
+	if len(comm.pushed["100"]) != 1 || len(comm.pushed["200"]) != 1 {
+		t.Fatalf("Test failed. sendDigest did not push to each recipient %v", comm.pushed)
+	}
+
+	text := comm.pushed["100"][0].TradeDetails
+	for _, expected := range []string{
+		"Bitstamp BTCUSD SPOT: open 10000.00 close 10500.00",
+		"Portfolio value: 21000.00 USD",
+		"Kraken ticker ETHUSD SPOT failing since 08:00: timeout",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Test failed. sendDigest report missing %q in:\n%s", expected, text)
+		}
+	}
+
+	if comm.pushed["100"][0].Type != digestEventType {
+		t.Error("Test failed. sendDigest unexpected event type", comm.pushed["100"][0].Type)
+	}
+
+	marketHistory.RecordTicker("Bitstamp", ticker.Spot, ticker.Price{Pair: btc, Last: 11550}, now.Add(time.Hour))
+	sendDigest(report, templates, now.Add(time.Hour*24))
+	if text = comm.pushed["200"][1].TradeDetails; text != "daily: 23100.00 +10.00%" {
+		t.Errorf("Test failed. sendDigest recipient template got %q", text)
+	}
+
+	report.Recipients[1].Template = "{{.Name"
+	if _, err = parseDigestTemplates(report); err == nil {
+		t.Error("Test failed. parseDigestTemplates accepted an invalid template")
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the events integration
+const (
+	eventsFeedBufferSize = 4096
+)
+
+var (
+	errEventNotFound  = errors.New("event not found")
+	errEventInvalidID = errors.New("invalid event ID")
+)
+
+// RESTv2Event holds a price event, Window and Cooldown are Go duration strings
+// such as 1h30m
+type RESTv2Event struct {
+	ID            int        `json:"id"`
+	Exchange      string     `json:"exchange"`
+	AssetType     string     `json:"assetType"`
+	Pair          string     `json:"pair"`
+	Item          string     `json:"item"`
+	Condition     string     `json:"condition"`
+	Window        string     `json:"window,omitempty"`
+	Action        string     `json:"action"`
+	Repeat        bool       `json:"repeat"`
+	Cooldown      string     `json:"cooldown,omitempty"`
+	Executed      bool       `json:"executed"`
+	LastTriggered *time.Time `json:"lastTriggered,omitempty"`
+	Owner         string     `json:"owner,omitempty"`
+	Medium        string     `json:"medium,omitempty"`
+	Recipient     string     `json:"recipient,omitempty"`
+	Severity      string     `json:"severity,omitempty"`
+}
+
+// NewRESTv2Event converts an event to its v2 representation
+func NewRESTv2Event(e events.Event) RESTv2Event {
+	result := RESTv2Event{
+		ID:        e.ID,
+		Exchange:  e.Exchange,
+		AssetType: e.Asset,
+		Pair:      e.Pair.Pair().String(),
+		Item:      common.StringToLower(e.Item),
+		Condition: e.Condition,
+		Action:    e.Action,
+		Repeat:    e.Repeat,
+		Executed:  e.Executed,
+		Owner:     e.Owner,
+		Medium:    e.Medium,
+		Recipient: e.Recipient,
+		Severity:  e.Severity,
+	}
+
+	if e.Window > 0 {
+		result.Window = e.Window.String()
+	}
+
+	if e.Cooldown > 0 {
+		result.Cooldown = e.Cooldown.String()
+	}
+
+	if !e.LastTriggered.IsZero() {
+		lastTriggered := e.LastTriggered
+		result.LastTriggered = &lastTriggered
+	}
+	return result
+}
+
+// Event converts the v2 representation to an event, the ID and trigger state
+// are ignored. The pair may be omitted for expression conditions
+func (r *RESTv2Event) Event() (events.Event, error) {
+	e := events.Event{
+		Exchange:  r.Exchange,
+		Asset:     r.AssetType,
+		Item:      r.Item,
+		Condition: r.Condition,
+		Action:    r.Action,
+		Repeat:    r.Repeat,
+		Owner:     r.Owner,
+		Medium:    r.Medium,
+		Recipient: r.Recipient,
+		Severity:  r.Severity,
+	}
+
+	var err error
+	if r.Pair != "" {
+		e.Pair, err = parseRESTv2Pair(r.Pair)
+		if err != nil {
+			return events.Event{}, err
+		}
+	}
+
+	if r.Window != "" {
+		e.Window, err = time.ParseDuration(r.Window)
+		if err != nil {
+			return events.Event{}, err
+		}
+	}
+
+	if r.Cooldown != "" {
+		e.Cooldown, err = time.ParseDuration(r.Cooldown)
+		if err != nil {
+			return events.Event{}, err
+		}
+	}
+	return e, nil
+}
+
+// StartEvents loads the persisted events and evaluates them on every ticker
+// update
+func StartEvents() {
+	events.SetComms(bot.Comms)
+	events.LoadEvents(bot.Config.Events)
+	events.SetPersistHandler(persistEvents)
+
+	total, executed := events.GetEventCounter()
+	log.Printf("Events: loaded %d event/s, %d executed.", total, executed)
+	go eventsDispatcher(SubscribeFeed(eventsFeedBufferSize))
+}
+
+// ReloadEvents reloads the events after the config has been updated
+func ReloadEvents() {
+	events.LoadEvents(bot.Config.Events)
+}
+
+// eventsDispatcher evaluates the events watching each ticker update
+func eventsDispatcher(feed chan WebsocketEvent) {
+	for evt := range feed {
+		t, ok := evt.Data.(ticker.Price)
+		if !ok {
+			continue
+		}
+		events.ProcessTicker(evt.Exchange, evt.AssetType, t)
+	}
+}
+
+// GetRESTv2Events returns the v2 representation of all events
+func GetRESTv2Events() []RESTv2Event {
+	all := events.GetEvents()
+	result := make([]RESTv2Event, len(all))
+	for x := range all {
+		result[x] = NewRESTv2Event(all[x])
+	}
+	return result
+}
+
+// CreateEvent adds an event and returns its v2 representation
+func CreateEvent(r RESTv2Event) (RESTv2Event, error) {
+	e, err := r.Event()
+	if err != nil {
+		return RESTv2Event{}, err
+	}
+
+	id, err := events.Add(e)
+	if err != nil {
+		return RESTv2Event{}, err
+	}
+
+	e, _ = events.GetEvent(id)
+	return NewRESTv2Event(e), nil
+}
+
+// RESTv2GetEvents returns the price events
+func RESTv2GetEvents(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	result := GetRESTv2Events()
+	restV2List(w, r, query, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+// RESTv2CreateEvent creates a price event from the request body
+func RESTv2CreateEvent(w http.ResponseWriter, r *http.Request) {
+	var req RESTv2Event
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := CreateEvent(req)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+	RESTv2JSONResponse(w, r, http.StatusCreated, result)
+}
+
+// RESTv2DeleteEvent deletes a price event by its ID
+func RESTv2DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, errEventInvalidID)
+		return
+	}
+
+	if !events.RemoveEvent(id) {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errEventNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func wsGetEvents(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "GetEvents",
+		Data:  GetRESTv2Events(),
+	}
+	return client.SendWebsocketMessage(wsResp)
+}
+
+func wsAddEvent(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "AddEvent",
+	}
+
+	var req RESTv2Event
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	result, err := CreateEvent(req)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	wsResp.Data = result
+	return client.SendWebsocketMessage(wsResp)
+}
+
+// WebsocketEventID is the websocket request of commands taking an event ID
+type WebsocketEventID struct {
+	ID int `json:"id"`
+}
+
+func wsRemoveEvent(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "RemoveEvent",
+	}
+
+	var req WebsocketEventID
+	err := common.JSONDecode(data.([]byte), &req)
+	if err == nil && !events.RemoveEvent(req.ID) {
+		err = errEventNotFound
+	}
+
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	wsResp.Data = WebsocketResponseSuccess
+	return client.SendWebsocketMessage(wsResp)
+}
@@ -1,369 +1,316 @@
 package events
 
-//
-// import (
-// 	"testing"
-//
-// 	"github.com/trustfeed/go-crypto-pricefeeder/config"
-// 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
-// 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
-// 	"github.com/trustfeed/go-crypto-pricefeeder/smsglobal"
-// )
-//
-// var (
-// 	loaded = false
-// )
-//
-// func testSetup(t *testing.T) {
-// 	if !loaded {
-// 		cfg := config.GetConfig()
-// 		err := cfg.LoadConfig("")
-// 		if err != nil {
-// 			t.Fatalf("Test failed. Failed to load config %s", err)
-// 		}
-// 		smsglobal.New(cfg.SMS.Username, cfg.SMS.Password, cfg.Name, cfg.SMS.Contacts)
-// 		loaded = true
-// 	}
-// }
-//
-// func TestAddEvent(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	eventID, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil && eventID != 0 {
-// 		t.Errorf("Test Failed. AddEvent: Error, %s", err)
-// 	}
-// 	eventID, err = AddEvent("ANXX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err == nil && eventID == 0 {
-// 		t.Error("Test Failed. AddEvent: Error, error not captured in Exchange")
-// 	}
-// 	eventID, err = AddEvent("ANX", "prices", ">,==", pair, "SPOT", actionTest)
-// 	if err == nil && eventID == 0 {
-// 		t.Error("Test Failed. AddEvent: Error, error not captured in Item")
-// 	}
-// 	eventID, err = AddEvent("ANX", "price", "3===D", pair, "SPOT", actionTest)
-// 	if err == nil && eventID == 0 {
-// 		t.Error("Test Failed. AddEvent: Error, error not captured in Condition")
-// 	}
-// 	eventID, err = AddEvent("ANX", "price", ">,==", pair, "SPOT", "console_prints")
-// 	if err == nil && eventID == 0 {
-// 		t.Error("Test Failed. AddEvent: Error, error not captured in Action")
-// 	}
-//
-// 	if !RemoveEvent(eventID) {
-// 		t.Error("Test Failed. RemoveEvent: Error, error removing event")
-// 	}
-// }
-//
-// func TestRemoveEvent(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	eventID, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil && eventID != 0 {
-// 		t.Errorf("Test Failed. RemoveEvent: Error, %s", err)
-// 	}
-// 	if !RemoveEvent(eventID) {
-// 		t.Error("Test Failed. RemoveEvent: Error, error removing event")
-// 	}
-// 	if RemoveEvent(1234) {
-// 		t.Error("Test Failed. RemoveEvent: Error, error removing event")
-// 	}
-// }
-//
-// func TestGetEventCounter(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	one, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. GetEventCounter: Error, %s", err)
-// 	}
-// 	two, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. GetEventCounter: Error, %s", err)
-// 	}
-// 	three, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. GetEventCounter: Error, %s", err)
-// 	}
-//
-// 	Events[three-1].Executed = true
-//
-// 	total, _ := GetEventCounter()
-// 	if total <= 0 {
-// 		t.Errorf("Test Failed. GetEventCounter: Total = %d", total)
-// 	}
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. GetEventCounter: Error, error removing event")
-// 	}
-// 	if !RemoveEvent(two) {
-// 		t.Error("Test Failed. GetEventCounter: Error, error removing event")
-// 	}
-// 	if !RemoveEvent(three) {
-// 		t.Error("Test Failed. GetEventCounter: Error, error removing event")
-// 	}
-//
-// 	total2, _ := GetEventCounter()
-// 	if total2 != 0 {
-// 		t.Errorf("Test Failed. GetEventCounter: Total = %d", total2)
-// 	}
-// }
-//
-// func TestExecuteAction(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	one, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Fatalf("Test Failed. ExecuteAction: Error, %s", err)
-// 	}
-// 	isExecuted := Events[one].ExecuteAction()
-// 	if !isExecuted {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-//
-// 	action := actionSMSNotify + "," + "ALL"
-// 	one, err = AddEvent("ANX", "price", ">,==", pair, "SPOT", action)
-// 	if err != nil {
-// 		t.Fatalf("Test Failed. ExecuteAction: Error, %s", err)
-// 	}
-//
-// 	isExecuted = Events[one].ExecuteAction()
-// 	if !isExecuted {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-//
-// 	action = actionSMSNotify + "," + "StyleGherkin"
-// 	one, err = AddEvent("ANX", "price", ">,==", pair, "SPOT", action)
-// 	if err != nil {
-// 		t.Fatalf("Test Failed. ExecuteAction: Error, %s", err)
-// 	}
-//
-// 	isExecuted = Events[one].ExecuteAction()
-// 	if !isExecuted {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. ExecuteAction: Error, error removing event")
-// 	}
-// 	// More tests when ExecuteAction is expanded
-// }
-//
-// func TestEventToString(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	one, err := AddEvent("ANX", "price", ">,==", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. EventToString: Error, %s", err)
-// 	}
-//
-// 	eventString := Events[one].String()
-// 	if eventString != "If the BTCUSD [SPOT] price on ANX is > == then ACTION_TEST." {
-// 		t.Error("Test Failed. EventToString: Error, incorrect return string")
-// 	}
-//
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. EventToString: Error, error removing event")
-// 	}
-// }
-//
-// func TestCheckCondition(t *testing.T) {
-// 	testSetup(t)
-//
-// 	// Test invalid currency pair
-// 	newPair := pair.NewCurrencyPair("A", "B")
-// 	one, err := AddEvent("ANX", "price", ">=,10", newPair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. CheckCondition: Error, %s", err)
-// 	}
-// 	conditionBool := Events[one].CheckCondition()
-// 	if conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	// Test last price == 0
-// 	var tickerNew ticker.Price
-// 	tickerNew.Last = 0
-// 	newPair = pair.NewCurrencyPair("BTC", "USD")
-// 	ticker.ProcessTicker("ANX", newPair, tickerNew, ticker.Spot)
-// 	Events[one].Pair = newPair
-// 	conditionBool = Events[one].CheckCondition()
-// 	if conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	// Test last pricce > 0 and conditional logic
-// 	tickerNew.Last = 11
-// 	ticker.ProcessTicker("ANX", newPair, tickerNew, ticker.Spot)
-// 	Events[one].Condition = ">,10"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if !conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	// Test last price >= 10
-// 	Events[one].Condition = ">=,10"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if !conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	// Test last price <= 10
-// 	Events[one].Condition = "<,100"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if !conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	// Test last price <= 10
-// 	Events[one].Condition = "<=,100"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if !conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	Events[one].Condition = "==,11"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if !conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	Events[one].Condition = "^,11"
-// 	conditionBool = Events[one].CheckCondition()
-// 	if conditionBool {
-// 		t.Error("Test Failed. CheckCondition: Error, wrong conditional.")
-// 	}
-//
-// 	if !RemoveEvent(one) {
-// 		t.Error("Test Failed. CheckCondition: Error, error removing event")
-// 	}
-// }
-//
-// func TestIsValidEvent(t *testing.T) {
-// 	testSetup(t)
-//
-// 	err := IsValidEvent("ANX", "price", ">,==", actionTest)
-// 	if err != nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-// 	err = IsValidEvent("ANX", "price", ">,", actionTest)
-// 	if err == nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-// 	err = IsValidEvent("ANX", "Testy", ">,==", actionTest)
-// 	if err == nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-// 	err = IsValidEvent("Testys", "price", ">,==", actionTest)
-// 	if err == nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-//
-// 	action := "blah,blah"
-// 	err = IsValidEvent("ANX", "price", ">=,10", action)
-// 	if err == nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-//
-// 	action = "SMS,blah"
-// 	err = IsValidEvent("ANX", "price", ">=,10", action)
-// 	if err == nil {
-// 		t.Errorf("Test Failed. IsValidEvent: %s", err)
-// 	}
-//
-// 	//Function tests need to appended to this function when more actions are
-// 	//implemented
-// }
-//
-// func TestCheckEvents(t *testing.T) {
-// 	testSetup(t)
-//
-// 	pair := pair.NewCurrencyPair("BTC", "USD")
-// 	_, err := AddEvent("ANX", "price", ">=,10", pair, "SPOT", actionTest)
-// 	if err != nil {
-// 		t.Fatal("Test failed. TestChcheckEvents add event")
-// 	}
-//
-// 	go CheckEvents()
-// }
-//
-// func TestIsValidExchange(t *testing.T) {
-// 	testSetup(t)
-//
-// 	boolean := IsValidExchange("ANX")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidExchange: Error, incorrect Exchange")
-// 	}
-// 	boolean = IsValidExchange("OBTUSE")
-// 	if boolean {
-// 		t.Error("Test Failed. IsValidExchange: Error, incorrect return")
-// 	}
-// }
-//
-// func TestIsValidCondition(t *testing.T) {
-// 	testSetup(t)
-//
-// 	boolean := IsValidCondition(">")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect Condition")
-// 	}
-// 	boolean = IsValidCondition(">=")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect Condition")
-// 	}
-// 	boolean = IsValidCondition("<")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect Condition")
-// 	}
-// 	boolean = IsValidCondition("<=")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect Condition")
-// 	}
-// 	boolean = IsValidCondition("==")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect Condition")
-// 	}
-// 	boolean = IsValidCondition("**********")
-// 	if boolean {
-// 		t.Error("Test Failed. IsValidCondition: Error, incorrect return")
-// 	}
-// }
-//
-// func TestIsValidAction(t *testing.T) {
-// 	testSetup(t)
-//
-// 	boolean := IsValidAction("sms")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidAction: Error, incorrect Action")
-// 	}
-// 	boolean = IsValidAction(actionTest)
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidAction: Error, incorrect Action")
-// 	}
-// 	boolean = IsValidAction("randomstring")
-// 	if boolean {
-// 		t.Error("Test Failed. IsValidAction: Error, incorrect return")
-// 	}
-// }
-//
-// func TestIsValidItem(t *testing.T) {
-// 	testSetup(t)
-//
-// 	boolean := IsValidItem("price")
-// 	if !boolean {
-// 		t.Error("Test Failed. IsValidItem: Error, incorrect Item")
-// 	}
-// 	boolean = IsValidItem("obtuse")
-// 	if boolean {
-// 		t.Error("Test Failed. IsValidItem: Error, incorrect return")
-// 	}
-// }
+import (
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+var (
+	loaded = false
+)
+
+func testSetup(t *testing.T) {
+	if !loaded {
+		cfg := config.GetConfig()
+		err := cfg.LoadConfig(config.ConfigTestFile)
+		if err != nil {
+			t.Fatalf("test failed - failed to load config %s", err)
+		}
+		loaded = true
+	}
+	LoadEvents(nil)
+	SetPersistHandler(nil)
+}
+
+func testPrice(last float64, at time.Time) ticker.Price {
+	return ticker.Price{
+		Pair:        pair.NewCurrencyPair("BTC", "USD"),
+		Last:        last,
+		Bid:         last - 1,
+		Ask:         last + 1,
+		Volume:      100,
+		LastUpdated: at,
+	}
+}
+
+func TestAddEvent(t *testing.T) {
+	testSetup(t)
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	eventID, err := AddEvent("anx", "price", ">,1000", p, ticker.Spot, actionTest)
+	if err != nil {
+		t.Fatalf("test failed - AddEvent error %s", err)
+	}
+
+	e, ok := GetEvent(eventID)
+	if !ok || e.Exchange != "ANX" || e.Item != itemPrice {
+		t.Errorf("test failed - unexpected event %v", e)
+	}
+
+	_, err = AddEvent("ANXX", "price", ">,1000", p, ticker.Spot, actionTest)
+	if err != errExchangeDisabled {
+		t.Error("test failed - AddEvent error not captured in Exchange")
+	}
+
+	_, err = AddEvent("ANX", "prices", ">,1000", p, ticker.Spot, actionTest)
+	if err != errInvalidItem {
+		t.Error("test failed - AddEvent error not captured in Item")
+	}
+
+	_, err = AddEvent("ANX", "price", ">,abc", p, ticker.Spot, actionTest)
+	if err != errInvalidCondition {
+		t.Error("test failed - AddEvent error not captured in Condition")
+	}
+
+	_, err = AddEvent("ANX", "price", ">,1000", p, ticker.Spot, "console_prints")
+	if err != errInvalidAction {
+		t.Error("test failed - AddEvent error not captured in Action")
+	}
+
+	_, err = Add(Event{Exchange: "ANX", Item: "pct_change", Condition: "<,-3", Pair: p,
+		Action: actionTest})
+	if err != errInvalidWindow {
+		t.Error("test failed - Add error not captured in Window")
+	}
+}
+
+func TestRemoveEvent(t *testing.T) {
+	testSetup(t)
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	first, _ := AddEvent("ANX", "price", ">,1000", p, ticker.Spot, actionTest)
+	second, _ := AddEvent("ANX", "price", ">,1000", p, ticker.Spot, actionTest)
+
+	if !RemoveEvent(first) {
+		t.Error("test failed - RemoveEvent error removing event")
+	}
+
+	if RemoveEvent(first) {
+		t.Error("test failed - RemoveEvent removed an event twice")
+	}
+
+	third, _ := AddEvent("ANX", "price", ">,1000", p, ticker.Spot, actionTest)
+	if third == second {
+		t.Error("test failed - event IDs should not be reused")
+	}
+
+	total, executed := GetEventCounter()
+	if total != 2 || executed != 0 {
+		t.Errorf("test failed - GetEventCounter returned %d %d", total, executed)
+	}
+}
+
+func TestCheckCondition(t *testing.T) {
+	tests := []struct {
+		Condition string
+		Value     float64
+		Expected  bool
+	}{
+		{">,100", 101, true},
+		{">,100", 100, false},
+		{">=,100", 100, true},
+		{"<,100", 99, true},
+		{"<=,100", 101, false},
+		{"==,100", 100, true},
+	}
+
+	for _, test := range tests {
+		e := Event{Condition: test.Condition}
+		if e.CheckCondition(test.Value) != test.Expected {
+			t.Errorf("test failed - CheckCondition %s with %v", test.Condition, test.Value)
+		}
+	}
+}
+
+func TestProcessTickerItems(t *testing.T) {
+	testSetup(t)
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	items := map[string]string{
+		"bid":    "==,6499",
+		"ask":    "==,6501",
+		"spread": "==,2",
+		"volume": ">,50",
+		"last":   "<,6000",
+	}
+
+	ids := make(map[int]string)
+	for item, condition := range items {
+		id, err := AddEvent("Bitfinex", item, condition, p, ticker.Spot, actionTest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids[id] = item
+	}
+
+	ProcessTicker("bitfinex", ticker.Spot, testPrice(6500, time.Now()))
+	for id, item := range ids {
+		e, _ := GetEvent(id)
+		if e.Executed != (item != "last") {
+			t.Errorf("test failed - %s event executed %v", item, e.Executed)
+		}
+	}
+
+	ProcessTicker("Bitfinex", "FUTURES", testPrice(5000, time.Now()))
+	if _, executed := GetEventCounter(); executed != 4 {
+		t.Errorf("test failed - events should only match their asset type, %d executed", executed)
+	}
+}
+
+func TestProcessTickerRepeat(t *testing.T) {
+	testSetup(t)
+
+	var persisted []config.EventConfig
+	SetPersistHandler(func(cfgs []config.EventConfig) {
+		persisted = cfgs
+	})
+
+	id, err := Add(Event{
+		Exchange:  "Bitfinex",
+		Item:      "last",
+		Condition: ">,6000",
+		Pair:      pair.NewCurrencyPair("BTC", "USD"),
+		Action:    actionTest,
+		Repeat:    true,
+		Cooldown:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	ProcessTicker("Bitfinex", ticker.Spot, testPrice(6500, start))
+	e, _ := GetEvent(id)
+	if !e.LastTriggered.Equal(start) || e.Executed {
+		t.Fatalf("test failed - repeating event not triggered %v", e)
+	}
+
+	if len(persisted) != 1 || persisted[0].LastTriggered != start.Unix() {
+		t.Errorf("test failed - trigger not persisted %v", persisted)
+	}
+
+	ProcessTicker("Bitfinex", ticker.Spot, testPrice(6500, start.Add(time.Second*30)))
+	e, _ = GetEvent(id)
+	if !e.LastTriggered.Equal(start) {
+		t.Error("test failed - repeating event triggered during its cooldown")
+	}
+
+	ProcessTicker("Bitfinex", ticker.Spot, testPrice(6500, start.Add(time.Minute)))
+	e, _ = GetEvent(id)
+	if !e.LastTriggered.Equal(start.Add(time.Minute)) {
+		t.Error("test failed - repeating event not triggered after its cooldown")
+	}
+}
+
+func TestProcessTickerPctChange(t *testing.T) {
+	testSetup(t)
+
+	id, err := Add(Event{
+		Exchange:  "Bitfinex",
+		Item:      "pct_change",
+		Condition: "<=,-5",
+		Window:    time.Minute * 5,
+		Pair:      pair.NewCurrencyPair("BTC", "USD"),
+		Action:    actionTest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	prices := []float64{1000, 980, 960, 950, 940}
+	for x := range prices {
+		ProcessTicker("Bitfinex", ticker.Spot,
+			testPrice(prices[x], start.Add(time.Duration(x)*time.Minute*2)))
+
+		e, _ := GetEvent(id)
+		// 960 at 4m has no 5m baseline, 950 at 6m is -5% of 1000 at 0m
+		if e.Executed != (x >= 3) {
+			t.Errorf("test failed - pct_change event executed %v after price %v",
+				e.Executed, prices[x])
+		}
+	}
+}
+
+func TestLoadEvents(t *testing.T) {
+	testSetup(t)
+
+	LoadEvents([]config.EventConfig{
+		{ID: 4, Exchange: "Bitfinex", Pair: pair.NewCurrencyPair("BTC", "USD"),
+			Item: "ask", Condition: ">,1", Action: actionConsolePrint, Repeat: true,
+			LastTriggered: 1500000000},
+		{ID: 5, Exchange: "Bitfinex", Item: "invalid", Condition: ">,1",
+			Action: actionConsolePrint},
+	})
+
+	all := GetEvents()
+	if len(all) != 1 || all[0].Cooldown != DefaultCooldown || all[0].Asset != ticker.Spot ||
+		all[0].LastTriggered.Unix() != 1500000000 {
+		t.Fatalf("test failed - unexpected loaded events %v", all)
+	}
+
+	id, _ := AddEvent("Bitfinex", "bid", ">,1", pair.NewCurrencyPair("BTC", "USD"),
+		ticker.Spot, actionTest)
+	if id != 5 {
+		t.Errorf("test failed - expected the next event ID 5, got %d", id)
+	}
+
+	cfgs := GetEventConfigs()
+	if len(cfgs) != 2 || cfgs[0].LastTriggered != 1500000000 || cfgs[1].LastTriggered != 0 {
+		t.Errorf("test failed - unexpected event configs %v", cfgs)
+	}
+}
+
+func TestIsValidExchange(t *testing.T) {
+	testSetup(t)
+
+	if !IsValidExchange("bitfinex") {
+		t.Error("test failed - IsValidExchange should match case insensitively")
+	}
+
+	if IsValidExchange("invalid") {
+		t.Error("test failed - IsValidExchange returned true for an invalid exchange")
+	}
+}
+
+func TestAddNotifyEvent(t *testing.T) {
+	testSetup(t)
+
+	e := Event{
+		Exchange:  "Bitfinex",
+		Item:      itemLast,
+		Condition: ">,1000",
+		Pair:      pair.NewCurrencyPair("BTC", "USD"),
+		Action:    actionNotify,
+		Owner:     "telegram:1",
+	}
+	if _, err := Add(e); err != errInvalidRecipient {
+		t.Error("test failed - NOTIFY event without a recipient added", err)
+	}
+
+	e.Medium, e.Recipient, e.Severity = "Telegram", "1", "urgent"
+	if _, err := Add(e); err != errInvalidSeverity {
+		t.Error("test failed - NOTIFY event with an invalid severity added", err)
+	}
+
+	e.Severity = ""
+	id, err := Add(e)
+	if err != nil {
+		t.Fatal("test failed - NOTIFY event not added", err)
+	}
+
+	owned := GetEventsByOwner("telegram:1")
+	if len(owned) != 1 || owned[0].ID != id || len(GetEventsByOwner("telegram:2")) != 0 {
+		t.Errorf("test failed - GetEventsByOwner unexpected result %v", owned)
+	}
+
+	cfgs := GetEventConfigs()
+	if cfgs[0].Owner != "telegram:1" || cfgs[0].Medium != "Telegram" || cfgs[0].Recipient != "1" ||
+		cfgs[0].Severity != "warning" {
+		t.Errorf("test failed - GetEventConfigs unexpected result %v", cfgs[0])
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/communications"
@@ -12,40 +15,101 @@ import (
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/webhooks"
 )
 
 const (
 	itemPrice          = "PRICE"
+	itemLast           = "LAST"
+	itemBid            = "BID"
+	itemAsk            = "ASK"
+	itemHigh           = "HIGH"
+	itemLow            = "LOW"
+	itemVolume         = "VOLUME"
+	itemSpread         = "SPREAD"
+	itemPctChange      = "PCT_CHANGE"
 	greaterThan        = ">"
 	greaterThanOrEqual = ">="
 	lessThan           = "<"
 	lessThanOrEqual    = "<="
 	isEqual            = "=="
 	actionSMSNotify    = "SMS"
+	actionNotify       = "NOTIFY"
 	actionConsolePrint = "CONSOLE_PRINT"
 	actionTest         = "ACTION_TEST"
+	eventTypeAlert     = "ALERT"
+	eventTypeTrigger   = "EVENT"
+
+	// DefaultCooldown is the cooldown of repeating events which do not set one
+	DefaultCooldown = time.Minute * 5
+	// MaxWindow is the maximum lookback of percent change events
+	MaxWindow = time.Hour * 24
 )
 
 var (
 	errInvalidItem      = errors.New("invalid item")
 	errInvalidCondition = errors.New("invalid conditional option")
 	errInvalidAction    = errors.New("invalid action")
+	errInvalidWindow    = fmt.Errorf("percent change events require a window between 1s and %v", MaxWindow)
 	errExchangeDisabled = errors.New("desired exchange is disabled")
+	errInvalidRecipient = errors.New("notify events require a medium and recipient")
+	errInvalidSeverity  = errors.New("invalid severity, must be info, warning or critical")
 
 	// NOTE comms is an interim implementation
 	comms *communications.Communications
+
+	webhookDispatcher *webhooks.Webhooks
+	persistHandler    func([]config.EventConfig)
+
+	// tickers holds the latest ticker and the ticker history of the feeds
+	// referenced by the events
+	tickers = evalContext{
+		latest:  make(map[string]ticker.Price),
+		history: make(map[string][]ticker.Price),
+	}
+	mtx sync.Mutex
 )
 
-// Event struct holds the event variables
+// Event struct holds the event variables. Condition is either an operator and
+// value such as ">,6500" compared against the item of the exchange ticker, or
+// an expression such as "bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50" in
+// which case the item and window are unused and the exchange, pair and asset
+// are only set if the expression references a single exchange ticker. NOTIFY
+// events are owned by a chat user and notify the recipient chat of the medium
+// they were created from. Severity routes the notification of the event and
+// defaults to warning
 type Event struct {
-	ID        int
-	Exchange  string
-	Item      string
-	Condition string
-	Pair      pair.CurrencyPair
-	Asset     string
-	Action    string
-	Executed  bool
+	ID            int               `json:"id"`
+	Exchange      string            `json:"exchange"`
+	Item          string            `json:"item"`
+	Condition     string            `json:"condition"`
+	Window        time.Duration     `json:"window,omitempty"`
+	Pair          pair.CurrencyPair `json:"pair"`
+	Asset         string            `json:"asset"`
+	Action        string            `json:"action"`
+	Repeat        bool              `json:"repeat"`
+	Cooldown      time.Duration     `json:"cooldown,omitempty"`
+	Executed      bool              `json:"executed"`
+	LastTriggered time.Time         `json:"lastTriggered,omitempty"`
+	Owner         string            `json:"owner,omitempty"`
+	Medium        string            `json:"medium,omitempty"`
+	Recipient     string            `json:"recipient,omitempty"`
+	Severity      string            `json:"severity,omitempty"`
+
+	expr *Expression
+}
+
+// TriggerPayload is the webhook payload sent when an event is triggered
+type TriggerPayload struct {
+	ID        int     `json:"id"`
+	Exchange  string  `json:"exchange"`
+	Pair      string  `json:"pair"`
+	Asset     string  `json:"asset"`
+	Item      string  `json:"item"`
+	Condition string  `json:"condition"`
+	Action    string  `json:"action"`
+	Value     float64 `json:"value"`
+	Message   string  `json:"message"`
 }
 
 // Events variable is a pointer array to the event structures that will be
@@ -58,47 +122,219 @@ func SetComms(commsP *communications.Communications) {
 	comms = commsP
 }
 
-// AddEvent adds an event to the Events chain and returns an index/eventID
-// and an error
+// SetWebhooks sets the webhook dispatcher which is notified of triggered
+// events
+func SetWebhooks(w *webhooks.Webhooks) {
+	webhookDispatcher = w
+}
+
+// SetPersistHandler sets the function which stores the events whenever they
+// are added, removed or triggered
+func SetPersistHandler(fn func([]config.EventConfig)) {
+	mtx.Lock()
+	persistHandler = fn
+	mtx.Unlock()
+}
+
+// persist passes the current events to the persist handler
+func persist() {
+	mtx.Lock()
+	fn := persistHandler
+	mtx.Unlock()
+
+	if fn != nil {
+		fn(GetEventConfigs())
+	}
+}
+
+// exchangeName returns the configured name of an enabled exchange, matched
+// case insensitively ignoring spaces and underscores
+func exchangeName(exch string) (string, bool) {
+	exch = normaliseExchange(exch)
+	cfg := config.GetConfig()
+	for _, x := range cfg.Exchanges {
+		if normaliseExchange(x.Name) == exch && x.Enabled {
+			return x.Name, true
+		}
+	}
+	return "", false
+}
+
+// AddEvent adds a one-shot event to the Events chain and returns an
+// index/eventID and an error
 func AddEvent(Exchange, Item, Condition string, CurrencyPair pair.CurrencyPair, Asset, Action string) (int, error) {
-	err := IsValidEvent(Exchange, Item, Condition, Action)
+	return Add(Event{
+		Exchange:  Exchange,
+		Item:      Item,
+		Condition: Condition,
+		Pair:      CurrencyPair,
+		Asset:     Asset,
+		Action:    Action,
+	})
+}
+
+// Add validates an event, assigns its ID and adds it to the Events chain
+func Add(e Event) (int, error) {
+	err := IsValidEvent(e.Exchange, e.Item, e.Condition, e.Action)
 	if err != nil {
 		return 0, err
 	}
 
-	Event := &Event{}
+	if isLegacyCondition(e.Condition) {
+		e.Exchange, _ = exchangeName(e.Exchange)
+	}
 
-	if len(Events) == 0 {
-		Event.ID = 0
+	err = e.normalise()
+	if err != nil {
+		return 0, err
+	}
+
+	mtx.Lock()
+	e.ID = nextID()
+	e.Executed = false
+	e.LastTriggered = time.Time{}
+	Events = append(Events, &e)
+	mtx.Unlock()
+
+	persist()
+	return e.ID, nil
+}
+
+// normalise upper cases the item and action, defaults the asset type,
+// cooldown and severity, validates the percent change window and compiles the
+// condition
+func (e *Event) normalise() error {
+	e.Action = common.StringToUpper(e.Action)
+	if e.Action == actionNotify && (e.Medium == "" || e.Recipient == "") {
+		return errInvalidRecipient
+	}
+
+	e.Severity = common.StringToLower(e.Severity)
+	switch e.Severity {
+	case "":
+		e.Severity = base.SeverityWarning
+	case base.SeverityInfo, base.SeverityWarning, base.SeverityCritical:
+	default:
+		return errInvalidSeverity
+	}
+
+	if !isLegacyCondition(e.Condition) {
+		expr, err := ParseExpression(e.Condition)
+		if err != nil {
+			return err
+		}
+
+		e.expr = expr
+		e.Exchange, e.Item, e.Asset, e.Pair, e.Window = "", "", "", pair.CurrencyPair{}, 0
+		if f, ok := expr.singleFeed(); ok {
+			// keep the exchange and pair of single feed expressions so their
+			// notifications can be routed and charted
+			e.Exchange, e.Asset = f.Exchange, f.Asset
+			e.Pair = pair.NewCurrencyPair(f.Pair.FirstCurrency.Upper().String(),
+				f.Pair.SecondCurrency.Upper().String())
+			if name, ok := exchangeName(f.Exchange); ok {
+				e.Exchange = name
+			}
+		}
 	} else {
-		Event.ID = len(Events) + 1
+		e.Item = common.StringToUpper(e.Item)
+		if e.Asset == "" {
+			e.Asset = ticker.Spot
+		}
+
+		if e.Item == itemPctChange {
+			if e.Window < time.Second || e.Window > MaxWindow {
+				return errInvalidWindow
+			}
+		} else {
+			e.Window = 0
+		}
+		e.expr = legacyExpression(e)
 	}
 
-	Event.Exchange = Exchange
-	Event.Item = Item
-	Event.Condition = Condition
-	Event.Pair = CurrencyPair
-	Event.Asset = Asset
-	Event.Action = Action
-	Event.Executed = false
-	Events = append(Events, Event)
-	return Event.ID, nil
+	if !e.Repeat {
+		e.Cooldown = 0
+	} else if e.Cooldown <= 0 {
+		e.Cooldown = DefaultCooldown
+	}
+	return nil
+}
+
+// nextID returns the next free event ID. The caller must hold the lock
+func nextID() int {
+	var id int
+	for _, x := range Events {
+		if x.ID >= id {
+			id = x.ID + 1
+		}
+	}
+	return id
 }
 
 // RemoveEvent deletes and event by its ID
 func RemoveEvent(EventID int) bool {
+	mtx.Lock()
+	var removed bool
 	for i, x := range Events {
 		if x.ID == EventID {
 			Events = append(Events[:i], Events[i+1:]...)
-			return true
+			removed = true
+			break
 		}
 	}
-	return false
+	mtx.Unlock()
+
+	if removed {
+		persist()
+	}
+	return removed
+}
+
+// GetEvents returns a copy of the events
+func GetEvents() []Event {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	result := make([]Event, len(Events))
+	for i, x := range Events {
+		result[i] = *x
+	}
+	return result
+}
+
+// GetEvent returns a copy of an event by its ID
+func GetEvent(EventID int) (Event, bool) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	for _, x := range Events {
+		if x.ID == EventID {
+			return *x, true
+		}
+	}
+	return Event{}, false
+}
+
+// GetEventsByOwner returns a copy of the events owned by a chat user
+func GetEventsByOwner(owner string) []Event {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	var result []Event
+	for _, x := range Events {
+		if x.Owner == owner {
+			result = append(result, *x)
+		}
+	}
+	return result
 }
 
 // GetEventCounter displays the emount of total events on the chain and the
 // events that have been executed.
 func GetEventCounter() (int, int) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
 	total := len(Events)
 	executed := 0
 
@@ -110,93 +346,321 @@ func GetEventCounter() (int, int) {
 	return total, executed
 }
 
-// ExecuteAction will execute the action pending on the chain
-func (e *Event) ExecuteAction() bool {
-	if common.StringContains(e.Action, ",") {
+// GetEventConfigs returns the events in their config representation
+func GetEventConfigs() []config.EventConfig {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	cfgs := make([]config.EventConfig, len(Events))
+	for i, x := range Events {
+		cfgs[i] = config.EventConfig{
+			ID:        x.ID,
+			Exchange:  x.Exchange,
+			Pair:      x.Pair,
+			Asset:     x.Asset,
+			Item:      x.Item,
+			Condition: x.Condition,
+			Window:    x.Window,
+			Action:    x.Action,
+			Repeat:    x.Repeat,
+			Cooldown:  x.Cooldown,
+			Executed:  x.Executed,
+			Owner:     x.Owner,
+			Medium:    x.Medium,
+			Recipient: x.Recipient,
+			Severity:  x.Severity,
+		}
+
+		if !x.LastTriggered.IsZero() {
+			cfgs[i].LastTriggered = x.LastTriggered.Unix()
+		}
+	}
+	return cfgs
+}
+
+// LoadEvents replaces the Events chain with the persisted events. Events with
+// an invalid item, condition, action or window are skipped, events of
+// disabled exchanges are kept but will not trigger
+func LoadEvents(cfgs []config.EventConfig) {
+	var loaded []*Event
+	for i := range cfgs {
+		e := &Event{
+			ID:        cfgs[i].ID,
+			Exchange:  cfgs[i].Exchange,
+			Pair:      cfgs[i].Pair,
+			Asset:     cfgs[i].Asset,
+			Item:      cfgs[i].Item,
+			Condition: cfgs[i].Condition,
+			Window:    cfgs[i].Window,
+			Action:    cfgs[i].Action,
+			Repeat:    cfgs[i].Repeat,
+			Cooldown:  cfgs[i].Cooldown,
+			Executed:  cfgs[i].Executed,
+			Owner:     cfgs[i].Owner,
+			Medium:    cfgs[i].Medium,
+			Recipient: cfgs[i].Recipient,
+			Severity:  cfgs[i].Severity,
+		}
+
+		if cfgs[i].LastTriggered > 0 {
+			e.LastTriggered = time.Unix(cfgs[i].LastTriggered, 0)
+		}
+
+		err := isValidEventAction(e.Action)
+		if err == nil && isLegacyCondition(e.Condition) {
+			err = isValidEventOptions(e.Item, e.Condition, e.Action)
+		}
+
+		if err == nil {
+			err = e.normalise()
+		}
+
+		if err != nil {
+			log.Printf("Events: skipping invalid event %d. Err: %s", e.ID, err)
+			continue
+		}
+		loaded = append(loaded, e)
+	}
+
+	mtx.Lock()
+	Events = loaded
+	tickers.latest = make(map[string]ticker.Price)
+	tickers.history = make(map[string][]ticker.Price)
+	mtx.Unlock()
+}
+
+// ExecuteAction will execute the action of an event triggered by the supplied
+// value
+func (e *Event) ExecuteAction(value float64) bool {
+	message := fmt.Sprintf("Event triggered: %s Value: %v", e.String(), value)
+	notification := base.Event{
+		Type:         eventTypeTrigger,
+		TradeDetails: message,
+		Severity:     e.Severity,
+		Exchange:     e.Exchange,
+		Pair:         e.Pair.Pair().String(),
+	}
+
+	switch {
+	case e.Action == actionNotify:
+		if comms != nil {
+			notification.Type = eventTypeAlert
+			err := comms.PushEventTo(e.Medium, e.Recipient, notification)
+			if err != nil {
+				log.Printf("Events: failed to notify %s recipient %s of event %d. Err: %s",
+					e.Medium, e.Recipient, e.ID, err)
+			}
+		}
+	case common.StringContains(e.Action, ","):
 		action := common.SplitStrings(e.Action, ",")
-		if action[0] == actionSMSNotify {
-			message := fmt.Sprintf("Event triggered: %s", e.String())
+		if action[0] == actionSMSNotify && comms != nil {
 			if action[1] == "ALL" {
-				comms.PushEvent(base.Event{TradeDetails: message})
+				comms.PushEvent(notification)
 			}
 		}
-	} else {
-		log.Printf("Event triggered: %s", e.String())
+	default:
+		log.Println(message)
+	}
+
+	if webhookDispatcher != nil {
+		err := webhookDispatcher.Publish(config.WebhookEventTrigger, TriggerPayload{
+			ID:        e.ID,
+			Exchange:  e.Exchange,
+			Pair:      e.Pair.Pair().String(),
+			Asset:     e.Asset,
+			Item:      e.Item,
+			Condition: e.Condition,
+			Action:    e.Action,
+			Value:     value,
+			Message:   message,
+		})
+		if err != nil {
+			log.Printf("Events: failed to queue webhook for event %d. Err: %s", e.ID, err)
+		}
 	}
 	return true
 }
 
 // EventToString turns the structure event into a string
 func (e *Event) String() string {
+	if !isLegacyCondition(e.Condition) {
+		return fmt.Sprintf("If %s then %s.", e.Condition, e.Action)
+	}
+
 	condition := common.SplitStrings(e.Condition, ",")
+	item := common.StringToLower(e.Item)
+	if e.Item == itemPctChange {
+		item = fmt.Sprintf("%s over %v", item, e.Window)
+	}
+
 	return fmt.Sprintf(
 		"If the %s%s [%s] %s on %s is %s then %s.", e.Pair.FirstCurrency.String(),
-		e.Pair.SecondCurrency.String(), e.Asset, e.Item, e.Exchange, condition[0]+" "+condition[1], e.Action,
+		e.Pair.SecondCurrency.String(), e.Asset, item, e.Exchange, condition[0]+" "+condition[1], e.Action,
 	)
 }
 
-// CheckCondition will check the event structure to see if there is a condition
-// met
-func (e *Event) CheckCondition() bool {
+// CheckCondition returns whether or not the value meets the operator and value
+// condition
+func (e *Event) CheckCondition(value float64) bool {
 	condition := common.SplitStrings(e.Condition, ",")
-	targetPrice, _ := strconv.ParseFloat(condition[1], 64)
-
-	t, err := ticker.GetTicker(e.Exchange, e.Pair, e.Asset)
+	targetPrice, err := strconv.ParseFloat(condition[1], 64)
 	if err != nil {
 		return false
 	}
 
-	lastPrice := t.Last
+	switch condition[0] {
+	case greaterThan:
+		return value > targetPrice
+	case greaterThanOrEqual:
+		return value >= targetPrice
+	case lessThan:
+		return value < targetPrice
+	case lessThanOrEqual:
+		return value <= targetPrice
+	case isEqual:
+		return value == targetPrice
+	}
+	return false
+}
 
-	if lastPrice == 0 {
-		return false
+// isLegacyCondition returns whether or not the condition is an operator and
+// value rather than an expression
+func isLegacyCondition(condition string) bool {
+	parts := strings.Split(condition, ",")
+	return len(parts) == 2 && IsValidCondition(parts[0])
+}
+
+// legacyExpression returns the expression comparing the item of the event
+// exchange ticker against the condition value
+func legacyExpression(e *Event) *Expression {
+	condition := common.SplitStrings(e.Condition, ",")
+	target, _ := strconv.ParseFloat(condition[1], 64)
+
+	ref := &refNode{
+		Feed: feed{Exchange: e.Exchange, Asset: e.Asset, Pair: e.Pair},
+		Item: e.Item,
 	}
 
-	switch condition[0] {
-	case greaterThan:
-		{
-			if lastPrice > targetPrice {
-				return e.ExecuteAction()
+	var x node = ref
+	switch e.Item {
+	case itemPrice:
+		ref.Item = itemLast
+	case itemPctChange:
+		ref.Item = itemLast
+		x = &pctChangeNode{Ref: ref, Window: e.Window}
+	}
+	return newExpression(&binaryNode{Op: condition[0], X: x, Y: numberNode(target)})
+}
+
+// ready returns whether or not the event may trigger at the supplied time
+func (e *Event) ready(now time.Time) bool {
+	if !e.Repeat {
+		return !e.Executed
+	}
+	return e.LastTriggered.IsZero() || now.Sub(e.LastTriggered) >= e.Cooldown
+}
+
+// triggered holds an event copy and the value which triggered it
+type triggered struct {
+	Event Event
+	Value float64
+}
+
+// ProcessTicker stores the ticker and evaluates the events referencing it,
+// executing the actions of those whose condition is met. The ticker last
+// updated time is used as the evaluation time
+func ProcessTicker(exchangeName, assetType string, t ticker.Price) {
+	if t.LastUpdated.IsZero() {
+		t.LastUpdated = time.Now()
+	}
+	now := t.LastUpdated
+	key := feedKey(exchangeName, assetType, t.Pair)
+
+	mtx.Lock()
+	var window time.Duration
+	var watched bool
+	for _, e := range Events {
+		if e.expr.References(key) {
+			watched = true
+			if e.expr.windows[key] > window {
+				window = e.expr.windows[key]
 			}
 		}
-	case greaterThanOrEqual:
-		{
-			if lastPrice >= targetPrice {
-				return e.ExecuteAction()
-			}
+	}
+
+	if !watched {
+		delete(tickers.latest, key)
+		delete(tickers.history, key)
+		mtx.Unlock()
+		return
+	}
+
+	tickers.latest[key] = t
+	if window > 0 {
+		samples := append(tickers.history[key], t)
+		// Keep a single ticker older than the largest window as its baseline
+		for len(samples) > 1 && !samples[1].LastUpdated.After(now.Add(-window)) {
+			samples = samples[1:]
 		}
-	case lessThan:
-		{
-			if lastPrice < targetPrice {
-				return e.ExecuteAction()
-			}
+		tickers.history[key] = samples
+	} else {
+		delete(tickers.history, key)
+	}
+
+	var fired []triggered
+	for _, e := range Events {
+		if !e.expr.References(key) || !e.ready(now) {
+			continue
 		}
-	case lessThanOrEqual:
-		{
-			if lastPrice <= targetPrice {
-				return e.ExecuteAction()
-			}
+
+		value, ok, evaluated := e.expr.evaluate(&tickers)
+		if !evaluated || !ok {
+			continue
 		}
-	case isEqual:
-		{
-			if lastPrice == targetPrice {
-				return e.ExecuteAction()
-			}
+
+		if e.Repeat {
+			e.LastTriggered = now
+		} else {
+			e.Executed = true
 		}
+		fired = append(fired, triggered{Event: *e, Value: value})
+	}
+	mtx.Unlock()
+
+	for x := range fired {
+		log.Printf("Event %d triggered successfully.\n", fired[x].Event.ID)
+		fired[x].Event.ExecuteAction(fired[x].Value)
+	}
+
+	if len(fired) > 0 {
+		persist()
 	}
-	return false
 }
 
 // IsValidEvent checks the actions to be taken and returns an error if incorrect
 func IsValidEvent(Exchange, Item, Condition, Action string) error {
-	Exchange = common.StringToUpper(Exchange)
-	Item = common.StringToUpper(Item)
-	Action = common.StringToUpper(Action)
+	if !isLegacyCondition(Condition) {
+		expr, err := ParseExpression(Condition)
+		if err != nil {
+			return err
+		}
+
+		err = expr.validateExchanges()
+		if err != nil {
+			return err
+		}
+		return isValidEventAction(Action)
+	}
 
 	if !IsValidExchange(Exchange) {
 		return errExchangeDisabled
 	}
+	return isValidEventOptions(Item, Condition, Action)
+}
 
+// isValidEventOptions checks the item, condition and action of an event
+func isValidEventOptions(Item, Condition, Action string) error {
+	Item = common.StringToUpper(Item)
 	if !IsValidItem(Item) {
 		return errInvalidItem
 	}
@@ -206,61 +670,37 @@ func IsValidEvent(Exchange, Item, Condition, Action string) error {
 	}
 
 	condition := common.SplitStrings(Condition, ",")
-
 	if !IsValidCondition(condition[0]) || len(condition[1]) == 0 {
 		return errInvalidCondition
 	}
 
+	if _, err := strconv.ParseFloat(condition[1], 64); err != nil {
+		return errInvalidCondition
+	}
+	return isValidEventAction(Action)
+}
+
+// isValidEventAction checks the action of an event
+func isValidEventAction(Action string) error {
+	Action = common.StringToUpper(Action)
 	if common.StringContains(Action, ",") {
 		action := common.SplitStrings(Action, ",")
 
-		if action[0] != actionSMSNotify {
+		if action[0] != actionSMSNotify || len(action[1]) == 0 {
 			return errInvalidAction
 		}
-
-		if action[1] != "ALL" {
-			comms.PushEvent(base.Event{Type: action[1]})
-		}
 	} else {
-		if Action != actionConsolePrint && Action != actionTest {
+		if Action != actionConsolePrint && Action != actionTest && Action != actionNotify {
 			return errInvalidAction
 		}
 	}
 	return nil
 }
 
-// CheckEvents is the overarching routine that will iterate through the Events
-// chain
-func CheckEvents() {
-	for {
-		total, executed := GetEventCounter()
-		if total > 0 && executed != total {
-			for _, event := range Events {
-				if !event.Executed {
-					success := event.CheckCondition()
-					if success {
-						log.Printf(
-							"Event %d triggered on %s successfully.\n", event.ID,
-							event.Exchange,
-						)
-						event.Executed = true
-					}
-				}
-			}
-		}
-	}
-}
-
 // IsValidExchange validates the exchange
 func IsValidExchange(Exchange string) bool {
-	Exchange = common.StringToUpper(Exchange)
-	cfg := config.GetConfig()
-	for _, x := range cfg.Exchanges {
-		if x.Name == Exchange && x.Enabled {
-			return true
-		}
-	}
-	return false
+	_, ok := exchangeName(Exchange)
+	return ok
 }
 
 // IsValidCondition validates passed in condition
@@ -276,7 +716,7 @@ func IsValidCondition(Condition string) bool {
 func IsValidAction(Action string) bool {
 	Action = common.StringToUpper(Action)
 	switch Action {
-	case actionSMSNotify, actionConsolePrint, actionTest:
+	case actionSMSNotify, actionConsolePrint, actionTest, actionNotify:
 		return true
 	}
 	return false
@@ -286,7 +726,8 @@ func IsValidAction(Action string) bool {
 func IsValidItem(Item string) bool {
 	Item = common.StringToUpper(Item)
 	switch Item {
-	case itemPrice:
+	case itemPrice, itemLast, itemBid, itemAsk, itemHigh, itemLow, itemVolume,
+		itemSpread, itemPctChange:
 		return true
 	}
 	return false
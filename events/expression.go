@@ -0,0 +1,756 @@
+package events
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Expressions are conditions over the tickers of one or more exchanges, for
+// example "bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50". The grammar, lowest
+// precedence first, is:
+//
+//	or         = and { "||" and }
+//	and        = comparison { "&&" comparison }
+//	comparison = sum [ ( ">" | ">=" | "<" | "<=" | "==" | "!=" ) sum ]
+//	sum        = product { ( "+" | "-" ) product }
+//	product    = unary { ( "*" | "/" ) unary }
+//	unary      = "-" unary | primary
+//	primary    = number | feed | function "(" arguments ")" | "(" or ")"
+//	feed       = exchange ":" pair [ ":" asset ] "." item
+//
+// Exchange names are matched case insensitively ignoring spaces and
+// underscores, pairs without a delimiter are split after the third character
+// and the asset type defaults to SPOT. The functions are pct_change(feed,
+// window), abs(x), min(x, y) and max(x, y).
+
+// Token kinds
+const (
+	tokenEOF = iota
+	tokenNumber
+	tokenDuration
+	tokenIdent
+	tokenOperator
+)
+
+const (
+	funcPctChange = "pct_change"
+	funcAbs       = "abs"
+	funcMin       = "min"
+	funcMax       = "max"
+)
+
+// ExpressionError is a parse error at a position of the expression
+type ExpressionError struct {
+	Pos     int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("invalid expression at position %d: %s", e.Pos, e.Message)
+}
+
+type token struct {
+	Kind int
+	Text string
+	Pos  int
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// lex splits an expression into tokens
+func lex(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case isDigit(c) || c == '.' && i+1 < len(s) && isDigit(s[i+1]):
+			for i < len(s) && (isDigit(s[i]) || s[i] == '.') {
+				i++
+			}
+
+			if i < len(s) && isLetter(s[i]) {
+				for i < len(s) && (isLetter(s[i]) || isDigit(s[i]) || s[i] == '.') {
+					i++
+				}
+				tokens = append(tokens, token{tokenDuration, s[start:i], start})
+				continue
+			}
+			tokens = append(tokens, token{tokenNumber, s[start:i], start})
+		case isLetter(c):
+			for i < len(s) && (isLetter(s[i]) || isDigit(s[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, s[start:i], start})
+		default:
+			if i+1 < len(s) {
+				switch op := s[i : i+2]; op {
+				case ">=", "<=", "==", "!=", "&&", "||":
+					tokens = append(tokens, token{tokenOperator, op, start})
+					i += 2
+					continue
+				}
+			}
+
+			if !strings.ContainsRune("+-*/()<>,:.", rune(c)) {
+				return nil, &ExpressionError{start, fmt.Sprintf("unexpected character %q", c)}
+			}
+			tokens = append(tokens, token{tokenOperator, string(c), start})
+			i++
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(s)}), nil
+}
+
+// feed identifies an exchange ticker
+type feed struct {
+	Exchange string
+	Asset    string
+	Pair     pair.CurrencyPair
+}
+
+// normaliseExchange returns the exchange name upper cased without spaces and
+// underscores
+func normaliseExchange(name string) string {
+	return strings.NewReplacer(" ", "", "_", "").Replace(common.StringToUpper(name))
+}
+
+// feedKey returns the key of an exchange ticker
+func feedKey(exchangeName, assetType string, p pair.CurrencyPair) string {
+	return normaliseExchange(exchangeName) + ":" + common.StringToUpper(assetType) + ":" +
+		p.FirstCurrency.Upper().String() + p.SecondCurrency.Upper().String()
+}
+
+func (f *feed) key() string {
+	return feedKey(f.Exchange, f.Asset, f.Pair)
+}
+
+// evalContext holds the latest ticker and the ticker history of each feed
+type evalContext struct {
+	latest  map[string]ticker.Price
+	history map[string][]ticker.Price
+}
+
+type node interface {
+	eval(ctx *evalContext) (float64, bool)
+}
+
+type numberNode float64
+
+type refNode struct {
+	Feed feed
+	Item string
+}
+
+type pctChangeNode struct {
+	Ref    *refNode
+	Window time.Duration
+}
+
+type unaryNode struct {
+	X node
+}
+
+type binaryNode struct {
+	Op   string
+	X, Y node
+}
+
+type callNode struct {
+	Name string
+	Args []node
+}
+
+func (n numberNode) eval(ctx *evalContext) (float64, bool) {
+	return float64(n), true
+}
+
+// itemValue returns the value of an item of a ticker
+func itemValue(t ticker.Price, item string) (float64, bool) {
+	switch item {
+	case itemPrice, itemLast:
+		return t.Last, t.Last != 0
+	case itemBid:
+		return t.Bid, t.Bid != 0
+	case itemAsk:
+		return t.Ask, t.Ask != 0
+	case itemHigh:
+		return t.High, t.High != 0
+	case itemLow:
+		return t.Low, t.Low != 0
+	case itemVolume:
+		return t.Volume, true
+	case itemSpread:
+		return t.Ask - t.Bid, t.Bid != 0 && t.Ask != 0
+	}
+	return 0, false
+}
+
+func (n *refNode) eval(ctx *evalContext) (float64, bool) {
+	t, ok := ctx.latest[n.Feed.key()]
+	if !ok {
+		return 0, false
+	}
+	return itemValue(t, n.Item)
+}
+
+// eval returns the percent change of the item since the last ticker at least
+// as old as the window
+func (n *pctChangeNode) eval(ctx *evalContext) (float64, bool) {
+	key := n.Ref.Feed.key()
+	t, ok := ctx.latest[key]
+	if !ok {
+		return 0, false
+	}
+
+	current, ok := itemValue(t, n.Ref.Item)
+	if !ok {
+		return 0, false
+	}
+
+	var base float64
+	for _, x := range ctx.history[key] {
+		if x.LastUpdated.After(t.LastUpdated.Add(-n.Window)) {
+			break
+		}
+		base, _ = itemValue(x, n.Ref.Item)
+	}
+
+	if base == 0 {
+		return 0, false
+	}
+	return (current - base) / base * 100, true
+}
+
+func (n *unaryNode) eval(ctx *evalContext) (float64, bool) {
+	x, ok := n.X.eval(ctx)
+	return -x, ok
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (n *binaryNode) eval(ctx *evalContext) (float64, bool) {
+	x, ok := n.X.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	y, ok := n.Y.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	switch n.Op {
+	case "+":
+		return x + y, true
+	case "-":
+		return x - y, true
+	case "*":
+		return x * y, true
+	case "/":
+		if y == 0 {
+			return 0, false
+		}
+		return x / y, true
+	case greaterThan:
+		return boolValue(x > y), true
+	case greaterThanOrEqual:
+		return boolValue(x >= y), true
+	case lessThan:
+		return boolValue(x < y), true
+	case lessThanOrEqual:
+		return boolValue(x <= y), true
+	case isEqual:
+		return boolValue(x == y), true
+	case "!=":
+		return boolValue(x != y), true
+	case "&&":
+		return boolValue(x != 0 && y != 0), true
+	case "||":
+		return boolValue(x != 0 || y != 0), true
+	}
+	return 0, false
+}
+
+func (n *callNode) eval(ctx *evalContext) (float64, bool) {
+	args := make([]float64, len(n.Args))
+	for x := range n.Args {
+		var ok bool
+		args[x], ok = n.Args[x].eval(ctx)
+		if !ok {
+			return 0, false
+		}
+	}
+
+	switch n.Name {
+	case funcAbs:
+		if args[0] < 0 {
+			return -args[0], true
+		}
+		return args[0], true
+	case funcMin:
+		if args[1] < args[0] {
+			return args[1], true
+		}
+		return args[0], true
+	case funcMax:
+		if args[1] > args[0] {
+			return args[1], true
+		}
+		return args[0], true
+	}
+	return 0, false
+}
+
+// isComparison returns whether or not the operator is a comparison
+func isComparison(op string) bool {
+	switch op {
+	case greaterThan, greaterThanOrEqual, lessThan, lessThanOrEqual, isEqual, "!=":
+		return true
+	}
+	return false
+}
+
+// isCondition returns whether or not the node is a comparison or a logical
+// operation
+func isCondition(n node) bool {
+	b, ok := n.(*binaryNode)
+	return ok && (isComparison(b.Op) || b.Op == "&&" || b.Op == "||")
+}
+
+// Expression is a parsed event condition
+type Expression struct {
+	root node
+	// feeds holds the referenced feeds by key, windows the largest
+	// pct_change window of each feed
+	feeds   map[string]feed
+	windows map[string]time.Duration
+}
+
+// newExpression returns an expression collecting the feeds of its root node
+func newExpression(root node) *Expression {
+	e := &Expression{
+		root:    root,
+		feeds:   make(map[string]feed),
+		windows: make(map[string]time.Duration),
+	}
+	e.collect(root)
+	return e
+}
+
+func (e *Expression) collect(n node) {
+	switch n := n.(type) {
+	case *refNode:
+		e.feeds[n.Feed.key()] = n.Feed
+	case *pctChangeNode:
+		e.collect(n.Ref)
+		if n.Window > e.windows[n.Ref.Feed.key()] {
+			e.windows[n.Ref.Feed.key()] = n.Window
+		}
+	case *unaryNode:
+		e.collect(n.X)
+	case *binaryNode:
+		e.collect(n.X)
+		e.collect(n.Y)
+	case *callNode:
+		for x := range n.Args {
+			e.collect(n.Args[x])
+		}
+	}
+}
+
+// References returns whether or not the expression references the feed key
+func (e *Expression) References(key string) bool {
+	_, ok := e.feeds[key]
+	return ok
+}
+
+// singleFeed returns the feed of an expression referencing exactly one feed
+func (e *Expression) singleFeed() (feed, bool) {
+	if len(e.feeds) != 1 {
+		return feed{}, false
+	}
+
+	for _, f := range e.feeds {
+		return f, true
+	}
+	return feed{}, false
+}
+
+// validateExchanges checks that the exchanges of all feeds are enabled
+func (e *Expression) validateExchanges() error {
+	for _, f := range e.feeds {
+		if !IsValidExchange(f.Exchange) {
+			return fmt.Errorf("exchange %s is not enabled", f.Exchange)
+		}
+	}
+	return nil
+}
+
+// evaluate returns whether or not the condition holds and the value of its
+// left operand if it is a comparison. ok is false if a referenced ticker or
+// its history is unavailable
+func (e *Expression) evaluate(ctx *evalContext) (value float64, triggered, ok bool) {
+	result, ok := e.root.eval(ctx)
+	if !ok {
+		return 0, false, false
+	}
+
+	value = result
+	if b, isBinary := e.root.(*binaryNode); isBinary && isComparison(b.Op) {
+		value, _ = b.X.eval(ctx)
+	}
+	return value, result != 0, true
+}
+
+// ParseExpression parses an expression condition
+func ParseExpression(s string) (*Expression, error) {
+	tokens, err := lex(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.Kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+
+	if !isCondition(root) {
+		return nil, &ExpressionError{0, "expression must be a comparison"}
+	}
+	return newExpression(root), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.Kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of the operators
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.Kind != tokenOperator {
+		return "", false
+	}
+
+	for _, op := range ops {
+		if t.Text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		return &ExpressionError{t.Pos, fmt.Sprintf("expected %q", op)}
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.Kind == tokenEOF {
+		return &ExpressionError{t.Pos, "unexpected end of expression"}
+	}
+	return &ExpressionError{t.Pos, fmt.Sprintf("unexpected %q", t.Text)}
+}
+
+// operand returns an error if the node is a condition used as a value
+func operand(n node, pos int) error {
+	if isCondition(n) {
+		return &ExpressionError{pos, "a comparison cannot be used as a value"}
+	}
+	return nil
+}
+
+func (p *parser) parseLogical(op string, parse func() (node, error)) (node, error) {
+	pos := p.peek().Pos
+	x, err := parse()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.accept(op); !ok {
+			return x, nil
+		}
+
+		ypos := p.peek().Pos
+		y, err := parse()
+		if err != nil {
+			return nil, err
+		}
+
+		if !isCondition(x) {
+			return nil, &ExpressionError{pos, fmt.Sprintf("operands of %s must be comparisons", op)}
+		}
+
+		if !isCondition(y) {
+			return nil, &ExpressionError{ypos, fmt.Sprintf("operands of %s must be comparisons", op)}
+		}
+		x = &binaryNode{Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseLogical("||", p.parseAnd)
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseLogical("&&", p.parseComparison)
+}
+
+func (p *parser) parseComparison() (node, error) {
+	pos := p.peek().Pos
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := p.accept(greaterThan, greaterThanOrEqual, lessThan, lessThanOrEqual, isEqual, "!=")
+	if !ok {
+		return x, nil
+	}
+
+	ypos := p.peek().Pos
+	y, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = operand(x, pos); err != nil {
+		return nil, err
+	}
+
+	if err = operand(y, ypos); err != nil {
+		return nil, err
+	}
+	return &binaryNode{Op: op, X: x, Y: y}, nil
+}
+
+func (p *parser) parseArithmetic(ops []string, parse func() (node, error)) (node, error) {
+	pos := p.peek().Pos
+	x, err := parse()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return x, nil
+		}
+
+		ypos := p.peek().Pos
+		y, err := parse()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = operand(x, pos); err != nil {
+			return nil, err
+		}
+
+		if err = operand(y, ypos); err != nil {
+			return nil, err
+		}
+		x = &binaryNode{Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseSum() (node, error) {
+	return p.parseArithmetic([]string{"+", "-"}, p.parseProduct)
+}
+
+func (p *parser) parseProduct() (node, error) {
+	return p.parseArithmetic([]string{"*", "/"}, p.parseUnary)
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if _, ok := p.accept("-"); ok {
+		pos := p.peek().Pos
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = operand(x, pos); err != nil {
+			return nil, err
+		}
+		return &unaryNode{X: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.Kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.Text, 64)
+		if err != nil {
+			return nil, &ExpressionError{t.Pos, fmt.Sprintf("invalid number %q", t.Text)}
+		}
+		return numberNode(value), nil
+	case tokenIdent:
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(t)
+		}
+
+		if p.peek().Text == ":" {
+			return p.parseFeed(t)
+		}
+		return nil, &ExpressionError{t.Pos, fmt.Sprintf("unknown identifier %q", t.Text)}
+	case tokenOperator:
+		if t.Text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expect(")")
+		}
+	}
+	return nil, p.unexpected(t)
+}
+
+// parseFeed parses a feed reference following its exchange name
+func (p *parser) parseFeed(exch token) (*refNode, error) {
+	p.next()
+	t := p.next()
+	if t.Kind != tokenIdent {
+		return nil, &ExpressionError{t.Pos, "expected a currency pair"}
+	}
+
+	currency := common.StringToUpper(t.Text)
+	if !strings.Contains(currency, "_") && len(currency) < 6 ||
+		strings.Count(currency, "_") > 1 {
+		return nil, &ExpressionError{t.Pos, fmt.Sprintf("invalid currency pair %q", t.Text)}
+	}
+
+	p2 := pair.NewCurrencyPairFromString(currency)
+	if p2.FirstCurrency == "" || p2.SecondCurrency == "" {
+		return nil, &ExpressionError{t.Pos, fmt.Sprintf("invalid currency pair %q", t.Text)}
+	}
+
+	ref := &refNode{Feed: feed{Exchange: exch.Text, Asset: ticker.Spot, Pair: p2}}
+	if _, ok := p.accept(":"); ok {
+		t = p.next()
+		if t.Kind != tokenIdent {
+			return nil, &ExpressionError{t.Pos, "expected an asset type"}
+		}
+		ref.Feed.Asset = common.StringToUpper(t.Text)
+	}
+
+	if err := p.expect("."); err != nil {
+		return nil, err
+	}
+
+	t = p.next()
+	ref.Item = common.StringToUpper(t.Text)
+	if t.Kind != tokenIdent || ref.Item == itemPctChange || !IsValidItem(ref.Item) {
+		return nil, &ExpressionError{t.Pos, fmt.Sprintf("unknown item %q", t.Text)}
+	}
+
+	if ref.Item == itemPrice {
+		ref.Item = itemLast
+	}
+	return ref, nil
+}
+
+// parseCall parses the arguments of a function call
+func (p *parser) parseCall(name token) (node, error) {
+	switch common.StringToLower(name.Text) {
+	case funcPctChange:
+		t := p.next()
+		if t.Kind != tokenIdent || p.peek().Text != ":" {
+			return nil, &ExpressionError{t.Pos, "pct_change expects a feed"}
+		}
+
+		ref, err := p.parseFeed(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+
+		t = p.next()
+		window, err := time.ParseDuration(t.Text)
+		if t.Kind != tokenDuration || err != nil {
+			return nil, &ExpressionError{t.Pos, "pct_change expects a window such as 5m"}
+		}
+
+		if window < time.Second || window > MaxWindow {
+			return nil, &ExpressionError{t.Pos, errInvalidWindow.Error()}
+		}
+		return &pctChangeNode{Ref: ref, Window: window}, p.expect(")")
+	case funcAbs, funcMin, funcMax:
+		call := &callNode{Name: common.StringToLower(name.Text)}
+		for {
+			pos := p.peek().Pos
+			x, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+
+			if err = operand(x, pos); err != nil {
+				return nil, err
+			}
+			call.Args = append(call.Args, x)
+
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+
+		expected := 2
+		if call.Name == funcAbs {
+			expected = 1
+		}
+
+		if len(call.Args) != expected {
+			return nil, &ExpressionError{name.Pos,
+				fmt.Sprintf("%s expects %d argument/s", call.Name, expected)}
+		}
+		return call, p.expect(")")
+	}
+	return nil, &ExpressionError{name.Pos, fmt.Sprintf("unknown function %q", name.Text)}
+}
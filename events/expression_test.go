@@ -0,0 +1,151 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func TestParseExpression(t *testing.T) {
+	valid := []string{
+		"bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50",
+		"pct_change(gdax:BTCUSD.last, 5m) < -3",
+		"abs(bitfinex:BTCUSD.last / kraken:XBT_USD:spot.last - 1) * 100 >= 0.5",
+		"(bitfinex:BTCUSD.spread > 10 || min(bitfinex:BTCUSD.bid, 1) == 1) && btc_markets:BTCAUD.volume != 0",
+	}
+
+	for _, x := range valid {
+		if _, err := ParseExpression(x); err != nil {
+			t.Errorf("test failed - ParseExpression %q error %s", x, err)
+		}
+	}
+
+	invalid := []struct {
+		Expression string
+		Pos        int
+		Message    string
+	}{
+		{"bitfinex:BTCUSD.last", 0, "must be a comparison"},
+		{"bitfinex:BTCUSD.foo > 1", 16, "unknown item"},
+		{"bitfinex:BTC.last > 1", 9, "invalid currency pair"},
+		{"bitfinex:BTCUSD.last > ", 23, "unexpected end"},
+		{"bitfinex:BTCUSD.last > 1 + (2 > 1)", 27, "cannot be used as a value"},
+		{"bitfinex:BTCUSD.last && 1 > 0", 0, "must be comparisons"},
+		{"pct_change(bitfinex:BTCUSD.last, 5) < 1", 33, "expects a window"},
+		{"pct_change(bitfinex:BTCUSD.last, 48h) < 1", 33, "window between"},
+		{"sqrt(4) > 1", 0, "unknown function"},
+		{"max(1) > 1", 0, "expects 2 argument"},
+		{"price > 1", 0, "unknown identifier"},
+		{"bitfinex:BTCUSD.last > 1 $", 25, "unexpected character"},
+		{"(bitfinex:BTCUSD.last > 1", 25, "expected \")\""},
+	}
+
+	for _, x := range invalid {
+		_, err := ParseExpression(x.Expression)
+		exprErr, ok := err.(*ExpressionError)
+		if !ok || exprErr.Pos != x.Pos || !strings.Contains(exprErr.Message, x.Message) {
+			t.Errorf("test failed - ParseExpression %q expected %q at %d, got %v",
+				x.Expression, x.Message, x.Pos, err)
+		}
+	}
+}
+
+func TestIsValidEventExpression(t *testing.T) {
+	testSetup(t)
+
+	err := IsValidEvent("", "", "bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50", actionTest)
+	if err != nil {
+		t.Errorf("test failed - IsValidEvent error %s", err)
+	}
+
+	err = IsValidEvent("", "", "bitfinex:BTCUSD.bid >", actionTest)
+	if _, ok := err.(*ExpressionError); !ok {
+		t.Errorf("test failed - IsValidEvent should return the parse error, got %v", err)
+	}
+
+	err = IsValidEvent("", "", "invalid:BTCUSD.bid > 1", actionTest)
+	if err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("test failed - IsValidEvent should reject unknown exchanges, got %v", err)
+	}
+
+	err = IsValidEvent("", "", "bitfinex:BTCUSD.bid > 1", "console_prints")
+	if err != errInvalidAction {
+		t.Errorf("test failed - IsValidEvent should validate the action, got %v", err)
+	}
+}
+
+func TestProcessTickerExpression(t *testing.T) {
+	testSetup(t)
+
+	id, err := Add(Event{
+		Condition: "bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50",
+		Action:    actionTest,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, _ := GetEvent(id); e.Exchange != "" || e.Pair.Pair().String() != "" {
+		t.Errorf("test failed - multiple feed event should not have an exchange or pair %v", e)
+	}
+
+	now := time.Now()
+	bitfinex := testPrice(6600, now)
+	ProcessTicker("Bitfinex", ticker.Spot, bitfinex)
+	if e, _ := GetEvent(id); e.Executed {
+		t.Fatal("test failed - event executed without the kraken ticker")
+	}
+
+	kraken := testPrice(6560, now)
+	kraken.Pair = pair.NewCurrencyPair("XBT", "USD")
+	ProcessTicker("Kraken", ticker.Spot, kraken)
+	if e, _ := GetEvent(id); e.Executed {
+		t.Fatal("test failed - event executed with a 38 bid ask difference")
+	}
+
+	kraken.Ask = 6548
+	kraken.LastUpdated = now.Add(time.Second)
+	ProcessTicker("Kraken", ticker.Spot, kraken)
+	if e, _ := GetEvent(id); !e.Executed {
+		t.Error("test failed - event not executed with a 51 bid ask difference")
+	}
+}
+
+func TestProcessTickerExpressionPctChange(t *testing.T) {
+	testSetup(t)
+
+	id, err := Add(Event{
+		Condition: "pct_change(gdax:BTCUSD.last, 5m) < -3",
+		Action:    actionTest,
+		Repeat:    true,
+		Cooldown:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, _ := GetEvent(id)
+	if e.Exchange != "GDAX" || e.Asset != ticker.Spot || e.Pair.Pair().String() != "BTCUSD" {
+		t.Errorf("test failed - single feed event should keep its exchange and pair %v", e)
+	}
+
+	start := time.Now()
+	prices := []float64{1000, 990, 985, 960}
+	for x := range prices {
+		ProcessTicker("GDAX", ticker.Spot,
+			testPrice(prices[x], start.Add(time.Duration(x)*time.Minute*3)))
+	}
+
+	e, _ = GetEvent(id)
+	// 960 at 9m is -3.04% of 990 at 3m
+	if !e.LastTriggered.Equal(start.Add(time.Minute * 9)) {
+		t.Errorf("test failed - pct_change expression not triggered %v", e)
+	}
+
+	if e.String() != "If pct_change(gdax:BTCUSD.last, 5m) < -3 then ACTION_TEST." {
+		t.Errorf("test failed - unexpected event string %s", e.String())
+	}
+}
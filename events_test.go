@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+)
+
+func TestRESTv2Events(t *testing.T) {
+	err := config.GetConfig().LoadConfig("testdata/configtest.json")
+	if err != nil {
+		t.Fatalf("Test failed. LoadConfig: %s", err)
+	}
+	events.LoadEvents(nil)
+	defer events.LoadEvents(nil)
+
+	w := httptest.NewRecorder()
+	RESTv2CreateEvent(w, httptest.NewRequest("POST", "/v2/events", strings.NewReader(
+		`{"exchange":"bitfinex","pair":"BTC-USD","item":"pct_change","condition":"<=,-5",`+
+			`"window":"1h","action":"CONSOLE_PRINT","repeat":true}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Test failed. RESTv2CreateEvent returned %d %s", w.Code, w.Body.String())
+	}
+
+	var created RESTv2Event
+	err = json.NewDecoder(w.Body).Decode(&created)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Exchange != "Bitfinex" || created.Pair != "BTC-USD" ||
+		created.Window != "1h0m0s" || created.Cooldown != events.DefaultCooldown.String() {
+		t.Errorf("Test failed. RESTv2CreateEvent unexpected result %v", created)
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2CreateEvent(w, httptest.NewRequest("POST", "/v2/events", strings.NewReader(
+		`{"exchange":"bitfinex","pair":"BTCUSD","item":"pct_change","condition":"<=,-5",`+
+			`"action":"CONSOLE_PRINT"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Test failed. RESTv2CreateEvent should reject a missing window, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2CreateEvent(w, httptest.NewRequest("POST", "/v2/events", strings.NewReader(
+		`{"condition":"bitfinex:BTCUSD.bid - kraken:XBTUSD.ask >","action":"CONSOLE_PRINT"}`)))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "position 41") {
+		t.Errorf("Test failed. RESTv2CreateEvent should return the expression error, got %s",
+			w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2CreateEvent(w, httptest.NewRequest("POST", "/v2/events", strings.NewReader(
+		`{"condition":"bitfinex:BTCUSD.bid - kraken:XBTUSD.ask > 50","action":"CONSOLE_PRINT"}`)))
+	if w.Code != http.StatusCreated {
+		t.Errorf("Test failed. RESTv2CreateEvent expression returned %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2GetEvents(w, httptest.NewRequest("GET", "/v2/events", nil))
+	var list struct {
+		Data       []RESTv2Event
+		Pagination RESTv2Pagination
+	}
+	err = json.NewDecoder(w.Body).Decode(&list)
+	if err != nil || list.Pagination.Total != 2 || list.Data[0].ID != created.ID {
+		t.Errorf("Test failed. RESTv2GetEvents unexpected result %v %v", list, err)
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v2/events/{id}", RESTv2DeleteEvent).Methods("DELETE")
+	path := "/v2/events/" + strconv.Itoa(created.ID)
+	for _, expected := range []int{http.StatusNoContent, http.StatusNotFound} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", path, nil))
+		if w.Code != expected {
+			t.Errorf("Test failed. RESTv2DeleteEvent expected %d got %d", expected, w.Code)
+		}
+	}
+}
@@ -185,7 +185,7 @@ func (a *ANX) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 func (a *ANX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrTradeHistoryNotImplemented
 }
 
 // SubmitExchangeOrder submits a new order
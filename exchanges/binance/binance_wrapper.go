@@ -130,7 +130,7 @@ func (b *Binance) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 func (b *Binance) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrTradeHistoryNotImplemented
 }
 
 // SubmitExchangeOrder submits a new order
@@ -163,11 +163,25 @@ func (b *Bitfinex) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent trades for a currency pair
 func (b *Bitfinex) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
+	trades, err := b.GetTrades(p.Pair().String(), url.Values{})
+	if err != nil {
+		return resp, err
+	}
 
-	return resp, errors.New("trade history not yet implemented")
+	for x := range trades {
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: trades[x].Timestamp,
+			TID:       trades[x].Tid,
+			Price:     trades[x].Price,
+			Amount:    trades[x].Amount,
+			Exchange:  b.Name,
+			Type:      trades[x].Type,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitExchangeOrder submits a new order
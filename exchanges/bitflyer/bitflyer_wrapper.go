@@ -142,7 +142,7 @@ func (b *Bitflyer) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 func (b *Bitflyer) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrTradeHistoryNotImplemented
 }
 
 // SubmitExchangeOrder submits a new order
@@ -129,7 +129,7 @@ func (b *Bithumb) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 func (b *Bithumb) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
 
-	return resp, errors.New("trade history not yet implemented")
+	return resp, exchange.ErrTradeHistoryNotImplemented
 }
 
 // SubmitExchangeOrder submits a new order
@@ -148,11 +148,30 @@ func (b *Bitstamp) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent trades for a currency pair
 func (b *Bitstamp) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
+	transactions, err := b.GetTransactions(p.Pair().String(), nil)
+	if err != nil {
+		return resp, err
+	}
 
-	return resp, errors.New("trade history not yet implemented")
+	for x := range transactions {
+		side := "buy"
+		if transactions[x].Type == 1 {
+			side = "sell"
+		}
+
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: transactions[x].Date,
+			TID:       transactions[x].TradeID,
+			Price:     transactions[x].Price,
+			Amount:    transactions[x].Amount,
+			Exchange:  b.Name,
+			Type:      side,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitExchangeOrder submits a new order
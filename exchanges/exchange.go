@@ -1,6 +1,7 @@
 package exchange
 
 import (
+	"errors"
 	"log"
 	"net/http"
 	"sync"
@@ -26,6 +27,10 @@ const (
 	DefaultHTTPTimeout = time.Second * 15
 )
 
+// ErrTradeHistoryNotImplemented is returned by exchanges which don't support
+// fetching their trade history
+var ErrTradeHistoryNotImplemented = errors.New("trade history not yet implemented")
+
 // AccountInfo is a Generic type to hold each exchange's holdings in
 // all enabled currencies
 type AccountInfo struct {
@@ -98,6 +103,7 @@ type IBotExchange interface {
 	SupportsAutoPairUpdates() bool
 	GetLastPairsUpdateTime() int64
 	SupportsRESTTickerBatchUpdates() bool
+	GetTakerFee() float64
 
 	SubmitExchangeOrder(p pair.CurrencyPair, side string, orderType int, amount, price float64) (int64, error)
 	ModifyExchangeOrder(p pair.CurrencyPair, orderID, action int64) (int64, error)
@@ -114,6 +120,11 @@ func (e *Base) SupportsRESTTickerBatchUpdates() bool {
 	return e.SupportsRESTTickerBatching
 }
 
+// GetTakerFee returns the taker fee percentage of the exchange
+func (e *Base) GetTakerFee() float64 {
+	return e.TakerFee
+}
+
 // SetHTTPClientTimeout sets the timeout value for the exchanges
 // HTTP Client
 func (e *Base) SetHTTPClientTimeout(t time.Duration) {
@@ -341,6 +341,16 @@ func TestGetName(t *testing.T) {
 	}
 }
 
+func TestGetTakerFee(t *testing.T) {
+	b := Base{
+		TakerFee: 0.25,
+	}
+
+	if b.GetTakerFee() != 0.25 {
+		t.Error("Test Failed - Exchange GetTakerFee() returned incorrect fee")
+	}
+}
+
 func TestGetEnabledCurrencies(t *testing.T) {
 	b := Base{
 		Name: "TESTNAME",
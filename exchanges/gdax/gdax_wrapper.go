@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
@@ -133,11 +134,30 @@ func (g *GDAX) UpdateOrderbook(p pair.CurrencyPair, assetType string) (orderbook
 	return orderbook.GetOrderbook(g.Name, p, assetType)
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent trades for a currency pair
 func (g *GDAX) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
+	trades, err := g.GetTrades(exchange.FormatExchangeCurrency(g.Name, p).String())
+	if err != nil {
+		return resp, err
+	}
+
+	for x := range trades {
+		timestamp, err := time.Parse(time.RFC3339Nano, trades[x].Time)
+		if err != nil {
+			return resp, err
+		}
 
-	return resp, errors.New("trade history not yet implemented")
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: timestamp.UnixNano() / int64(time.Millisecond),
+			TID:       trades[x].TradeID,
+			Price:     trades[x].Price,
+			Amount:    trades[x].Size,
+			Exchange:  g.Name,
+			Type:      trades[x].Side,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitExchangeOrder submits a new order
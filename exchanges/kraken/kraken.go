@@ -310,9 +310,21 @@ func (k *Kraken) GetTrades(symbol string) ([]RecentTrades, error) {
 	}
 
 	data := result.(map[string]interface{})
-	tradeInfo := data["result"].(map[string]interface{})
+	tradeInfo, ok := data["result"].(map[string]interface{})
+	if !ok {
+		return recentTrades, fmt.Errorf("Kraken error: %v", data["error"])
+	}
+
+	// The trades are keyed by the Kraken pair name, such as XXBTZUSD for
+	// XBTUSD, alongside the last trade id
+	var trades []interface{}
+	for key, value := range tradeInfo {
+		if key != "last" {
+			trades, _ = value.([]interface{})
+		}
+	}
 
-	for _, x := range tradeInfo[symbol].([]interface{}) {
+	for _, x := range trades {
 		r := RecentTrades{}
 		for i, y := range x.([]interface{}) {
 			switch i {
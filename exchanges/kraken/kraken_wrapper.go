@@ -185,11 +185,31 @@ func (k *Kraken) GetExchangeAccountInfo() (exchange.AccountInfo, error) {
 	return response, nil
 }
 
-// GetExchangeHistory returns historic trade data since exchange opening.
+// GetExchangeHistory returns the most recent trades for a currency pair.
+// Kraken doesn't return trade IDs so trades are ordered by their millisecond
+// timestamps
 func (k *Kraken) GetExchangeHistory(p pair.CurrencyPair, assetType string) ([]exchange.TradeHistory, error) {
 	var resp []exchange.TradeHistory
+	trades, err := k.GetTrades(exchange.FormatExchangeCurrency(k.Name, p).String())
+	if err != nil {
+		return resp, err
+	}
 
-	return resp, errors.New("trade history not yet implemented")
+	for x := range trades {
+		side := "buy"
+		if trades[x].BuyOrSell == "s" {
+			side = "sell"
+		}
+
+		resp = append(resp, exchange.TradeHistory{
+			Timestamp: int64(trades[x].Time * 1000),
+			Price:     trades[x].Price,
+			Amount:    trades[x].Volume,
+			Exchange:  k.Name,
+			Type:      side,
+		})
+	}
+	return resp, nil
 }
 
 // SubmitExchangeOrder submits a new order
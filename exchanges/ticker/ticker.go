@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
@@ -35,6 +36,7 @@ type Price struct {
 	Ask          float64           `json:"Ask"`
 	Volume       float64           `json:"Volume"`
 	PriceATH     float64           `json:"PriceATH"`
+	LastUpdated  time.Time         `json:"LastUpdated"`
 }
 
 // Ticker struct holds the ticker information for a currency pair and type
@@ -148,6 +150,21 @@ func CreateNewTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price,
 // ProcessTicker processes incoming tickers, creating or updating the Tickers
 // list
 func ProcessTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, tickerType string) {
+	tickerNew.LastUpdated = time.Now()
+	processTicker(exchangeName, p, tickerNew, tickerType)
+}
+
+// RestoreTicker stores a previously processed ticker, such as one loaded from
+// a shared cache, keeping its last updated time so it ages like a live ticker
+func RestoreTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, tickerType string) {
+	if tickerNew.LastUpdated.IsZero() {
+		tickerNew.LastUpdated = time.Now()
+	}
+	processTicker(exchangeName, p, tickerNew, tickerType)
+}
+
+// processTicker creates or updates the Tickers list
+func processTicker(exchangeName string, p pair.CurrencyPair, tickerNew Price, tickerType string) {
 	tickerNew.CurrencyPair = p.Pair().String()
 	if len(Tickers) == 0 {
 		CreateNewTicker(exchangeName, p, tickerNew, tickerType)
@@ -3,6 +3,7 @@ package ticker
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
 )
@@ -71,6 +72,9 @@ func TestGetTicker(t *testing.T) {
 	if tickerPrice.CurrencyPair != "BTCUSD" {
 		t.Error("Test Failed - ticker tickerPrice.CurrencyPair value is incorrect")
 	}
+	if tickerPrice.LastUpdated.IsZero() {
+		t.Error("Test Failed - ticker tickerPrice.LastUpdated value not set")
+	}
 
 	_, err = GetTicker("blah", newPair, Spot)
 	if err == nil {
@@ -276,3 +280,20 @@ func TestProcessTicker(t *testing.T) { //non-appending function to tickers
 		t.Fatal("Test failed. TestProcessTicker failed to return an existing ticker")
 	}
 }
+
+func TestRestoreTicker(t *testing.T) {
+	Tickers = []Ticker{}
+	newPair := pair.NewCurrencyPair("BTC", "USD")
+	lastUpdated := time.Now().Add(-time.Minute)
+
+	RestoreTicker("btcc", newPair, Price{Pair: newPair, Last: 1200, LastUpdated: lastUpdated}, Spot)
+
+	result, err := GetTicker("btcc", newPair, Spot)
+	if err != nil {
+		t.Fatal("Test failed. TestRestoreTicker failed to return the restored ticker")
+	}
+
+	if !result.LastUpdated.Equal(lastUpdated) || result.CurrencyPair != "BTCUSD" {
+		t.Error("Test failed. TestRestoreTicker did not keep the ticker values")
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"sync"
+)
+
+// Const vars for the feed events relayed by the updater routines
+const (
+	FeedEventTicker    = "ticker_update"
+	FeedEventOrderbook = "orderbook_update"
+)
+
+var (
+	feedSubscribers = make(map[chan WebsocketEvent]bool)
+	feedMtx         sync.Mutex
+)
+
+// SubscribeFeed registers and returns a new channel which receives the ticker
+// and orderbook events relayed by the updater routines
+func SubscribeFeed(bufferSize int) chan WebsocketEvent {
+	feedMtx.Lock()
+	defer feedMtx.Unlock()
+
+	ch := make(chan WebsocketEvent, bufferSize)
+	feedSubscribers[ch] = true
+	return ch
+}
+
+// UnsubscribeFeed removes a channel from the feed subscribers
+func UnsubscribeFeed(ch chan WebsocketEvent) {
+	feedMtx.Lock()
+	defer feedMtx.Unlock()
+	delete(feedSubscribers, ch)
+}
+
+// PublishFeedEvent sends an event to all feed subscribers, subscribers with a
+// full buffer miss the event rather than blocking the updater routines
+func PublishFeedEvent(evt WebsocketEvent) {
+	feedMtx.Lock()
+	defer feedMtx.Unlock()
+
+	for ch := range feedSubscribers {
+		select {
+		case ch <- evt:
+		default:
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+)
+
+var fxHealthy = true
+
+// StartFXRefresher periodically re-fetches the FX rates from the forex
+// providers, keeping the last good rates while the providers fail
+func StartFXRefresher() {
+	interval := bot.Config.Currency.FXRefreshInterval
+	if interval <= 0 {
+		return
+	}
+
+	log.Printf("Currency: refreshing FX rates every %s.", interval)
+	go func() {
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for range tick.C {
+			refreshFXRates()
+		}
+	}()
+}
+
+// refreshFXRates re-fetches the FX rates and mirrors them to Redis, logging
+// provider failures and recoveries without repeating the error every refresh
+func refreshFXRates() {
+	err := currency.RefreshRates()
+	if err != nil {
+		if fxHealthy {
+			log.Printf("Currency: failed to refresh FX rates, serving the last good rates. Err: %s", err)
+		}
+		fxHealthy = false
+		return
+	}
+
+	if !fxHealthy {
+		log.Println("Currency: FX rate refresh restored.")
+	}
+	fxHealthy = true
+	MirrorFXRates()
+}
+
+// GetFXStatus returns the FX rates with their fetch times and staleness
+func GetFXStatus() currency.FXStatus {
+	return currency.GetFXStatus(bot.Config.Currency.FXStaleAfter, time.Now())
+}
+
+// RESTv2GetFXRates returns the FX rates with their fetch times and staleness
+func RESTv2GetFXRates(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+	restV2Object(w, r, query, GetFXStatus())
+}
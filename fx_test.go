@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+)
+
+func TestRESTv2GetFXRates(t *testing.T) {
+	backupConfig := bot.Config
+	defer func() { bot.Config = backupConfig }()
+
+	bot.Config = &config.Config{}
+	bot.Config.Currency.FXStaleAfter = time.Hour
+	currency.SetExchangeRates(map[string]float64{"USDAUD": 1.35}, time.Now())
+	currency.SetExchangeRates(map[string]float64{"USDJPY": 110}, time.Now().Add(-time.Hour*2))
+
+	w := httptest.NewRecorder()
+	RESTv2GetFXRates(w, httptest.NewRequest("GET", "/v2/fx/rates", nil))
+
+	var status currency.FXStatus
+	err := json.NewDecoder(w.Body).Decode(&status)
+	if err != nil || status.Rates["USDAUD"] != 1.35 || !status.Stale ||
+		len(status.StaleRates) != 1 || status.StaleRates[0] != "USDJPY" {
+		t.Errorf("Test failed. RESTv2GetFXRates unexpected result %+v %v", status, err)
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2GetFXRates(w, httptest.NewRequest("GET", "/v2/fx/rates?fields=stale", nil))
+	if body := w.Body.String(); body != `{"stale":true}` {
+		t.Errorf("Test failed. RESTv2GetFXRates field selection returned %s", body)
+	}
+}
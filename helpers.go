@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	Bot "github.com/trustfeed/go-crypto-pricefeeder/bot"
 	"github.com/trustfeed/go-crypto-pricefeeder/currency"
@@ -193,22 +194,9 @@ func GetRelatableCurrencies(p pair.CurrencyPair, incOrig, incUSDT bool) []pair.C
 			addPair(p)
 		}
 
-		first, err := translation.GetTranslation(p.FirstCurrency)
-		if err == nil {
-			addPair(pair.NewCurrencyPair(first.String(),
-				p.SecondCurrency.String()))
-
-			second, err := translation.GetTranslation(p.SecondCurrency)
-			if err == nil {
-				addPair(pair.NewCurrencyPair(first.String(),
-					second.String()))
-			}
-		}
-
-		second, err := translation.GetTranslation(p.SecondCurrency)
-		if err == nil {
-			addPair(pair.NewCurrencyPair(p.FirstCurrency.String(),
-				second.String()))
+		translated := GetTranslatedPairs(p, true)
+		for x := range translated {
+			addPair(translated[x])
 		}
 	}
 
@@ -222,6 +210,49 @@ func GetRelatableCurrencies(p pair.CurrencyPair, incOrig, incUSDT bool) []pair.C
 	return pairs
 }
 
+// GetTranslatedPairs returns the translations of a currency pair keeping its
+// order (e.g BTCUSD -> XBTUSD, XBTUSDT, BTCUSDT). USD and USDT are only
+// translated if incUSDT is set
+func GetTranslatedPairs(p pair.CurrencyPair, incUSDT bool) []pair.CurrencyPair {
+	translate := func(c pair.CurrencyItem) (pair.CurrencyItem, bool) {
+		result, err := translation.GetTranslation(c)
+		if err != nil || (!incUSDT && (c.Upper() == "USDT" || result == "USDT")) {
+			return "", false
+		}
+		return result, true
+	}
+
+	var pairs []pair.CurrencyPair
+	first, firstOK := translate(p.FirstCurrency)
+	second, secondOK := translate(p.SecondCurrency)
+	if firstOK {
+		pairs = append(pairs, pair.NewCurrencyPair(first.String(),
+			p.SecondCurrency.String()))
+
+		if secondOK {
+			pairs = append(pairs, pair.NewCurrencyPair(first.String(),
+				second.String()))
+		}
+	}
+
+	if secondOK {
+		pairs = append(pairs, pair.NewCurrencyPair(p.FirstCurrency.String(),
+			second.String()))
+	}
+	return pairs
+}
+
+// GetSyntheticPrice returns the price of a currency pair which may not be
+// traded directly (e.g ETHEUR -> ETHBTC -> BTCUSDT -> USDEUR), converting
+// along the most liquid and freshest path across the tickers of the enabled
+// exchanges, or a specific exchange if exchangeName is set, and the FX rates.
+// Relatable currencies are treated as the same currency, USD and USDT only
+// if incUSDT is set
+func GetSyntheticPrice(bot Bot.Bot, p pair.CurrencyPair, exchangeName string, incUSDT bool) (SyntheticPrice, error) {
+	graph := BuildPriceGraph(bot, exchangeName, incUSDT, time.Now())
+	return graph.Price(p.FirstCurrency.String(), p.SecondCurrency.String(), syntheticMaxHops)
+}
+
 // GetSpecificOrderbook returns a specific orderbook given the currency,
 // exchangeName and assetType
 func GetSpecificOrderbook(bot Bot.Bot, currency, exchangeName, assetType string) (orderbook.Base, error) {
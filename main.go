@@ -19,6 +19,7 @@ import (
 	"github.com/trustfeed/go-crypto-pricefeeder/communications"
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
 	"github.com/trustfeed/go-crypto-pricefeeder/portfolio"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks"
 )
 
 const banner = `
@@ -90,11 +91,25 @@ func main() {
 	log.Println("Starting communication mediums..")
 	bot.Comms = communications.NewComm(bot.Config.GetCommunicationsConfig())
 	bot.Comms.GetEnabledCommunicationMediums()
+	StartTelegram()
+
+	log.Println("Starting output sinks..")
+	bot.Sinks = sinks.NewSinks(bot.Config.Sinks)
+	bot.Sinks.GetEnabledSinks()
+	StartWebhooks()
+	StartRedisCache()
 
 	log.Printf("Fiat display currency: %s.", bot.Config.Currency.FiatDisplayCurrency)
 	currency.BaseCurrency = bot.Config.Currency.FiatDisplayCurrency
 	currency.FXProviders = forexprovider.StartFXService(bot.Config.GetCurrencyConfig().ForexProviders)
-	log.Printf("Primary forex conversion provider: %s.\n", bot.Config.GetPrimaryForexProvider())
+	currency.FXConsensus = bot.Config.Currency.FXConsensus
+	currency.FXDeviationTolerance = bot.Config.Currency.FXDeviationTolerance
+	if currency.FXConsensus {
+		log.Printf("Forex conversion using the median rate of all enabled providers, flagging deviations over %.2f%%.\n",
+			currency.FXDeviationTolerance)
+	} else {
+		log.Printf("Primary forex conversion provider: %s.\n", bot.Config.GetPrimaryForexProvider())
+	}
 	err = bot.Config.RetrieveConfigCurrencyPairs(true)
 	if err != nil {
 		log.Fatalf("Failed to retrieve config currency pairs. Error: %s", err)
@@ -103,17 +118,31 @@ func main() {
 	log.Println("Fetching currency data from forex provider..")
 	err = currency.SeedCurrencyData(common.JoinStrings(currency.FiatCurrencies, ","))
 	if err != nil {
-		log.Fatalf("Unable to fetch forex data. Error: %s", err)
+		if !currency.HasExchangeRates() {
+			log.Fatalf("Unable to fetch forex data. Error: %s", err)
+		}
+		log.Printf("Unable to fetch forex data, using the rates restored from Redis. Error: %s", err)
 	}
+	MirrorFXRates()
+	StartFXRefresher()
 
 	bot.Portfolio = &portfolio.Portfolio
 	bot.Portfolio.SeedPortfolio(bot.Config.Portfolio)
 	SeedExchangeAccountInfo(GetAllEnabledExchangeAccountInfo().Data)
 
 	go portfolio.StartPortfolioWatcher()
+	StartEvents()
+	StartChatAlerts()
+	StartArbitrageMonitor()
+	StartDigests()
+	StartCharts()
 	go TickerUpdaterRoutine()
 	go OrderbookUpdaterRoutine()
 
+	if bot.Sinks.WantsEvent(config.SinkEventTrade) {
+		go TradeUpdaterRoutine()
+	}
+
 	if bot.Config.Webserver.Enabled {
 		listenAddr := bot.Config.Webserver.ListenAddress
 		log.Printf(
@@ -132,6 +161,11 @@ func main() {
 		log.Println("HTTP Webserver started successfully.")
 		log.Println("Starting websocket handler.")
 		StartWebsocketHandler()
+		StartStreamBroker()
+
+		if bot.Config.Webserver.GRPCEnabled {
+			StartRPCServer()
+		}
 	} else {
 		log.Println("HTTP RESTful Webserver support disabled.")
 	}
@@ -178,12 +212,24 @@ func HandleInterrupt() {
 func Shutdown() {
 	log.Println("Bot shutting down..")
 
+	if bot.Sinks != nil {
+		bot.Sinks.Close()
+	}
+
+	if bot.Webhooks != nil {
+		bot.Webhooks.Stop()
+	}
+
+	if bot.Cache != nil {
+		bot.Cache.Close()
+	}
+
 	if len(portfolio.Portfolio.Addresses) != 0 {
 		bot.Config.Portfolio = portfolio.Portfolio
 	}
 
 	if !bot.DryRun {
-		err := bot.Config.SaveConfig(bot.ConfigFile)
+		err := SaveConfig()
 
 		if err != nil {
 			log.Println("Unable to save config.")
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+)
+
+// Const vars for the config persistence
+const (
+	// configSaveDelay debounces the config saves of the events, which are
+	// persisted whenever they trigger on a ticker update
+	configSaveDelay = time.Second * 5
+)
+
+var (
+	configSaveMtx          sync.Mutex
+	configSaveTimer        *time.Timer
+	configEventsPending    bool
+	configTelegramsPending bool
+)
+
+// persistEvents schedules a config save with the current events
+func persistEvents([]config.EventConfig) {
+	configSaveMtx.Lock()
+	configEventsPending = true
+	if configSaveTimer == nil {
+		configSaveTimer = time.AfterFunc(configSaveDelay, func() {
+			err := SaveConfig()
+			if err != nil {
+				log.Printf("Events: unable to save config. Err: %s", err)
+			}
+		})
+	}
+	configSaveMtx.Unlock()
+}
+
+// persistTelegramClients saves the config with the current authorised
+// telegram chats
+func persistTelegramClients([]int64) {
+	configSaveMtx.Lock()
+	configTelegramsPending = true
+	configSaveMtx.Unlock()
+
+	err := SaveConfig()
+	if err != nil {
+		log.Printf("Telegram: unable to save config. Err: %s", err)
+	}
+}
+
+// SaveConfig stores the pending events and telegram chats in the config and
+// saves it. The snapshots are taken under configSaveMtx so an older snapshot
+// is never saved after a newer one
+func SaveConfig() error {
+	configSaveMtx.Lock()
+	defer configSaveMtx.Unlock()
+
+	if configSaveTimer != nil {
+		configSaveTimer.Stop()
+		configSaveTimer = nil
+	}
+
+	if configEventsPending {
+		bot.Config.UpdateEventsConfig(events.GetEventConfigs())
+		configEventsPending = false
+	}
+
+	if configTelegramsPending {
+		if t, ok := getTelegram(); ok {
+			cfg := bot.Config.GetCommunicationsConfig()
+			cfg.TelegramConfig.AuthorisedClients = t.GetAuthorisedClients()
+			bot.Config.UpdateCommunicationsConfig(cfg)
+		}
+		configTelegramsPending = false
+	}
+
+	if bot.DryRun {
+		return nil
+	}
+	return bot.Config.SaveConfig(bot.ConfigFile)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+)
+
+func TestPersistEvents(t *testing.T) {
+	backupConfig, backupDryRun := bot.Config, bot.DryRun
+	defer func() { bot.Config, bot.DryRun = backupConfig, backupDryRun }()
+	bot.Config = &config.Config{Events: []config.EventConfig{{Exchange: "Bitfinex"}}}
+	bot.DryRun = true
+	events.LoadEvents(nil)
+
+	persistEvents(nil)
+	persistEvents(nil)
+
+	configSaveMtx.Lock()
+	scheduled := configSaveTimer != nil && configEventsPending
+	configSaveMtx.Unlock()
+	if !scheduled || len(bot.Config.Events) != 1 {
+		t.Fatal("Test failed. persistEvents should debounce the config save")
+	}
+
+	err := SaveConfig()
+	if err != nil {
+		t.Fatal("Test failed. SaveConfig error", err)
+	}
+
+	if configSaveTimer != nil || configEventsPending || len(bot.Config.Events) != 0 {
+		t.Error("Test failed. SaveConfig did not store the pending events")
+	}
+}
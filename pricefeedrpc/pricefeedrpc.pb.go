@@ -0,0 +1,1938 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: pricefeedrpc.proto
+
+package pricefeedrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetExchangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *GetExchangesRequest) Reset() {
+	*x = GetExchangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExchangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExchangesRequest) ProtoMessage() {}
+
+func (x *GetExchangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExchangesRequest.ProtoReflect.Descriptor instead.
+func (*GetExchangesRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetExchangesRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ExchangeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name                    string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled                 bool     `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Loaded                  bool     `protobuf:"varint,3,opt,name=loaded,proto3" json:"loaded,omitempty"`
+	Websocket               bool     `protobuf:"varint,4,opt,name=websocket,proto3" json:"websocket,omitempty"`
+	AuthenticatedApiSupport bool     `protobuf:"varint,5,opt,name=authenticated_api_support,json=authenticatedApiSupport,proto3" json:"authenticated_api_support,omitempty"`
+	AssetTypes              []string `protobuf:"bytes,6,rep,name=asset_types,json=assetTypes,proto3" json:"asset_types,omitempty"`
+	EnabledPairs            []string `protobuf:"bytes,7,rep,name=enabled_pairs,json=enabledPairs,proto3" json:"enabled_pairs,omitempty"`
+	PairsLastUpdated        int64    `protobuf:"varint,8,opt,name=pairs_last_updated,json=pairsLastUpdated,proto3" json:"pairs_last_updated,omitempty"`
+}
+
+func (x *ExchangeStatus) Reset() {
+	*x = ExchangeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExchangeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeStatus) ProtoMessage() {}
+
+func (x *ExchangeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeStatus.ProtoReflect.Descriptor instead.
+func (*ExchangeStatus) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExchangeStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExchangeStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ExchangeStatus) GetLoaded() bool {
+	if x != nil {
+		return x.Loaded
+	}
+	return false
+}
+
+func (x *ExchangeStatus) GetWebsocket() bool {
+	if x != nil {
+		return x.Websocket
+	}
+	return false
+}
+
+func (x *ExchangeStatus) GetAuthenticatedApiSupport() bool {
+	if x != nil {
+		return x.AuthenticatedApiSupport
+	}
+	return false
+}
+
+func (x *ExchangeStatus) GetAssetTypes() []string {
+	if x != nil {
+		return x.AssetTypes
+	}
+	return nil
+}
+
+func (x *ExchangeStatus) GetEnabledPairs() []string {
+	if x != nil {
+		return x.EnabledPairs
+	}
+	return nil
+}
+
+func (x *ExchangeStatus) GetPairsLastUpdated() int64 {
+	if x != nil {
+		return x.PairsLastUpdated
+	}
+	return 0
+}
+
+type GetExchangesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchanges []*ExchangeStatus `protobuf:"bytes,1,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+}
+
+func (x *GetExchangesResponse) Reset() {
+	*x = GetExchangesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExchangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExchangesResponse) ProtoMessage() {}
+
+func (x *GetExchangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExchangesResponse.ProtoReflect.Descriptor instead.
+func (*GetExchangesResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetExchangesResponse) GetExchanges() []*ExchangeStatus {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+type CurrencyPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delimiter string `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Base      string `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Quote     string `protobuf:"bytes,3,opt,name=quote,proto3" json:"quote,omitempty"`
+}
+
+func (x *CurrencyPair) Reset() {
+	*x = CurrencyPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CurrencyPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyPair) ProtoMessage() {}
+
+func (x *CurrencyPair) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyPair.ProtoReflect.Descriptor instead.
+func (*CurrencyPair) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CurrencyPair) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *CurrencyPair) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *CurrencyPair) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+type GetTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange  string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+}
+
+func (x *GetTickerRequest) Reset() {
+	*x = GetTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickerRequest) ProtoMessage() {}
+
+func (x *GetTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickerRequest.ProtoReflect.Descriptor instead.
+func (*GetTickerRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTickerRequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *GetTickerRequest) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *GetTickerRequest) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+type TickerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange    string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	AssetType   string        `protobuf:"bytes,2,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	Pair        *CurrencyPair `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	Last        float64       `protobuf:"fixed64,4,opt,name=last,proto3" json:"last,omitempty"`
+	High        float64       `protobuf:"fixed64,5,opt,name=high,proto3" json:"high,omitempty"`
+	Low         float64       `protobuf:"fixed64,6,opt,name=low,proto3" json:"low,omitempty"`
+	Bid         float64       `protobuf:"fixed64,7,opt,name=bid,proto3" json:"bid,omitempty"`
+	Ask         float64       `protobuf:"fixed64,8,opt,name=ask,proto3" json:"ask,omitempty"`
+	Volume      float64       `protobuf:"fixed64,9,opt,name=volume,proto3" json:"volume,omitempty"`
+	PriceAth    float64       `protobuf:"fixed64,10,opt,name=price_ath,json=priceAth,proto3" json:"price_ath,omitempty"`
+	LastUpdated int64         `protobuf:"varint,11,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *TickerResponse) Reset() {
+	*x = TickerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TickerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TickerResponse) ProtoMessage() {}
+
+func (x *TickerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TickerResponse.ProtoReflect.Descriptor instead.
+func (*TickerResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TickerResponse) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *TickerResponse) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *TickerResponse) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *TickerResponse) GetLast() float64 {
+	if x != nil {
+		return x.Last
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetPriceAth() float64 {
+	if x != nil {
+		return x.PriceAth
+	}
+	return 0
+}
+
+func (x *TickerResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+type GetTickersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTickersRequest) Reset() {
+	*x = GetTickersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTickersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickersRequest) ProtoMessage() {}
+
+func (x *GetTickersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickersRequest.ProtoReflect.Descriptor instead.
+func (*GetTickersRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{6}
+}
+
+type Tickers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange string            `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Tickers  []*TickerResponse `protobuf:"bytes,2,rep,name=tickers,proto3" json:"tickers,omitempty"`
+}
+
+func (x *Tickers) Reset() {
+	*x = Tickers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tickers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tickers) ProtoMessage() {}
+
+func (x *Tickers) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tickers.ProtoReflect.Descriptor instead.
+func (*Tickers) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Tickers) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *Tickers) GetTickers() []*TickerResponse {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+type GetTickersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tickers []*Tickers `protobuf:"bytes,1,rep,name=tickers,proto3" json:"tickers,omitempty"`
+}
+
+func (x *GetTickersResponse) Reset() {
+	*x = GetTickersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTickersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTickersResponse) ProtoMessage() {}
+
+func (x *GetTickersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTickersResponse.ProtoReflect.Descriptor instead.
+func (*GetTickersResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTickersResponse) GetTickers() []*Tickers {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+type GetOrderbookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange  string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+}
+
+func (x *GetOrderbookRequest) Reset() {
+	*x = GetOrderbookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrderbookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderbookRequest) ProtoMessage() {}
+
+func (x *GetOrderbookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderbookRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderbookRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderbookRequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *GetOrderbookRequest) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *GetOrderbookRequest) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+type OrderbookItem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Price  float64 `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+}
+
+func (x *OrderbookItem) Reset() {
+	*x = OrderbookItem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderbookItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderbookItem) ProtoMessage() {}
+
+func (x *OrderbookItem) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderbookItem.ProtoReflect.Descriptor instead.
+func (*OrderbookItem) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderbookItem) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *OrderbookItem) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+type OrderbookResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange    string           `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	AssetType   string           `protobuf:"bytes,2,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+	Pair        *CurrencyPair    `protobuf:"bytes,3,opt,name=pair,proto3" json:"pair,omitempty"`
+	Bids        []*OrderbookItem `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks        []*OrderbookItem `protobuf:"bytes,5,rep,name=asks,proto3" json:"asks,omitempty"`
+	LastUpdated int64            `protobuf:"varint,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *OrderbookResponse) Reset() {
+	*x = OrderbookResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OrderbookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderbookResponse) ProtoMessage() {}
+
+func (x *OrderbookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderbookResponse.ProtoReflect.Descriptor instead.
+func (*OrderbookResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OrderbookResponse) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *OrderbookResponse) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+func (x *OrderbookResponse) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *OrderbookResponse) GetBids() []*OrderbookItem {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderbookResponse) GetAsks() []*OrderbookItem {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderbookResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+type GetOrderbooksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetOrderbooksRequest) Reset() {
+	*x = GetOrderbooksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrderbooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderbooksRequest) ProtoMessage() {}
+
+func (x *GetOrderbooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderbooksRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderbooksRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{12}
+}
+
+type Orderbooks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange   string               `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Orderbooks []*OrderbookResponse `protobuf:"bytes,2,rep,name=orderbooks,proto3" json:"orderbooks,omitempty"`
+}
+
+func (x *Orderbooks) Reset() {
+	*x = Orderbooks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Orderbooks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Orderbooks) ProtoMessage() {}
+
+func (x *Orderbooks) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Orderbooks.ProtoReflect.Descriptor instead.
+func (*Orderbooks) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Orderbooks) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *Orderbooks) GetOrderbooks() []*OrderbookResponse {
+	if x != nil {
+		return x.Orderbooks
+	}
+	return nil
+}
+
+type GetOrderbooksResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Orderbooks []*Orderbooks `protobuf:"bytes,1,rep,name=orderbooks,proto3" json:"orderbooks,omitempty"`
+}
+
+func (x *GetOrderbooksResponse) Reset() {
+	*x = GetOrderbooksResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetOrderbooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderbooksResponse) ProtoMessage() {}
+
+func (x *GetOrderbooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderbooksResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderbooksResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetOrderbooksResponse) GetOrderbooks() []*Orderbooks {
+	if x != nil {
+		return x.Orderbooks
+	}
+	return nil
+}
+
+type GetExchangeRatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetExchangeRatesRequest) Reset() {
+	*x = GetExchangeRatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExchangeRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExchangeRatesRequest) ProtoMessage() {}
+
+func (x *GetExchangeRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExchangeRatesRequest.ProtoReflect.Descriptor instead.
+func (*GetExchangeRatesRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{15}
+}
+
+type GetExchangeRatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rates map[string]float64 `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+}
+
+func (x *GetExchangeRatesResponse) Reset() {
+	*x = GetExchangeRatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExchangeRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExchangeRatesResponse) ProtoMessage() {}
+
+func (x *GetExchangeRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExchangeRatesResponse.ProtoReflect.Descriptor instead.
+func (*GetExchangeRatesResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetExchangeRatesResponse) GetRates() map[string]float64 {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type GetPortfolioSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetPortfolioSummaryRequest) Reset() {
+	*x = GetPortfolioSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPortfolioSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioSummaryRequest) ProtoMessage() {}
+
+func (x *GetPortfolioSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetPortfolioSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{17}
+}
+
+type Coin struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Coin       string  `protobuf:"bytes,1,opt,name=coin,proto3" json:"coin,omitempty"`
+	Balance    float64 `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Address    string  `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Percentage float64 `protobuf:"fixed64,4,opt,name=percentage,proto3" json:"percentage,omitempty"`
+}
+
+func (x *Coin) Reset() {
+	*x = Coin{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Coin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coin) ProtoMessage() {}
+
+func (x *Coin) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coin.ProtoReflect.Descriptor instead.
+func (*Coin) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Coin) GetCoin() string {
+	if x != nil {
+		return x.Coin
+	}
+	return ""
+}
+
+func (x *Coin) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Coin) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Coin) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type GetPortfolioSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CoinTotals   []*Coin `protobuf:"bytes,1,rep,name=coin_totals,json=coinTotals,proto3" json:"coin_totals,omitempty"`
+	CoinsOffline []*Coin `protobuf:"bytes,2,rep,name=coins_offline,json=coinsOffline,proto3" json:"coins_offline,omitempty"`
+	CoinsOnline  []*Coin `protobuf:"bytes,3,rep,name=coins_online,json=coinsOnline,proto3" json:"coins_online,omitempty"`
+}
+
+func (x *GetPortfolioSummaryResponse) Reset() {
+	*x = GetPortfolioSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPortfolioSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioSummaryResponse) ProtoMessage() {}
+
+func (x *GetPortfolioSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetPortfolioSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPortfolioSummaryResponse) GetCoinTotals() []*Coin {
+	if x != nil {
+		return x.CoinTotals
+	}
+	return nil
+}
+
+func (x *GetPortfolioSummaryResponse) GetCoinsOffline() []*Coin {
+	if x != nil {
+		return x.CoinsOffline
+	}
+	return nil
+}
+
+func (x *GetPortfolioSummaryResponse) GetCoinsOnline() []*Coin {
+	if x != nil {
+		return x.CoinsOnline
+	}
+	return nil
+}
+
+type SubscribeTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange  string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+}
+
+func (x *SubscribeTickerRequest) Reset() {
+	*x = SubscribeTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTickerRequest) ProtoMessage() {}
+
+func (x *SubscribeTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTickerRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTickerRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SubscribeTickerRequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *SubscribeTickerRequest) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *SubscribeTickerRequest) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+type SubscribeOrderbookRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exchange  string        `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Pair      *CurrencyPair `protobuf:"bytes,2,opt,name=pair,proto3" json:"pair,omitempty"`
+	AssetType string        `protobuf:"bytes,3,opt,name=asset_type,json=assetType,proto3" json:"asset_type,omitempty"`
+}
+
+func (x *SubscribeOrderbookRequest) Reset() {
+	*x = SubscribeOrderbookRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pricefeedrpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeOrderbookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOrderbookRequest) ProtoMessage() {}
+
+func (x *SubscribeOrderbookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricefeedrpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOrderbookRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeOrderbookRequest) Descriptor() ([]byte, []int) {
+	return file_pricefeedrpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubscribeOrderbookRequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *SubscribeOrderbookRequest) GetPair() *CurrencyPair {
+	if x != nil {
+		return x.Pair
+	}
+	return nil
+}
+
+func (x *SubscribeOrderbookRequest) GetAssetType() string {
+	if x != nil {
+		return x.AssetType
+	}
+	return ""
+}
+
+var File_pricefeedrpc_proto protoreflect.FileDescriptor
+
+var file_pricefeedrpc_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72,
+	0x70, 0x63, 0x22, 0x2f, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0xa4, 0x02, 0x0a, 0x0e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3a, 0x0a, 0x19, 0x61, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x70, 0x69, 0x5f,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x41, 0x70, 0x69, 0x53,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x2c, 0x0a, 0x12,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x61, 0x69, 0x72, 0x73, 0x4c,
+	0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x52, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x09, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x56,
+	0x0a, 0x0c, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0x7d, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64,
+	0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69, 0x72,
+	0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0xb1, 0x02, 0x0a, 0x0e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63,
+	0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x04, 0x70,
+	0x61, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x10, 0x0a,
+	0x03, 0x62, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x73,
+	0x6b, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x5f, 0x61, 0x74, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x41, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61,
+	0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5d,
+	0x0a, 0x07, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x22, 0x45, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64,
+	0x72, 0x70, 0x63, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x07, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61,
+	0x69, 0x72, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x6f, 0x6b, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x22, 0x83, 0x02, 0x0a, 0x11, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73,
+	0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69,
+	0x72, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x2f, 0x0a, 0x04, 0x62, 0x69, 0x64, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x49, 0x74,
+	0x65, 0x6d, 0x52, 0x04, 0x62, 0x69, 0x64, 0x73, 0x12, 0x2f, 0x0a, 0x04, 0x61, 0x73, 0x6b, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x16, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x69, 0x0a, 0x0a, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f,
+	0x6b, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x3f,
+	0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70,
+	0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x22,
+	0x51, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f,
+	0x6b, 0x73, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9d, 0x01,
+	0x0a, 0x18, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x72, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x61,
+	0x74, 0x65, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x52, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1c, 0x0a,
+	0x1a, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x66, 0x6f, 0x6c, 0x69, 0x6f, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x6e, 0x0a, 0x04, 0x43,
+	0x6f, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x63, 0x6f, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0xc2, 0x01, 0x0a, 0x1b,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x66, 0x6f, 0x6c, 0x69, 0x6f, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x63,
+	0x6f, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x6f, 0x69, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x69, 0x6e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x73,
+	0x12, 0x37, 0x0a, 0x0d, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x5f, 0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e,
+	0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66,
+	0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x69,
+	0x6e, 0x73, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x0c, 0x63, 0x6f, 0x69,
+	0x6e, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x6f, 0x69, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x4f, 0x6e, 0x6c, 0x69, 0x6e, 0x65,
+	0x22, 0x83, 0x01, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x69,
+	0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
+	0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69,
+	0x72, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x73,
+	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x19, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x2e, 0x0a, 0x04, 0x70, 0x61, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x61, 0x69, 0x72, 0x52, 0x04, 0x70, 0x61, 0x69, 0x72,
+	0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x73, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x73, 0x73, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x32,
+	0xca, 0x06, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x46, 0x65, 0x65, 0x64, 0x65, 0x72, 0x12,
+	0x57, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12,
+	0x21, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47,
+	0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70,
+	0x63, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x73, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72,
+	0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64,
+	0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x62, 0x6f, 0x6f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x12,
+	0x22, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47,
+	0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72,
+	0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x25,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65,
+	0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65,
+	0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x6c, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x66, 0x6f, 0x6c, 0x69, 0x6f, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x28, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x66, 0x6f, 0x6c,
+	0x69, 0x6f, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x29, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e,
+	0x47, 0x65, 0x74, 0x50, 0x6f, 0x72, 0x74, 0x66, 0x6f, 0x6c, 0x69, 0x6f, 0x53, 0x75, 0x6d, 0x6d,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a,
+	0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72,
+	0x12, 0x24, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65,
+	0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x62, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x27,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x65, 0x66,
+	0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x39, 0x5a, 0x37,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x75, 0x73, 0x74,
+	0x66, 0x65, 0x65, 0x64, 0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2d, 0x70,
+	0x72, 0x69, 0x63, 0x65, 0x66, 0x65, 0x65, 0x64, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x66, 0x65, 0x65, 0x64, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pricefeedrpc_proto_rawDescOnce sync.Once
+	file_pricefeedrpc_proto_rawDescData = file_pricefeedrpc_proto_rawDesc
+)
+
+func file_pricefeedrpc_proto_rawDescGZIP() []byte {
+	file_pricefeedrpc_proto_rawDescOnce.Do(func() {
+		file_pricefeedrpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_pricefeedrpc_proto_rawDescData)
+	})
+	return file_pricefeedrpc_proto_rawDescData
+}
+
+var file_pricefeedrpc_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_pricefeedrpc_proto_goTypes = []any{
+	(*GetExchangesRequest)(nil),         // 0: pricefeedrpc.GetExchangesRequest
+	(*ExchangeStatus)(nil),              // 1: pricefeedrpc.ExchangeStatus
+	(*GetExchangesResponse)(nil),        // 2: pricefeedrpc.GetExchangesResponse
+	(*CurrencyPair)(nil),                // 3: pricefeedrpc.CurrencyPair
+	(*GetTickerRequest)(nil),            // 4: pricefeedrpc.GetTickerRequest
+	(*TickerResponse)(nil),              // 5: pricefeedrpc.TickerResponse
+	(*GetTickersRequest)(nil),           // 6: pricefeedrpc.GetTickersRequest
+	(*Tickers)(nil),                     // 7: pricefeedrpc.Tickers
+	(*GetTickersResponse)(nil),          // 8: pricefeedrpc.GetTickersResponse
+	(*GetOrderbookRequest)(nil),         // 9: pricefeedrpc.GetOrderbookRequest
+	(*OrderbookItem)(nil),               // 10: pricefeedrpc.OrderbookItem
+	(*OrderbookResponse)(nil),           // 11: pricefeedrpc.OrderbookResponse
+	(*GetOrderbooksRequest)(nil),        // 12: pricefeedrpc.GetOrderbooksRequest
+	(*Orderbooks)(nil),                  // 13: pricefeedrpc.Orderbooks
+	(*GetOrderbooksResponse)(nil),       // 14: pricefeedrpc.GetOrderbooksResponse
+	(*GetExchangeRatesRequest)(nil),     // 15: pricefeedrpc.GetExchangeRatesRequest
+	(*GetExchangeRatesResponse)(nil),    // 16: pricefeedrpc.GetExchangeRatesResponse
+	(*GetPortfolioSummaryRequest)(nil),  // 17: pricefeedrpc.GetPortfolioSummaryRequest
+	(*Coin)(nil),                        // 18: pricefeedrpc.Coin
+	(*GetPortfolioSummaryResponse)(nil), // 19: pricefeedrpc.GetPortfolioSummaryResponse
+	(*SubscribeTickerRequest)(nil),      // 20: pricefeedrpc.SubscribeTickerRequest
+	(*SubscribeOrderbookRequest)(nil),   // 21: pricefeedrpc.SubscribeOrderbookRequest
+	nil,                                 // 22: pricefeedrpc.GetExchangeRatesResponse.RatesEntry
+}
+var file_pricefeedrpc_proto_depIdxs = []int32{
+	1,  // 0: pricefeedrpc.GetExchangesResponse.exchanges:type_name -> pricefeedrpc.ExchangeStatus
+	3,  // 1: pricefeedrpc.GetTickerRequest.pair:type_name -> pricefeedrpc.CurrencyPair
+	3,  // 2: pricefeedrpc.TickerResponse.pair:type_name -> pricefeedrpc.CurrencyPair
+	5,  // 3: pricefeedrpc.Tickers.tickers:type_name -> pricefeedrpc.TickerResponse
+	7,  // 4: pricefeedrpc.GetTickersResponse.tickers:type_name -> pricefeedrpc.Tickers
+	3,  // 5: pricefeedrpc.GetOrderbookRequest.pair:type_name -> pricefeedrpc.CurrencyPair
+	3,  // 6: pricefeedrpc.OrderbookResponse.pair:type_name -> pricefeedrpc.CurrencyPair
+	10, // 7: pricefeedrpc.OrderbookResponse.bids:type_name -> pricefeedrpc.OrderbookItem
+	10, // 8: pricefeedrpc.OrderbookResponse.asks:type_name -> pricefeedrpc.OrderbookItem
+	11, // 9: pricefeedrpc.Orderbooks.orderbooks:type_name -> pricefeedrpc.OrderbookResponse
+	13, // 10: pricefeedrpc.GetOrderbooksResponse.orderbooks:type_name -> pricefeedrpc.Orderbooks
+	22, // 11: pricefeedrpc.GetExchangeRatesResponse.rates:type_name -> pricefeedrpc.GetExchangeRatesResponse.RatesEntry
+	18, // 12: pricefeedrpc.GetPortfolioSummaryResponse.coin_totals:type_name -> pricefeedrpc.Coin
+	18, // 13: pricefeedrpc.GetPortfolioSummaryResponse.coins_offline:type_name -> pricefeedrpc.Coin
+	18, // 14: pricefeedrpc.GetPortfolioSummaryResponse.coins_online:type_name -> pricefeedrpc.Coin
+	3,  // 15: pricefeedrpc.SubscribeTickerRequest.pair:type_name -> pricefeedrpc.CurrencyPair
+	3,  // 16: pricefeedrpc.SubscribeOrderbookRequest.pair:type_name -> pricefeedrpc.CurrencyPair
+	0,  // 17: pricefeedrpc.PriceFeeder.GetExchanges:input_type -> pricefeedrpc.GetExchangesRequest
+	4,  // 18: pricefeedrpc.PriceFeeder.GetTicker:input_type -> pricefeedrpc.GetTickerRequest
+	6,  // 19: pricefeedrpc.PriceFeeder.GetTickers:input_type -> pricefeedrpc.GetTickersRequest
+	9,  // 20: pricefeedrpc.PriceFeeder.GetOrderbook:input_type -> pricefeedrpc.GetOrderbookRequest
+	12, // 21: pricefeedrpc.PriceFeeder.GetOrderbooks:input_type -> pricefeedrpc.GetOrderbooksRequest
+	15, // 22: pricefeedrpc.PriceFeeder.GetExchangeRates:input_type -> pricefeedrpc.GetExchangeRatesRequest
+	17, // 23: pricefeedrpc.PriceFeeder.GetPortfolioSummary:input_type -> pricefeedrpc.GetPortfolioSummaryRequest
+	20, // 24: pricefeedrpc.PriceFeeder.SubscribeTicker:input_type -> pricefeedrpc.SubscribeTickerRequest
+	21, // 25: pricefeedrpc.PriceFeeder.SubscribeOrderbook:input_type -> pricefeedrpc.SubscribeOrderbookRequest
+	2,  // 26: pricefeedrpc.PriceFeeder.GetExchanges:output_type -> pricefeedrpc.GetExchangesResponse
+	5,  // 27: pricefeedrpc.PriceFeeder.GetTicker:output_type -> pricefeedrpc.TickerResponse
+	8,  // 28: pricefeedrpc.PriceFeeder.GetTickers:output_type -> pricefeedrpc.GetTickersResponse
+	11, // 29: pricefeedrpc.PriceFeeder.GetOrderbook:output_type -> pricefeedrpc.OrderbookResponse
+	14, // 30: pricefeedrpc.PriceFeeder.GetOrderbooks:output_type -> pricefeedrpc.GetOrderbooksResponse
+	16, // 31: pricefeedrpc.PriceFeeder.GetExchangeRates:output_type -> pricefeedrpc.GetExchangeRatesResponse
+	19, // 32: pricefeedrpc.PriceFeeder.GetPortfolioSummary:output_type -> pricefeedrpc.GetPortfolioSummaryResponse
+	5,  // 33: pricefeedrpc.PriceFeeder.SubscribeTicker:output_type -> pricefeedrpc.TickerResponse
+	11, // 34: pricefeedrpc.PriceFeeder.SubscribeOrderbook:output_type -> pricefeedrpc.OrderbookResponse
+	26, // [26:35] is the sub-list for method output_type
+	17, // [17:26] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_pricefeedrpc_proto_init() }
+func file_pricefeedrpc_proto_init() {
+	if File_pricefeedrpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pricefeedrpc_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExchangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ExchangeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExchangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CurrencyPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*TickerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTickersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Tickers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*GetTickersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*GetOrderbookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*OrderbookItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*OrderbookResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetOrderbooksRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Orderbooks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetOrderbooksResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExchangeRatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExchangeRatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPortfolioSummaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Coin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPortfolioSummaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pricefeedrpc_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeOrderbookRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pricefeedrpc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pricefeedrpc_proto_goTypes,
+		DependencyIndexes: file_pricefeedrpc_proto_depIdxs,
+		MessageInfos:      file_pricefeedrpc_proto_msgTypes,
+	}.Build()
+	File_pricefeedrpc_proto = out.File
+	file_pricefeedrpc_proto_rawDesc = nil
+	file_pricefeedrpc_proto_goTypes = nil
+	file_pricefeedrpc_proto_depIdxs = nil
+}
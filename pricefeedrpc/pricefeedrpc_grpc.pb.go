@@ -0,0 +1,460 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pricefeedrpc.proto
+
+package pricefeedrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PriceFeeder_GetExchanges_FullMethodName        = "/pricefeedrpc.PriceFeeder/GetExchanges"
+	PriceFeeder_GetTicker_FullMethodName           = "/pricefeedrpc.PriceFeeder/GetTicker"
+	PriceFeeder_GetTickers_FullMethodName          = "/pricefeedrpc.PriceFeeder/GetTickers"
+	PriceFeeder_GetOrderbook_FullMethodName        = "/pricefeedrpc.PriceFeeder/GetOrderbook"
+	PriceFeeder_GetOrderbooks_FullMethodName       = "/pricefeedrpc.PriceFeeder/GetOrderbooks"
+	PriceFeeder_GetExchangeRates_FullMethodName    = "/pricefeedrpc.PriceFeeder/GetExchangeRates"
+	PriceFeeder_GetPortfolioSummary_FullMethodName = "/pricefeedrpc.PriceFeeder/GetPortfolioSummary"
+	PriceFeeder_SubscribeTicker_FullMethodName     = "/pricefeedrpc.PriceFeeder/SubscribeTicker"
+	PriceFeeder_SubscribeOrderbook_FullMethodName  = "/pricefeedrpc.PriceFeeder/SubscribeOrderbook"
+)
+
+// PriceFeederClient is the client API for PriceFeeder service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PriceFeederClient interface {
+	GetExchanges(ctx context.Context, in *GetExchangesRequest, opts ...grpc.CallOption) (*GetExchangesResponse, error)
+	GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*TickerResponse, error)
+	GetTickers(ctx context.Context, in *GetTickersRequest, opts ...grpc.CallOption) (*GetTickersResponse, error)
+	GetOrderbook(ctx context.Context, in *GetOrderbookRequest, opts ...grpc.CallOption) (*OrderbookResponse, error)
+	GetOrderbooks(ctx context.Context, in *GetOrderbooksRequest, opts ...grpc.CallOption) (*GetOrderbooksResponse, error)
+	GetExchangeRates(ctx context.Context, in *GetExchangeRatesRequest, opts ...grpc.CallOption) (*GetExchangeRatesResponse, error)
+	GetPortfolioSummary(ctx context.Context, in *GetPortfolioSummaryRequest, opts ...grpc.CallOption) (*GetPortfolioSummaryResponse, error)
+	SubscribeTicker(ctx context.Context, in *SubscribeTickerRequest, opts ...grpc.CallOption) (PriceFeeder_SubscribeTickerClient, error)
+	SubscribeOrderbook(ctx context.Context, in *SubscribeOrderbookRequest, opts ...grpc.CallOption) (PriceFeeder_SubscribeOrderbookClient, error)
+}
+
+type priceFeederClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPriceFeederClient(cc grpc.ClientConnInterface) PriceFeederClient {
+	return &priceFeederClient{cc}
+}
+
+func (c *priceFeederClient) GetExchanges(ctx context.Context, in *GetExchangesRequest, opts ...grpc.CallOption) (*GetExchangesResponse, error) {
+	out := new(GetExchangesResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetExchanges_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetTicker(ctx context.Context, in *GetTickerRequest, opts ...grpc.CallOption) (*TickerResponse, error) {
+	out := new(TickerResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetTicker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetTickers(ctx context.Context, in *GetTickersRequest, opts ...grpc.CallOption) (*GetTickersResponse, error) {
+	out := new(GetTickersResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetTickers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetOrderbook(ctx context.Context, in *GetOrderbookRequest, opts ...grpc.CallOption) (*OrderbookResponse, error) {
+	out := new(OrderbookResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetOrderbook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetOrderbooks(ctx context.Context, in *GetOrderbooksRequest, opts ...grpc.CallOption) (*GetOrderbooksResponse, error) {
+	out := new(GetOrderbooksResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetOrderbooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetExchangeRates(ctx context.Context, in *GetExchangeRatesRequest, opts ...grpc.CallOption) (*GetExchangeRatesResponse, error) {
+	out := new(GetExchangeRatesResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetExchangeRates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) GetPortfolioSummary(ctx context.Context, in *GetPortfolioSummaryRequest, opts ...grpc.CallOption) (*GetPortfolioSummaryResponse, error) {
+	out := new(GetPortfolioSummaryResponse)
+	err := c.cc.Invoke(ctx, PriceFeeder_GetPortfolioSummary_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *priceFeederClient) SubscribeTicker(ctx context.Context, in *SubscribeTickerRequest, opts ...grpc.CallOption) (PriceFeeder_SubscribeTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PriceFeeder_ServiceDesc.Streams[0], PriceFeeder_SubscribeTicker_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &priceFeederSubscribeTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PriceFeeder_SubscribeTickerClient interface {
+	Recv() (*TickerResponse, error)
+	grpc.ClientStream
+}
+
+type priceFeederSubscribeTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *priceFeederSubscribeTickerClient) Recv() (*TickerResponse, error) {
+	m := new(TickerResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *priceFeederClient) SubscribeOrderbook(ctx context.Context, in *SubscribeOrderbookRequest, opts ...grpc.CallOption) (PriceFeeder_SubscribeOrderbookClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PriceFeeder_ServiceDesc.Streams[1], PriceFeeder_SubscribeOrderbook_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &priceFeederSubscribeOrderbookClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PriceFeeder_SubscribeOrderbookClient interface {
+	Recv() (*OrderbookResponse, error)
+	grpc.ClientStream
+}
+
+type priceFeederSubscribeOrderbookClient struct {
+	grpc.ClientStream
+}
+
+func (x *priceFeederSubscribeOrderbookClient) Recv() (*OrderbookResponse, error) {
+	m := new(OrderbookResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PriceFeederServer is the server API for PriceFeeder service.
+// All implementations must embed UnimplementedPriceFeederServer
+// for forward compatibility
+type PriceFeederServer interface {
+	GetExchanges(context.Context, *GetExchangesRequest) (*GetExchangesResponse, error)
+	GetTicker(context.Context, *GetTickerRequest) (*TickerResponse, error)
+	GetTickers(context.Context, *GetTickersRequest) (*GetTickersResponse, error)
+	GetOrderbook(context.Context, *GetOrderbookRequest) (*OrderbookResponse, error)
+	GetOrderbooks(context.Context, *GetOrderbooksRequest) (*GetOrderbooksResponse, error)
+	GetExchangeRates(context.Context, *GetExchangeRatesRequest) (*GetExchangeRatesResponse, error)
+	GetPortfolioSummary(context.Context, *GetPortfolioSummaryRequest) (*GetPortfolioSummaryResponse, error)
+	SubscribeTicker(*SubscribeTickerRequest, PriceFeeder_SubscribeTickerServer) error
+	SubscribeOrderbook(*SubscribeOrderbookRequest, PriceFeeder_SubscribeOrderbookServer) error
+	mustEmbedUnimplementedPriceFeederServer()
+}
+
+// UnimplementedPriceFeederServer must be embedded to have forward compatible implementations.
+type UnimplementedPriceFeederServer struct {
+}
+
+func (UnimplementedPriceFeederServer) GetExchanges(context.Context, *GetExchangesRequest) (*GetExchangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchanges not implemented")
+}
+func (UnimplementedPriceFeederServer) GetTicker(context.Context, *GetTickerRequest) (*TickerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicker not implemented")
+}
+func (UnimplementedPriceFeederServer) GetTickers(context.Context, *GetTickersRequest) (*GetTickersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTickers not implemented")
+}
+func (UnimplementedPriceFeederServer) GetOrderbook(context.Context, *GetOrderbookRequest) (*OrderbookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbook not implemented")
+}
+func (UnimplementedPriceFeederServer) GetOrderbooks(context.Context, *GetOrderbooksRequest) (*GetOrderbooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbooks not implemented")
+}
+func (UnimplementedPriceFeederServer) GetExchangeRates(context.Context, *GetExchangeRatesRequest) (*GetExchangeRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeRates not implemented")
+}
+func (UnimplementedPriceFeederServer) GetPortfolioSummary(context.Context, *GetPortfolioSummaryRequest) (*GetPortfolioSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioSummary not implemented")
+}
+func (UnimplementedPriceFeederServer) SubscribeTicker(*SubscribeTickerRequest, PriceFeeder_SubscribeTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeTicker not implemented")
+}
+func (UnimplementedPriceFeederServer) SubscribeOrderbook(*SubscribeOrderbookRequest, PriceFeeder_SubscribeOrderbookServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeOrderbook not implemented")
+}
+func (UnimplementedPriceFeederServer) mustEmbedUnimplementedPriceFeederServer() {}
+
+// UnsafePriceFeederServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PriceFeederServer will
+// result in compilation errors.
+type UnsafePriceFeederServer interface {
+	mustEmbedUnimplementedPriceFeederServer()
+}
+
+func RegisterPriceFeederServer(s grpc.ServiceRegistrar, srv PriceFeederServer) {
+	s.RegisterService(&PriceFeeder_ServiceDesc, srv)
+}
+
+func _PriceFeeder_GetExchanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExchangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetExchanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetExchanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetExchanges(ctx, req.(*GetExchangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetTicker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTickerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetTicker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetTicker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetTicker(ctx, req.(*GetTickerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetTickers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTickersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetTickers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetTickers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetTickers(ctx, req.(*GetTickersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetOrderbook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderbookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetOrderbook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetOrderbook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetOrderbook(ctx, req.(*GetOrderbookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetOrderbooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderbooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetOrderbooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetOrderbooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetOrderbooks(ctx, req.(*GetOrderbooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetExchangeRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetExchangeRates(ctx, req.(*GetExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_GetPortfolioSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PriceFeederServer).GetPortfolioSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PriceFeeder_GetPortfolioSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PriceFeederServer).GetPortfolioSummary(ctx, req.(*GetPortfolioSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PriceFeeder_SubscribeTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PriceFeederServer).SubscribeTicker(m, &priceFeederSubscribeTickerServer{stream})
+}
+
+type PriceFeeder_SubscribeTickerServer interface {
+	Send(*TickerResponse) error
+	grpc.ServerStream
+}
+
+type priceFeederSubscribeTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *priceFeederSubscribeTickerServer) Send(m *TickerResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PriceFeeder_SubscribeOrderbook_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeOrderbookRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PriceFeederServer).SubscribeOrderbook(m, &priceFeederSubscribeOrderbookServer{stream})
+}
+
+type PriceFeeder_SubscribeOrderbookServer interface {
+	Send(*OrderbookResponse) error
+	grpc.ServerStream
+}
+
+type priceFeederSubscribeOrderbookServer struct {
+	grpc.ServerStream
+}
+
+func (x *priceFeederSubscribeOrderbookServer) Send(m *OrderbookResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PriceFeeder_ServiceDesc is the grpc.ServiceDesc for PriceFeeder service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PriceFeeder_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pricefeedrpc.PriceFeeder",
+	HandlerType: (*PriceFeederServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExchanges",
+			Handler:    _PriceFeeder_GetExchanges_Handler,
+		},
+		{
+			MethodName: "GetTicker",
+			Handler:    _PriceFeeder_GetTicker_Handler,
+		},
+		{
+			MethodName: "GetTickers",
+			Handler:    _PriceFeeder_GetTickers_Handler,
+		},
+		{
+			MethodName: "GetOrderbook",
+			Handler:    _PriceFeeder_GetOrderbook_Handler,
+		},
+		{
+			MethodName: "GetOrderbooks",
+			Handler:    _PriceFeeder_GetOrderbooks_Handler,
+		},
+		{
+			MethodName: "GetExchangeRates",
+			Handler:    _PriceFeeder_GetExchangeRates_Handler,
+		},
+		{
+			MethodName: "GetPortfolioSummary",
+			Handler:    _PriceFeeder_GetPortfolioSummary_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTicker",
+			Handler:       _PriceFeeder_SubscribeTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeOrderbook",
+			Handler:       _PriceFeeder_SubscribeOrderbook_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pricefeedrpc.proto",
+}
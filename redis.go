@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/cache"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the Redis cache integration
+const (
+	redisFeedBufferSize = 4096
+)
+
+var (
+	redisHealthy    = true
+	redisHealthyMtx sync.Mutex
+)
+
+// StartRedisCache connects the Redis shared cache, restores the ticker store
+// and FX rates when warm start is enabled and mirrors subsequent ticker and
+// reference price updates
+func StartRedisCache() {
+	if !bot.Config.Redis.Enabled {
+		return
+	}
+
+	bot.Cache = cache.New(bot.Config.Redis)
+	err := bot.Cache.Ping()
+	if err != nil {
+		log.Printf("Redis: unable to connect to %s, retrying on the next update. Err: %s",
+			bot.Config.Redis.Address, err)
+		redisHealthy = false
+	} else {
+		log.Printf("Redis: connected to %s.", bot.Config.Redis.Address)
+		if bot.Config.Redis.WarmStart {
+			WarmStartFromRedis()
+		}
+	}
+
+	go redisTickerDispatcher(SubscribeFeed(redisFeedBufferSize))
+}
+
+// WarmStartFromRedis restores the unexpired tickers of the loaded exchanges
+// and the FX rates from Redis
+func WarmStartFromRedis() {
+	entries, err := bot.Cache.Tickers()
+	if err != nil {
+		log.Printf("Redis: failed to restore tickers. Err: %s", err)
+	}
+
+	var restored int
+	for x := range entries {
+		exch := GetExchangeByName(bot, entries[x].Exchange)
+		if exch == nil || !exch.IsEnabled() {
+			continue
+		}
+
+		price := entries[x].Price()
+		if time.Since(price.LastUpdated) > bot.Config.Redis.TickerTTL {
+			continue
+		}
+
+		ticker.RestoreTicker(exch.GetName(), price.Pair, price, entries[x].AssetType)
+		restored++
+	}
+
+	rates, err := bot.Cache.FXRates()
+	if err != nil {
+		log.Printf("Redis: failed to restore FX rates. Err: %s", err)
+	}
+
+	if len(rates) > 0 {
+		currency.SetExchangeRates(rates, time.Time{})
+	}
+	log.Printf("Redis: restored %d ticker/s and %d FX rate/s.", restored, len(rates))
+}
+
+// reportRedisError logs Redis failures and recoveries without repeating the
+// error for every update during an outage
+func reportRedisError(err error) {
+	redisHealthyMtx.Lock()
+	defer redisHealthyMtx.Unlock()
+
+	if err != nil && redisHealthy {
+		log.Printf("Redis: failed to update the cache. Err: %s", err)
+	} else if err == nil && !redisHealthy {
+		log.Println("Redis: connection restored.")
+	}
+	redisHealthy = err == nil
+}
+
+// MirrorFXRates writes the current FX rates to Redis if the cache is enabled
+func MirrorFXRates() {
+	if bot.Cache == nil {
+		return
+	}
+	reportRedisError(bot.Cache.SetFXRates(currency.GetExchangeRates()))
+}
+
+// redisTickerDispatcher mirrors each ticker update and the reference price of
+// its pair into Redis
+func redisTickerDispatcher(feed chan WebsocketEvent) {
+	for evt := range feed {
+		t, ok := evt.Data.(ticker.Price)
+		if !ok {
+			continue
+		}
+
+		err := bot.Cache.SetTicker(cache.NewTickerEntry(evt.Exchange, evt.AssetType, t))
+		if err == nil {
+			err = mirrorReferencePrice(t)
+		}
+		reportRedisError(err)
+	}
+}
+
+// mirrorReferencePrice writes the reference price of the ticker pair, pairs
+// without fresh tickers on enabled exchanges are skipped
+func mirrorReferencePrice(t ticker.Price) error {
+	price, sources, err := GetAdapterPrice(bot, t.Pair.FirstCurrency.String(),
+		t.Pair.SecondCurrency.String(), "", "", bot.Config.Redis.TickerTTL)
+	if err != nil {
+		return nil
+	}
+
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return err
+	}
+
+	return bot.Cache.SetReferencePrice(cache.ReferencePriceEntry{
+		Pair:    normaliseTopicPair(t.Pair),
+		Price:   price,
+		Sources: data,
+		Updated: time.Now().UnixNano() / int64(time.Millisecond),
+	})
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	Bot "github.com/trustfeed/go-crypto-pricefeeder/bot"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/translation"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the external adapter
+const (
+	AdapterStatusErrored = "errored"
+)
+
+// Vars for the external adapter
+var (
+	errAdapterInvalidRequest = errors.New("from and to currencies must be supplied and differ")
+	errAdapterPairNotFound   = errors.New("no ticker data found for requested pair")
+	errAdapterStaleData      = errors.New("ticker data for requested pair is stale")
+)
+
+// AdapterRequest is the request body sent by an oracle node to an external
+// adapter
+type AdapterRequest struct {
+	ID   string             `json:"id"`
+	Data AdapterRequestData `json:"data"`
+}
+
+// AdapterRequestData holds the requested pair and optional filters, base and
+// quote are accepted as aliases for from and to
+type AdapterRequestData struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Base      string `json:"base"`
+	Quote     string `json:"quote"`
+	Exchange  string `json:"exchange"`
+	AssetType string `json:"assetType"`
+}
+
+// AdapterResponse is the response body returned to an oracle node
+type AdapterResponse struct {
+	JobRunID   string               `json:"jobRunID"`
+	Data       *AdapterResponseData `json:"data,omitempty"`
+	Result     float64              `json:"result,omitempty"`
+	Status     string               `json:"status,omitempty"`
+	Error      string               `json:"error,omitempty"`
+	StatusCode int                  `json:"statusCode"`
+}
+
+// AdapterResponseData holds the resolved price and the tickers it was derived
+// from
+type AdapterResponseData struct {
+	Result  float64              `json:"result"`
+	Sources []AdapterPriceSource `json:"sources"`
+}
+
+// AdapterPriceSource is an individual exchange ticker used to derive an
+// adapter result
+type AdapterPriceSource struct {
+	Exchange    string    `json:"exchange"`
+	Pair        string    `json:"pair"`
+	Price       float64   `json:"price"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// isAdapterCurrency returns whether or not the supplied currency matches the
+// target currency or its translation (e.g XBT -> BTC)
+func isAdapterCurrency(c, target pair.CurrencyItem) bool {
+	if c.Upper() == target.Upper() {
+		return true
+	}
+
+	result, err := translation.GetTranslation(target.Upper())
+	if err != nil {
+		return false
+	}
+	return c.Upper() == result
+}
+
+// adapterQuote derives the price of the target pair from a ticker on pair p,
+// inverting the ticker or converting through the forex rates if required
+func adapterQuote(p pair.CurrencyPair, last float64, target pair.CurrencyPair, relatable []pair.CurrencyPair) (float64, bool) {
+	if pair.Contains(relatable, p, true) {
+		if isAdapterCurrency(p.FirstCurrency, target.FirstCurrency) {
+			return last, true
+		}
+		return 1 / last, true
+	}
+
+	if !currency.IsFiatCurrency(p.SecondCurrency.String()) {
+		return 0, false
+	}
+
+	from := target.FirstCurrency.String()
+	to := target.SecondCurrency.String()
+
+	if currency.IsFiatCurrency(to) && isAdapterCurrency(p.FirstCurrency, target.FirstCurrency) {
+		conv, err := currency.ConvertCurrency(last, p.SecondCurrency.String(), to)
+		if err != nil {
+			log.Printf("Adapter: failed to convert %s to %s. Error: %s",
+				p.SecondCurrency, to, err)
+			return 0, false
+		}
+		return conv, true
+	}
+
+	if currency.IsFiatCurrency(from) && isAdapterCurrency(p.FirstCurrency, target.SecondCurrency) {
+		conv, err := currency.ConvertCurrency(last, p.SecondCurrency.String(), from)
+		if err != nil || conv == 0 {
+			log.Printf("Adapter: failed to convert %s to %s. Error: %v",
+				p.SecondCurrency, from, err)
+			return 0, false
+		}
+		return 1 / conv, true
+	}
+	return 0, false
+}
+
+// adapterMedian returns the median of the supplied source prices
+func adapterMedian(sources []AdapterPriceSource) float64 {
+	var prices []float64
+	for x := range sources {
+		prices = append(prices, sources[x].Price)
+	}
+	sort.Float64s(prices)
+
+	mid := len(prices) / 2
+	if len(prices)%2 == 0 {
+		return (prices[mid-1] + prices[mid]) / 2
+	}
+	return prices[mid]
+}
+
+// GetAdapterPrice resolves the price of from denominated in to using the
+// stored tickers of all enabled exchanges (or a specific exchange if
+// exchangeName is set). Tickers older than maxAge are ignored and the median
+// of the remaining prices is returned along with the tickers used
+func GetAdapterPrice(bot Bot.Bot, from, to, exchangeName, assetType string, maxAge time.Duration) (float64, []AdapterPriceSource, error) {
+	from = common.StringToUpper(from)
+	to = common.StringToUpper(to)
+	if from == "" || to == "" || from == to {
+		return 0, nil, errAdapterInvalidRequest
+	}
+
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+
+	target := pair.NewCurrencyPair(from, to)
+	relatable := GetRelatableCurrencies(target, true,
+		pair.ContainsCurrency(target, "USDT"))
+
+	var sources []AdapterPriceSource
+	var stale int
+	for x := range bot.Exchanges {
+		if bot.Exchanges[x] == nil || !bot.Exchanges[x].IsEnabled() {
+			continue
+		}
+
+		exchName := bot.Exchanges[x].GetName()
+		if exchangeName != "" && common.StringToLower(exchName) != common.StringToLower(exchangeName) {
+			continue
+		}
+
+		enabledCurrencies := bot.Exchanges[x].GetEnabledCurrencies()
+		for y := range enabledCurrencies {
+			p := enabledCurrencies[y]
+			tickerPrice, err := ticker.GetTicker(exchName, p, assetType)
+			if err != nil || tickerPrice.Last == 0 {
+				continue
+			}
+
+			result, ok := adapterQuote(p, tickerPrice.Last, target, relatable)
+			if !ok {
+				continue
+			}
+
+			if time.Since(tickerPrice.LastUpdated) > maxAge {
+				stale++
+				continue
+			}
+
+			sources = append(sources, AdapterPriceSource{
+				Exchange:    exchName,
+				Pair:        p.Pair().String(),
+				Price:       result,
+				LastUpdated: tickerPrice.LastUpdated,
+			})
+		}
+	}
+
+	if len(sources) == 0 {
+		if stale > 0 {
+			log.Printf("Adapter: %d %s ticker/s older than %v", stale,
+				target.Pair(), maxAge)
+			return 0, nil, errAdapterStaleData
+		}
+		return 0, nil, errAdapterPairNotFound
+	}
+	return adapterMedian(sources), sources, nil
+}
+
+// RESTfulAdapterResponse outputs an external adapter JSON response using its
+// status code
+func RESTfulAdapterResponse(w http.ResponseWriter, resp AdapterResponse) error {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(resp.StatusCode)
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// RESTfulAdapterError outputs an external adapter error response
+func RESTfulAdapterError(w http.ResponseWriter, r *http.Request, jobRunID string, statusCode int, err error) {
+	resp := AdapterResponse{
+		JobRunID:   jobRunID,
+		Status:     AdapterStatusErrored,
+		Error:      err.Error(),
+		StatusCode: statusCode,
+	}
+
+	err = RESTfulAdapterResponse(w, resp)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTExternalAdapter resolves a price request from an oracle node against the
+// live ticker data and replies in the external adapter format
+func RESTExternalAdapter(w http.ResponseWriter, r *http.Request) {
+	var req AdapterRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		RESTfulAdapterError(w, r, req.ID, http.StatusBadRequest, err)
+		return
+	}
+
+	from := req.Data.From
+	if from == "" {
+		from = req.Data.Base
+	}
+
+	to := req.Data.To
+	if to == "" {
+		to = req.Data.Quote
+	}
+
+	result, sources, err := GetAdapterPrice(bot, from, to, req.Data.Exchange,
+		req.Data.AssetType, bot.Config.Webserver.AdapterTickerMaxAge)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case errAdapterInvalidRequest:
+			statusCode = http.StatusBadRequest
+		case errAdapterPairNotFound:
+			statusCode = http.StatusNotFound
+		case errAdapterStaleData:
+			statusCode = http.StatusServiceUnavailable
+		}
+		RESTfulAdapterError(w, r, req.ID, statusCode, err)
+		return
+	}
+
+	resp := AdapterResponse{
+		JobRunID: req.ID,
+		Data: &AdapterResponseData{
+			Result:  result,
+			Sources: sources,
+		},
+		Result:     result,
+		StatusCode: http.StatusOK,
+	}
+
+	err = RESTfulAdapterResponse(w, resp)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
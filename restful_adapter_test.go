@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/forexprovider"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	exchange "github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/bitstamp"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/gdax"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/kraken"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func TestRESTExternalAdapter(t *testing.T) {
+	backupConfig, backupExchanges := bot.Config, bot.Exchanges
+	backupFiat, backupProviders := currency.FiatCurrencies, currency.FXProviders
+	defer func() {
+		bot.Config, bot.Exchanges = backupConfig, backupExchanges
+		currency.FiatCurrencies, currency.FXProviders = backupFiat, backupProviders
+	}()
+
+	bot.Config = &config.Config{}
+	bot.Config.Webserver.AdapterTickerMaxAge = time.Minute
+	currency.FiatCurrencies = []string{"USD", "EUR"}
+	// Avoid the defaults being seeded from the network
+	currency.FXProviders = new(forexprovider.ForexProviders)
+	currency.SetExchangeRates(map[string]float64{"USDEUR": 0.8}, time.Now())
+
+	b := new(bitstamp.Bitstamp)
+	b.Name, b.Enabled, b.EnabledPairs = "Bitstamp", true, []string{"BTCUSD", "LTCUSD"}
+	g := new(gdax.GDAX)
+	g.Name, g.Enabled, g.EnabledPairs = "GDAX", true, []string{"BTCUSD"}
+	k := new(kraken.Kraken)
+	k.Name, k.Enabled, k.EnabledPairs = "Kraken", true, []string{"XBTUSD"}
+	bot.Exchanges = []exchange.IBotExchange{b, g, k}
+
+	now := time.Now()
+	ticker.RestoreTicker("Bitstamp", pair.NewCurrencyPair("BTC", "USD"),
+		ticker.Price{Last: 1000, LastUpdated: now}, ticker.Spot)
+	ticker.RestoreTicker("GDAX", pair.NewCurrencyPair("BTC", "USD"),
+		ticker.Price{Last: 1010, LastUpdated: now}, ticker.Spot)
+	ticker.RestoreTicker("Kraken", pair.NewCurrencyPair("XBT", "USD"),
+		ticker.Price{Last: 1030, LastUpdated: now}, ticker.Spot)
+	ticker.RestoreTicker("Bitstamp", pair.NewCurrencyPair("LTC", "USD"),
+		ticker.Price{Last: 100, LastUpdated: now.Add(-time.Hour)}, ticker.Spot)
+
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int
+		result     float64
+		sources    int
+		err        string
+	}{
+		{"median", `{"id":"1","data":{"from":"BTC","to":"USD"}}`, http.StatusOK, 1010, 3, ""},
+		{"aliases", `{"id":"2","data":{"base":"xbt","quote":"usd"}}`, http.StatusOK, 1010, 3, ""},
+		{"inversion", `{"id":"3","data":{"from":"USD","to":"BTC"}}`, http.StatusOK, 1 / 1010.0, 3, ""},
+		{"fx conversion", `{"id":"4","data":{"from":"BTC","to":"EUR"}}`, http.StatusOK, 808, 3, ""},
+		{"fx inversion", `{"id":"5","data":{"from":"EUR","to":"BTC","exchange":"gdax"}}`, http.StatusOK, 1 / 808.0, 1, ""},
+		{"exchange filter", `{"id":"6","data":{"from":"BTC","to":"USD","exchange":"Kraken"}}`, http.StatusOK, 1030, 1, ""},
+		{"stale", `{"id":"7","data":{"from":"LTC","to":"USD"}}`, http.StatusServiceUnavailable, 0, 0, errAdapterStaleData.Error()},
+		{"not found", `{"id":"8","data":{"from":"DOGE","to":"USD"}}`, http.StatusNotFound, 0, 0, errAdapterPairNotFound.Error()},
+		{"invalid pair", `{"id":"9","data":{"from":"BTC","to":"btc"}}`, http.StatusBadRequest, 0, 0, errAdapterInvalidRequest.Error()},
+		{"invalid body", `{"id":`, http.StatusBadRequest, 0, 0, "unexpected EOF"},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		RESTExternalAdapter(w, httptest.NewRequest("POST", "/adapter", strings.NewReader(test.body)))
+
+		var resp AdapterResponse
+		err := json.NewDecoder(w.Body).Decode(&resp)
+		if err != nil {
+			t.Fatalf("Test failed. %s: unable to decode response %s", test.name, err)
+		}
+
+		if w.Code != test.statusCode || resp.StatusCode != test.statusCode {
+			t.Errorf("Test failed. %s: expected status %d got %d (statusCode %d)",
+				test.name, test.statusCode, w.Code, resp.StatusCode)
+			continue
+		}
+
+		if test.err != "" {
+			var req AdapterRequest
+			json.Unmarshal([]byte(test.body), &req)
+			if resp.Status != AdapterStatusErrored || resp.Error != test.err ||
+				resp.JobRunID != req.ID || resp.Data != nil {
+				t.Errorf("Test failed. %s: unexpected error response %+v", test.name, resp)
+			}
+			continue
+		}
+
+		if math.Abs(resp.Result-test.result) > 1e-9 || resp.Data == nil ||
+			resp.Data.Result != resp.Result || len(resp.Data.Sources) != test.sources ||
+			resp.Status != "" || resp.Error != "" {
+			t.Errorf("Test failed. %s: unexpected response %+v", test.name, resp)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges"
 )
 
@@ -33,6 +34,7 @@ type Route struct {
 	Method      string
 	Pattern     string
 	HandlerFunc http.HandlerFunc
+	Scope       string
 }
 
 // Routes is an array of all the registered routes
@@ -51,66 +53,224 @@ func NewRouter(exchanges []exchange.IBotExchange) *mux.Router {
 			"GET",
 			"/",
 			getIndex,
+			"",
 		},
 		Route{
 			"GetAllSettings",
 			"GET",
 			"/config/all",
 			RESTGetAllSettings,
+			config.APIScopeAdmin,
 		},
 		Route{
 			"SaveAllSettings",
 			"POST",
 			"/config/all/save",
 			RESTSaveAllSettings,
+			config.APIScopeAdmin,
 		},
 		Route{
 			"AllEnabledAccountInfo",
 			"GET",
 			"/exchanges/enabled/accounts/all",
 			RESTGetAllEnabledAccountInfo,
+			config.APIScopeReadPortfolio,
 		},
 		Route{
 			"AllActiveExchangesAndCurrencies",
 			"GET",
 			"/exchanges/enabled/latest/all",
 			RESTGetAllActiveTickers,
+			config.APIScopeReadMarket,
 		},
 		Route{
 			"IndividualExchangeAndCurrency",
 			"GET",
 			"/exchanges/{exchangeName}/latest/{currency}",
 			RESTGetTicker,
+			config.APIScopeReadMarket,
 		},
 		Route{
 			"GetPortfolio",
 			"GET",
 			"/portfolio/all",
 			RESTGetPortfolio,
+			config.APIScopeReadPortfolio,
 		},
 		Route{
 			"AllActiveExchangesAndOrderbooks",
 			"GET",
 			"/exchanges/orderbook/latest/all",
 			RESTGetAllActiveOrderbooks,
+			config.APIScopeReadMarket,
 		},
 		Route{
 			"IndividualExchangeOrderbook",
 			"GET",
 			"/exchanges/{exchangeName}/orderbook/latest/{currency}",
 			RESTGetOrderbook,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"Login",
+			"POST",
+			"/auth/login",
+			RESTLogin,
+			"",
+		},
+		Route{
+			"ExternalAdapter",
+			"POST",
+			"/adapter",
+			RESTExternalAdapter,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2OpenAPI",
+			"GET",
+			RESTv2Prefix + "/openapi.json",
+			RESTv2GetOpenAPI,
+			"",
+		},
+		Route{
+			"V2GetExchanges",
+			"GET",
+			RESTv2Prefix + "/exchanges",
+			RESTv2GetExchanges,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetTickers",
+			"GET",
+			RESTv2Prefix + "/tickers",
+			RESTv2GetTickers,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetTicker",
+			"GET",
+			RESTv2Prefix + "/exchanges/{exchangeName}/tickers/{currency}",
+			RESTv2GetTicker,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetOrderbooks",
+			"GET",
+			RESTv2Prefix + "/orderbooks",
+			RESTv2GetOrderbooks,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetOrderbook",
+			"GET",
+			RESTv2Prefix + "/exchanges/{exchangeName}/orderbooks/{currency}",
+			RESTv2GetOrderbook,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetPortfolio",
+			"GET",
+			RESTv2Prefix + "/portfolio",
+			RESTv2GetPortfolio,
+			config.APIScopeReadPortfolio,
+		},
+		Route{
+			"V2GetWebhookDeliveries",
+			"GET",
+			RESTv2Prefix + "/webhooks/deliveries",
+			RESTv2GetWebhookDeliveries,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2GetArbitrageSpreads",
+			"GET",
+			RESTv2Prefix + "/arbitrage/spreads",
+			RESTv2GetArbitrageSpreads,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetArbitrageOpportunities",
+			"GET",
+			RESTv2Prefix + "/arbitrage/opportunities",
+			RESTv2GetArbitrageOpportunities,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetFXRates",
+			"GET",
+			RESTv2Prefix + "/fx/rates",
+			RESTv2GetFXRates,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetSyntheticPrice",
+			"GET",
+			RESTv2Prefix + "/synthetic/{currency}",
+			RESTv2GetSyntheticPrice,
+			config.APIScopeReadMarket,
+		},
+		Route{
+			"V2GetEvents",
+			"GET",
+			RESTv2Prefix + "/events",
+			RESTv2GetEvents,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2CreateEvent",
+			"POST",
+			RESTv2Prefix + "/events",
+			RESTv2CreateEvent,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2DeleteEvent",
+			"DELETE",
+			RESTv2Prefix + "/events/{id}",
+			RESTv2DeleteEvent,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2CreateTelegramPairingCode",
+			"POST",
+			RESTv2Prefix + "/telegram/pairing",
+			RESTv2CreateTelegramPairingCode,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2GetTelegramClients",
+			"GET",
+			RESTv2Prefix + "/telegram/clients",
+			RESTv2GetTelegramClients,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"V2DeleteTelegramClient",
+			"DELETE",
+			RESTv2Prefix + "/telegram/clients/{id}",
+			RESTv2DeleteTelegramClient,
+			config.APIScopeAdmin,
+		},
+		Route{
+			"Stream",
+			"GET",
+			"/stream",
+			RESTStream,
+			config.APIScopeReadMarket,
 		},
 		Route{
 			"ws",
 			"GET",
 			"/ws",
 			WebsocketClientHandler,
+			"",
 		},
 	}
 
 	for _, route := range routes {
 		var handler http.Handler
 		handler = route.HandlerFunc
+		handler = RESTAuth(handler, route.Scope)
 		handler = RESTLogger(handler, route.Name)
 
 		router.
@@ -119,6 +279,7 @@ func NewRouter(exchanges []exchange.IBotExchange) *mux.Router {
 			Name(route.Name).
 			Handler(handler)
 	}
+	router.NotFoundHandler = http.HandlerFunc(RESTv2NotFound)
 	return router
 }
 
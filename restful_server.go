@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
 	exchange "github.com/trustfeed/go-crypto-pricefeeder/exchanges"
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
@@ -55,9 +56,9 @@ func RESTfulError(method string, err error) {
 }
 
 // RESTGetAllSettings replies to a request with an encoded JSON response about the
-// trading bots configuration.
+// trading bots configuration with its secrets redacted.
 func RESTGetAllSettings(w http.ResponseWriter, r *http.Request) {
-	err := RESTfulJSONResponse(w, r, bot.Config)
+	err := RESTfulJSONResponse(w, r, bot.Config.RedactSecrets())
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
@@ -79,12 +80,13 @@ func RESTSaveAllSettings(w http.ResponseWriter, r *http.Request) {
 		RESTfulError(r.Method, err)
 	}
 
-	err = RESTfulJSONResponse(w, r, bot.Config)
+	err = RESTfulJSONResponse(w, r, bot.Config.RedactSecrets())
 	if err != nil {
 		RESTfulError(r.Method, err)
 	}
 
 	SetupExchanges(bot)
+	ReloadEvents()
 }
 
 // RESTGetOrderbook returns orderbook info for a given currency, exchange and
@@ -112,6 +114,45 @@ func RESTGetOrderbook(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ActiveAssetPair is an enabled currency pair and asset type for an enabled
+// exchange
+type ActiveAssetPair struct {
+	Exchange  exchange.IBotExchange
+	AssetType string
+	Pair      pair.CurrencyPair
+}
+
+// GetActiveAssetPairs returns every enabled currency pair for each asset type
+// supported by the enabled exchanges
+func GetActiveAssetPairs() []ActiveAssetPair {
+	var activePairs []ActiveAssetPair
+	for _, individualBot := range bot.Exchanges {
+		if individualBot == nil || !individualBot.IsEnabled() {
+			continue
+		}
+
+		exchangeName := individualBot.GetName()
+		assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
+		if err != nil {
+			log.Printf("failed to get %s exchange asset types. Error: %s",
+				exchangeName, err)
+			continue
+		}
+
+		currencies := individualBot.GetEnabledCurrencies()
+		for x := range assetTypes {
+			for y := range currencies {
+				activePairs = append(activePairs, ActiveAssetPair{
+					Exchange:  individualBot,
+					AssetType: assetTypes[x],
+					Pair:      currencies[y],
+				})
+			}
+		}
+	}
+	return activePairs
+}
+
 // GetAllActiveOrderbooks returns all enabled exchanges orderbooks
 func GetAllActiveOrderbooks() []EnabledExchangeOrderbooks {
 	var orderbookData []EnabledExchangeOrderbooks
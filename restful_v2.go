@@ -0,0 +1,886 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	exchange "github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the v2 RESTful API
+const (
+	RESTv2Prefix       = "/v2"
+	RESTv2DefaultLimit = 100
+	RESTv2MaxLimit     = 1000
+
+	restV2QueryExchange  = "exchange"
+	restV2QueryAssetType = "assetType"
+	restV2QueryPair      = "pair"
+	restV2QueryLimit     = "limit"
+	restV2QueryOffset    = "offset"
+	restV2QueryFields    = "fields"
+)
+
+// Vars for the v2 RESTful API
+var (
+	errRESTv2InvalidPair      = errors.New("invalid currency pair, expected format BTCUSD, BTC-USD or BTC_USD")
+	errRESTv2InvalidLimit     = fmt.Errorf("limit must be between 1 and %d", RESTv2MaxLimit)
+	errRESTv2InvalidOffset    = errors.New("offset must be a positive integer")
+	errRESTv2ExchangeNotFound = errors.New("exchange not found or not enabled")
+	errRESTv2RouteNotFound    = errors.New("route not found")
+
+	restV2PathParam = regexp.MustCompile(`{([^}:]+)(:[^}]+)?}`)
+
+	restV2QueryDescriptions = map[string]string{
+		restV2QueryExchange:  "Filter by exchange name",
+		restV2QueryAssetType: "Filter by asset type (e.g SPOT)",
+		restV2QueryPair:      "Filter by currency pair (e.g BTCUSD, BTC-USD)",
+		restV2QueryLimit:     fmt.Sprintf("Maximum number of items to return (default %d, max %d)", RESTv2DefaultLimit, RESTv2MaxLimit),
+		restV2QueryOffset:    "Number of items to skip",
+		restV2QueryFields:    "Comma separated list of fields to return",
+		streamQueryEvents:    "Comma separated list of events to stream (ticker, orderbook)",
+		streamQueryLastEventID: "Resume after this event ID, the Last-Event-ID header takes " +
+			"precedence. A reset event is sent if events after it are no longer buffered",
+		webhookQueryName:   "Filter by webhook name",
+		syntheticQueryUSDT: "Treat USD and USDT as the same currency when converting (true or false)",
+		// The status filter is shared by the webhook deliveries and arbitrage
+		// opportunities
+		webhookQueryStatus: "Filter by status (pending, delivered or failed deliveries, open or " +
+			"closed opportunities)",
+	}
+)
+
+// RouteDoc holds the documentation of a route used to generate the OpenAPI
+// document
+type RouteDoc struct {
+	Summary     string
+	QueryParams []string
+}
+
+// routeDocs documents the routes by route name
+var routeDocs = map[string]RouteDoc{
+	"GetAllSettings":                  {Summary: "Returns the bot configuration"},
+	"SaveAllSettings":                 {Summary: "Saves and reloads the bot configuration"},
+	"AllEnabledAccountInfo":           {Summary: "Returns the account info of all enabled exchanges"},
+	"AllActiveExchangesAndCurrencies": {Summary: "Returns the tickers of all enabled exchanges"},
+	"IndividualExchangeAndCurrency":   {Summary: "Returns the ticker of an exchange currency pair"},
+	"GetPortfolio":                    {Summary: "Returns the portfolio summary"},
+	"AllActiveExchangesAndOrderbooks": {Summary: "Returns the orderbooks of all enabled exchanges"},
+	"IndividualExchangeOrderbook":     {Summary: "Returns the orderbook of an exchange currency pair"},
+	"Login":                           {Summary: "Returns an expiring session token for the admin credentials"},
+	"ExternalAdapter":                 {Summary: "Resolves an oracle node external adapter price request"},
+	"ws":                              {Summary: "Websocket connection endpoint"},
+	"Stream": {
+		Summary:     "Streams ticker and orderbook updates as server-sent events",
+		QueryParams: []string{restV2QueryExchange, restV2QueryAssetType, restV2QueryPair, streamQueryEvents, streamQueryLastEventID},
+	},
+	"V2OpenAPI": {Summary: "Returns the OpenAPI document of the RESTful API"},
+	"V2GetExchanges": {
+		Summary:     "Returns the loaded exchanges",
+		QueryParams: []string{restV2QueryExchange, restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetTickers": {
+		Summary:     "Returns the tickers of all enabled exchanges",
+		QueryParams: []string{restV2QueryExchange, restV2QueryAssetType, restV2QueryPair, restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetTicker": {
+		Summary:     "Returns the ticker of an exchange currency pair",
+		QueryParams: []string{restV2QueryAssetType, restV2QueryFields},
+	},
+	"V2GetOrderbooks": {
+		Summary:     "Returns the orderbooks of all enabled exchanges",
+		QueryParams: []string{restV2QueryExchange, restV2QueryAssetType, restV2QueryPair, restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetOrderbook": {
+		Summary:     "Returns the orderbook of an exchange currency pair",
+		QueryParams: []string{restV2QueryAssetType, restV2QueryFields},
+	},
+	"V2GetPortfolio": {
+		Summary:     "Returns the portfolio summary",
+		QueryParams: []string{restV2QueryFields},
+	},
+	"V2GetWebhookDeliveries": {
+		Summary:     "Returns the pending and completed webhook deliveries",
+		QueryParams: []string{webhookQueryName, webhookQueryStatus, restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetArbitrageSpreads": {
+		Summary:     "Returns the best cross-exchange spread of each market after taker fees",
+		QueryParams: []string{restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetArbitrageOpportunities": {
+		Summary:     "Returns the open and closed arbitrage opportunities",
+		QueryParams: []string{arbitrageQueryStatus, restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2GetFXRates": {
+		Summary:     "Returns the FX rates with the time each was fetched, whether they are stale and the rates of each provider in consensus mode",
+		QueryParams: []string{restV2QueryFields},
+	},
+	"V2GetSyntheticPrice": {
+		Summary:     "Returns the price of a currency pair converted along the best path across the tickers and FX rates",
+		QueryParams: []string{restV2QueryExchange, syntheticQueryUSDT, restV2QueryFields},
+	},
+	"V2GetEvents": {
+		Summary:     "Returns the price events",
+		QueryParams: []string{restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2CreateEvent": {Summary: "Creates a price event"},
+	"V2DeleteEvent": {Summary: "Deletes a price event"},
+	"V2CreateTelegramPairingCode": {
+		Summary: "Creates a one-time code which authorises the Telegram chat it is sent from",
+	},
+	"V2GetTelegramClients": {
+		Summary:     "Returns the authorised Telegram chats",
+		QueryParams: []string{restV2QueryLimit, restV2QueryOffset, restV2QueryFields},
+	},
+	"V2DeleteTelegramClient": {Summary: "Revokes the authorisation of a Telegram chat"},
+}
+
+// RESTv2ErrorResponse is the JSON body returned by the v2 API on failure
+type RESTv2ErrorResponse struct {
+	Error RESTv2Error `json:"error"`
+}
+
+// RESTv2Error holds the HTTP status code and error message
+type RESTv2Error struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// RESTv2ListResponse is the JSON body returned by the v2 list endpoints
+type RESTv2ListResponse struct {
+	Data       interface{}      `json:"data"`
+	Pagination RESTv2Pagination `json:"pagination"`
+}
+
+// RESTv2Pagination holds the pagination details of a list response
+type RESTv2Pagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+// RESTv2Exchange holds the status of a loaded exchange
+type RESTv2Exchange struct {
+	Name                    string   `json:"name"`
+	Websocket               bool     `json:"websocket"`
+	AuthenticatedAPISupport bool     `json:"authenticatedApiSupport"`
+	AssetTypes              []string `json:"assetTypes"`
+	EnabledPairs            []string `json:"enabledPairs"`
+}
+
+// RESTv2Ticker holds an exchange ticker for a currency pair and asset type
+type RESTv2Ticker struct {
+	Exchange    string    `json:"exchange"`
+	AssetType   string    `json:"assetType"`
+	Pair        string    `json:"pair"`
+	Last        float64   `json:"last"`
+	High        float64   `json:"high"`
+	Low         float64   `json:"low"`
+	Bid         float64   `json:"bid"`
+	Ask         float64   `json:"ask"`
+	Volume      float64   `json:"volume"`
+	PriceATH    float64   `json:"priceAth"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// RESTv2Orderbook holds an exchange orderbook for a currency pair and asset
+// type
+type RESTv2Orderbook struct {
+	Exchange    string           `json:"exchange"`
+	AssetType   string           `json:"assetType"`
+	Pair        string           `json:"pair"`
+	Bids        []orderbook.Item `json:"bids"`
+	Asks        []orderbook.Item `json:"asks"`
+	LastUpdated time.Time        `json:"lastUpdated"`
+}
+
+// RESTv2Query holds the parsed v2 query parameters
+type RESTv2Query struct {
+	Exchange  string
+	AssetType string
+	Pair      pair.CurrencyPair
+	Limit     int
+	Offset    int
+	Fields    []string
+}
+
+// OpenAPIDocument is an OpenAPI 3 document describing the RESTful API
+type OpenAPIDocument struct {
+	OpenAPI string                                 `json:"openapi"`
+	Info    OpenAPIInfo                            `json:"info"`
+	Paths   map[string]map[string]OpenAPIOperation `json:"paths"`
+}
+
+// OpenAPIInfo holds the OpenAPI document metadata
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIOperation describes a single route
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+	Scope       string                     `json:"x-required-scope,omitempty"`
+}
+
+// OpenAPIParameter describes a route path or query parameter
+type OpenAPIParameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description,omitempty"`
+	Required    bool          `json:"required"`
+	Schema      OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema holds a parameter type
+type OpenAPISchema struct {
+	Type string `json:"type"`
+}
+
+// OpenAPIResponse describes a route response
+type OpenAPIResponse struct {
+	Description string `json:"description"`
+}
+
+// ParseRESTv2Query parses and validates the v2 query parameters
+func ParseRESTv2Query(r *http.Request) (RESTv2Query, error) {
+	values := r.URL.Query()
+	query := RESTv2Query{
+		Exchange:  values.Get(restV2QueryExchange),
+		AssetType: common.StringToUpper(values.Get(restV2QueryAssetType)),
+		Limit:     RESTv2DefaultLimit,
+	}
+
+	if p := values.Get(restV2QueryPair); p != "" {
+		var err error
+		query.Pair, err = parseRESTv2Pair(p)
+		if err != nil {
+			return query, err
+		}
+	}
+
+	if limit := values.Get(restV2QueryLimit); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 || l > RESTv2MaxLimit {
+			return query, errRESTv2InvalidLimit
+		}
+		query.Limit = l
+	}
+
+	if offset := values.Get(restV2QueryOffset); offset != "" {
+		o, err := strconv.Atoi(offset)
+		if err != nil || o < 0 {
+			return query, errRESTv2InvalidOffset
+		}
+		query.Offset = o
+	}
+
+	if fields := values.Get(restV2QueryFields); fields != "" {
+		for _, field := range common.SplitStrings(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field != "" {
+				query.Fields = append(query.Fields, field)
+			}
+		}
+	}
+	return query, nil
+}
+
+// parseRESTv2Pair converts a currency pair string with or without a delimiter
+// into a currency pair. Pairs without a delimiter are split as the matching
+// enabled exchange pair, or in half if they are 6 characters long
+func parseRESTv2Pair(p string) (pair.CurrencyPair, error) {
+	p = common.StringToUpper(p)
+	if strings.ContainsAny(p, "-_") {
+		result := pair.NewCurrencyPairFromString(p)
+		if result.FirstCurrency == "" || result.SecondCurrency == "" {
+			return pair.CurrencyPair{}, errRESTv2InvalidPair
+		}
+		return result, nil
+	}
+
+	matches := enabledPairSplits(p)
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) == 0 && len(p) == 6:
+		return pair.NewCurrencyPairFromString(p), nil
+	}
+	return pair.CurrencyPair{}, errRESTv2InvalidPair
+}
+
+// enabledPairSplits returns the distinct splits of an undelimited currency
+// pair matching the enabled pairs of the enabled exchanges. Exchanges without
+// a config pair delimiter or index are skipped as their pairs are split in
+// half
+func enabledPairSplits(p string) []pair.CurrencyPair {
+	if bot.Config == nil {
+		return nil
+	}
+
+	var matches []pair.CurrencyPair
+	for x := range bot.Config.Exchanges {
+		exchCfg := bot.Config.Exchanges[x]
+		if !exchCfg.Enabled || exchCfg.ConfigCurrencyPairFormat == nil ||
+			(exchCfg.ConfigCurrencyPairFormat.Delimiter == "" &&
+				exchCfg.ConfigCurrencyPairFormat.Index == "") {
+			continue
+		}
+
+		pairs := pair.FormatPairs(common.SplitStrings(exchCfg.EnabledPairs, ","),
+			exchCfg.ConfigCurrencyPairFormat.Delimiter,
+			exchCfg.ConfigCurrencyPairFormat.Index)
+		for y := range pairs {
+			first := pairs[y].FirstCurrency.Upper().String()
+			second := pairs[y].SecondCurrency.Upper().String()
+			if first+second != p {
+				continue
+			}
+
+			match := pair.NewCurrencyPair(first, second)
+			if !pair.Contains(matches, match, true) {
+				matches = append(matches, match)
+			}
+		}
+	}
+	return matches
+}
+
+// MatchesPair returns whether or not the currency pair matches the query pair
+// filter
+func (q *RESTv2Query) MatchesPair(p pair.CurrencyPair) bool {
+	if q.Pair.FirstCurrency == "" {
+		return true
+	}
+	return q.Pair.Equal(p, true)
+}
+
+// MatchesExchange returns whether or not the exchange name matches the query
+// exchange filter
+func (q *RESTv2Query) MatchesExchange(exchangeName string) bool {
+	return q.Exchange == "" ||
+		common.StringToLower(q.Exchange) == common.StringToLower(exchangeName)
+}
+
+// MatchesAssetType returns whether or not the asset type matches the query
+// asset type filter
+func (q *RESTv2Query) MatchesAssetType(assetType string) bool {
+	return q.AssetType == "" || q.AssetType == common.StringToUpper(assetType)
+}
+
+// paginate returns the start and end indexes of the requested page
+func (q *RESTv2Query) paginate(total int) (int, int) {
+	start := q.Offset
+	if start > total {
+		start = total
+	}
+
+	end := start + q.Limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// SelectRESTv2Fields limits the JSON encoding of an object or a list of
+// objects to the supplied fields
+func SelectRESTv2Fields(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	encoded, err := common.JSONEncode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []map[string]json.RawMessage
+	if json.Unmarshal(encoded, &list) == nil {
+		for x := range list {
+			list[x], err = selectRESTv2Fields(list[x], fields)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	}
+
+	var object map[string]json.RawMessage
+	err = json.Unmarshal(encoded, &object)
+	if err != nil {
+		return nil, errors.New("field selection is not supported by this route")
+	}
+	return selectRESTv2Fields(object, fields)
+}
+
+func selectRESTv2Fields(object map[string]json.RawMessage, fields []string) (map[string]json.RawMessage, error) {
+	result := make(map[string]json.RawMessage)
+	for x := range fields {
+		value, ok := object[fields[x]]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s", fields[x])
+		}
+		result[fields[x]] = value
+	}
+	return result, nil
+}
+
+// RESTv2ETag returns a strong entity tag for the supplied response body
+func RESTv2ETag(body []byte) string {
+	return `"` + common.HexEncodeToString(common.GetSHA256(body)) + `"`
+}
+
+// restV2ETagMatches returns whether or not the If-None-Match header matches the
+// entity tag
+func restV2ETagMatches(ifNoneMatch, etag string) bool {
+	for _, tag := range common.SplitStrings(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// RESTv2JSONResponse outputs a JSON response with an ETag header, replying
+// with 304 Not Modified if the request If-None-Match header matches
+func RESTv2JSONResponse(w http.ResponseWriter, r *http.Request, statusCode int, resp interface{}) {
+	body, err := common.JSONEncode(resp)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	etag := RESTv2ETag(body)
+	w.Header().Set("ETag", etag)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && restV2ETagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(statusCode)
+	_, err = w.Write(body)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// RESTv2ErrorJSONResponse outputs a JSON error body with the supplied status
+// code
+func RESTv2ErrorJSONResponse(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	resp := RESTv2ErrorResponse{
+		Error: RESTv2Error{
+			Status:  statusCode,
+			Message: err.Error(),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(statusCode)
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		RESTfulError(r.Method, err)
+	}
+}
+
+// restV2Object outputs a single object applying the query field selection
+func restV2Object(w http.ResponseWriter, r *http.Request, query RESTv2Query, data interface{}) {
+	result, err := SelectRESTv2Fields(data, query.Fields)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+	RESTv2JSONResponse(w, r, http.StatusOK, result)
+}
+
+// restV2List outputs a page of a list applying the query field selection, the
+// supplied page function returns the items between the start and end indexes
+func restV2List(w http.ResponseWriter, r *http.Request, query RESTv2Query, total int, page func(start, end int) interface{}) {
+	start, end := query.paginate(total)
+	result, err := SelectRESTv2Fields(page(start, end), query.Fields)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	RESTv2JSONResponse(w, r, http.StatusOK, RESTv2ListResponse{
+		Data: result,
+		Pagination: RESTv2Pagination{
+			Offset: query.Offset,
+			Limit:  query.Limit,
+			Total:  total,
+		},
+	})
+}
+
+// restV2Exchange returns an enabled exchange by name, case insensitive
+func restV2Exchange(exchangeName string) exchange.IBotExchange {
+	for x := range bot.Exchanges {
+		if bot.Exchanges[x] == nil || !bot.Exchanges[x].IsEnabled() {
+			continue
+		}
+
+		if common.StringToLower(bot.Exchanges[x].GetName()) == common.StringToLower(exchangeName) {
+			return bot.Exchanges[x]
+		}
+	}
+	return nil
+}
+
+// restV2AssetType validates the requested asset type against the exchange
+// supported asset types, defaulting to the first supported asset type
+func restV2AssetType(exchangeName, assetType string) (string, error) {
+	assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
+	if err != nil {
+		return "", err
+	}
+
+	if assetType == "" {
+		return assetTypes[0], nil
+	}
+
+	for x := range assetTypes {
+		if common.StringToUpper(assetTypes[x]) == assetType {
+			return assetTypes[x], nil
+		}
+	}
+	return "", fmt.Errorf("exchange %s does not support asset type %s",
+		exchangeName, assetType)
+}
+
+// restV2FilteredPairs returns the active asset pairs matching the query
+// filters
+func restV2FilteredPairs(query RESTv2Query) []ActiveAssetPair {
+	var result []ActiveAssetPair
+	activePairs := GetActiveAssetPairs()
+	for x := range activePairs {
+		if !query.MatchesExchange(activePairs[x].Exchange.GetName()) ||
+			!query.MatchesAssetType(activePairs[x].AssetType) ||
+			!query.MatchesPair(activePairs[x].Pair) {
+			continue
+		}
+		result = append(result, activePairs[x])
+	}
+	return result
+}
+
+// NewRESTv2Ticker converts an exchange ticker to its v2 representation
+func NewRESTv2Ticker(exchangeName, assetType string, t ticker.Price) RESTv2Ticker {
+	return RESTv2Ticker{
+		Exchange:    exchangeName,
+		AssetType:   assetType,
+		Pair:        t.Pair.Pair().String(),
+		Last:        t.Last,
+		High:        t.High,
+		Low:         t.Low,
+		Bid:         t.Bid,
+		Ask:         t.Ask,
+		Volume:      t.Volume,
+		PriceATH:    t.PriceATH,
+		LastUpdated: t.LastUpdated,
+	}
+}
+
+// NewRESTv2Orderbook converts an exchange orderbook to its v2 representation
+func NewRESTv2Orderbook(exchangeName, assetType string, ob orderbook.Base) RESTv2Orderbook {
+	return RESTv2Orderbook{
+		Exchange:    exchangeName,
+		AssetType:   assetType,
+		Pair:        ob.Pair.Pair().String(),
+		Bids:        ob.Bids,
+		Asks:        ob.Asks,
+		LastUpdated: ob.LastUpdated,
+	}
+}
+
+// RESTv2GetExchanges returns the enabled exchanges
+func RESTv2GetExchanges(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	exchanges := []RESTv2Exchange{}
+	for x := range bot.Exchanges {
+		if bot.Exchanges[x] == nil || !bot.Exchanges[x].IsEnabled() ||
+			!query.MatchesExchange(bot.Exchanges[x].GetName()) {
+			continue
+		}
+
+		exchCfg, err := bot.Config.GetExchangeConfig(bot.Exchanges[x].GetName())
+		if err != nil {
+			continue
+		}
+
+		result := RESTv2Exchange{
+			Name:                    exchCfg.Name,
+			Websocket:               exchCfg.Websocket,
+			AuthenticatedAPISupport: exchCfg.AuthenticatedAPISupport,
+			AssetTypes:              common.SplitStrings(exchCfg.AssetTypes, ","),
+			EnabledPairs:            []string{},
+		}
+
+		enabledPairs := bot.Exchanges[x].GetEnabledCurrencies()
+		for y := range enabledPairs {
+			result.EnabledPairs = append(result.EnabledPairs,
+				enabledPairs[y].Pair().String())
+		}
+		exchanges = append(exchanges, result)
+	}
+
+	restV2List(w, r, query, len(exchanges), func(start, end int) interface{} {
+		return exchanges[start:end]
+	})
+}
+
+// RESTv2GetTickers returns the tickers of the enabled exchanges filtered by
+// exchange, asset type and currency pair
+func RESTv2GetTickers(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	tickers := []RESTv2Ticker{}
+	activePairs := restV2FilteredPairs(query)
+	for x := range activePairs {
+		exchangeName := activePairs[x].Exchange.GetName()
+		tickerPrice, err := ticker.GetTicker(exchangeName, activePairs[x].Pair,
+			activePairs[x].AssetType)
+		if err != nil {
+			continue
+		}
+		tickers = append(tickers, NewRESTv2Ticker(exchangeName,
+			activePairs[x].AssetType, tickerPrice))
+	}
+
+	restV2List(w, r, query, len(tickers), func(start, end int) interface{} {
+		return tickers[start:end]
+	})
+}
+
+// RESTv2GetTicker returns the ticker for an exchange currency pair
+func RESTv2GetTicker(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	exch := restV2Exchange(vars["exchangeName"])
+	if exch == nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errRESTv2ExchangeNotFound)
+		return
+	}
+
+	p, err := parseRESTv2Pair(vars["currency"])
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !pair.Contains(exch.GetEnabledCurrencies(), p, true) {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound,
+			fmt.Errorf("currency pair %s not enabled for %s", p.Pair(), exch.GetName()))
+		return
+	}
+
+	assetType, err := restV2AssetType(exch.GetName(), query.AssetType)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	tickerPrice, err := exch.GetTickerPrice(p, assetType)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	restV2Object(w, r, query, NewRESTv2Ticker(exch.GetName(), assetType,
+		tickerPrice))
+}
+
+// RESTv2GetOrderbooks returns the orderbooks of the enabled exchanges filtered
+// by exchange, asset type and currency pair
+func RESTv2GetOrderbooks(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	orderbooks := []RESTv2Orderbook{}
+	activePairs := restV2FilteredPairs(query)
+	for x := range activePairs {
+		exchangeName := activePairs[x].Exchange.GetName()
+		ob, err := orderbook.GetOrderbook(exchangeName, activePairs[x].Pair,
+			activePairs[x].AssetType)
+		if err != nil {
+			continue
+		}
+		orderbooks = append(orderbooks, NewRESTv2Orderbook(exchangeName,
+			activePairs[x].AssetType, ob))
+	}
+
+	restV2List(w, r, query, len(orderbooks), func(start, end int) interface{} {
+		return orderbooks[start:end]
+	})
+}
+
+// RESTv2GetOrderbook returns the orderbook for an exchange currency pair
+func RESTv2GetOrderbook(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	vars := mux.Vars(r)
+	exch := restV2Exchange(vars["exchangeName"])
+	if exch == nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errRESTv2ExchangeNotFound)
+		return
+	}
+
+	p, err := parseRESTv2Pair(vars["currency"])
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !pair.Contains(exch.GetEnabledCurrencies(), p, true) {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound,
+			fmt.Errorf("currency pair %s not enabled for %s", p.Pair(), exch.GetName()))
+		return
+	}
+
+	assetType, err := restV2AssetType(exch.GetName(), query.AssetType)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	ob, err := exch.GetOrderbookEx(p, assetType)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	restV2Object(w, r, query, NewRESTv2Orderbook(exch.GetName(), assetType, ob))
+}
+
+// RESTv2GetPortfolio returns the portfolio summary
+func RESTv2GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+	restV2Object(w, r, query, bot.Portfolio.GetPortfolioSummary())
+}
+
+// RESTv2NotFound replies to unknown routes, using a v2 JSON error body for v2
+// requests
+func RESTv2NotFound(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, RESTv2Prefix+"/") {
+		http.NotFound(w, r)
+		return
+	}
+	RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errRESTv2RouteNotFound)
+}
+
+// GenerateOpenAPIDocument generates an OpenAPI document from the route table
+func GenerateOpenAPIDocument(routeTable Routes) OpenAPIDocument {
+	doc := OpenAPIDocument{
+		OpenAPI: "3.0.0",
+		Info: OpenAPIInfo{
+			Title:   "go-crypto-pricefeeder RESTful API",
+			Version: fmt.Sprintf("%s.%s", MajorVersion, MinorVersion),
+		},
+		Paths: make(map[string]map[string]OpenAPIOperation),
+	}
+
+	for _, route := range routeTable {
+		if route.Name == "" {
+			continue
+		}
+
+		path := restV2PathParam.ReplaceAllString(route.Pattern, "{$1}")
+		operation := OpenAPIOperation{
+			OperationID: route.Name,
+			Summary:     routeDocs[route.Name].Summary,
+			Scope:       route.Scope,
+			Responses: map[string]OpenAPIResponse{
+				"200": {Description: "OK"},
+			},
+		}
+
+		pathParams := restV2PathParam.FindAllStringSubmatch(route.Pattern, -1)
+		for _, match := range pathParams {
+			operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   OpenAPISchema{Type: "string"},
+			})
+		}
+
+		for _, param := range routeDocs[route.Name].QueryParams {
+			paramType := "string"
+			if param == restV2QueryLimit || param == restV2QueryOffset {
+				paramType = "integer"
+			}
+			operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+				Name:        param,
+				In:          "query",
+				Description: restV2QueryDescriptions[param],
+				Schema:      OpenAPISchema{Type: paramType},
+			})
+		}
+
+		if strings.HasPrefix(route.Pattern, RESTv2Prefix+"/") {
+			operation.Responses["304"] = OpenAPIResponse{Description: "Not Modified"}
+			operation.Responses["400"] = OpenAPIResponse{Description: "Bad Request"}
+			if len(pathParams) > 0 {
+				operation.Responses["404"] = OpenAPIResponse{Description: "Not Found"}
+				operation.Responses["502"] = OpenAPIResponse{Description: "Bad Gateway"}
+			}
+		}
+
+		if route.Scope != "" {
+			operation.Responses["401"] = OpenAPIResponse{Description: "Unauthorized"}
+			operation.Responses["403"] = OpenAPIResponse{Description: "Forbidden"}
+			operation.Responses["429"] = OpenAPIResponse{Description: "Too Many Requests"}
+		}
+
+		if _, ok := doc.Paths[path]; !ok {
+			doc.Paths[path] = make(map[string]OpenAPIOperation)
+		}
+		doc.Paths[path][common.StringToLower(route.Method)] = operation
+	}
+	return doc
+}
+
+// RESTv2GetOpenAPI returns the OpenAPI document generated from the route table
+func RESTv2GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	RESTv2JSONResponse(w, r, http.StatusOK, GenerateOpenAPIDocument(routes))
+}
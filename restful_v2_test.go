@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+func TestParseRESTv2Query(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v2/tickers?exchange=Bitfinex&assetType=spot&pair=btc-usd&limit=5&offset=10&fields=pair,last", nil)
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		t.Fatalf("Test failed. ParseRESTv2Query: %s", err)
+	}
+
+	if query.Exchange != "Bitfinex" || query.AssetType != "SPOT" ||
+		query.Pair.Pair().String() != "BTC-USD" || query.Limit != 5 ||
+		query.Offset != 10 || len(query.Fields) != 2 {
+		t.Errorf("Test failed. ParseRESTv2Query unexpected result: %v", query)
+	}
+
+	r = httptest.NewRequest("GET", "/v2/tickers", nil)
+	query, err = ParseRESTv2Query(r)
+	if err != nil {
+		t.Fatalf("Test failed. ParseRESTv2Query: %s", err)
+	}
+
+	if query.Limit != RESTv2DefaultLimit || query.Offset != 0 {
+		t.Error("Test failed. ParseRESTv2Query default pagination incorrect")
+	}
+
+	for _, q := range []string{"limit=0", "limit=abc", "limit=1001", "offset=-1", "pair=BTC"} {
+		r = httptest.NewRequest("GET", "/v2/tickers?"+q, nil)
+		_, err = ParseRESTv2Query(r)
+		if err == nil {
+			t.Errorf("Test failed. ParseRESTv2Query %s should have thrown an error", q)
+		}
+	}
+}
+
+func TestRESTv2QueryMatches(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v2/tickers?exchange=bitfinex&assetType=spot&pair=BTCUSD", nil)
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		t.Fatalf("Test failed. ParseRESTv2Query: %s", err)
+	}
+
+	if !query.MatchesExchange("Bitfinex") || query.MatchesExchange("Kraken") {
+		t.Error("Test failed. MatchesExchange returned incorrect result")
+	}
+
+	if !query.MatchesAssetType("SPOT") || query.MatchesAssetType("FUTURES") {
+		t.Error("Test failed. MatchesAssetType returned incorrect result")
+	}
+
+	p, _ := parseRESTv2Pair("BTC_USD")
+	if !query.MatchesPair(p) {
+		t.Error("Test failed. MatchesPair returned incorrect result")
+	}
+
+	p, _ = parseRESTv2Pair("USDBTC")
+	if query.MatchesPair(p) {
+		t.Error("Test failed. MatchesPair returned incorrect result")
+	}
+}
+
+func TestParseRESTv2Pair(t *testing.T) {
+	backupConfig := bot.Config
+	defer func() { bot.Config = backupConfig }()
+
+	bot.Config = &config.Config{Exchanges: []config.ExchangeConfig{
+		{Name: "Bitfinex", Enabled: true, EnabledPairs: "DASH-BTC,BTC-USDT,USDT-BTC",
+			ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{Delimiter: "-"}},
+		{Name: "Kraken", Enabled: true, EnabledPairs: "DAS_HBTC,BTCU_SDT",
+			ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{Delimiter: "_"}},
+		{Name: "GDAX", Enabled: false, EnabledPairs: "USD_TBTC",
+			ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{Delimiter: "_"}},
+		{Name: "Bitstamp", Enabled: true, EnabledPairs: "LTCBTCX",
+			ConfigCurrencyPairFormat: &config.CurrencyPairFormatConfig{}},
+	}}
+
+	valid := map[string]string{
+		"btc_usd":  "BTC_USD",
+		"BTC-USDT": "BTC-USDT",
+		"BTCUSD":   "BTCUSD",
+		"usdtbtc":  "USDTBTC",
+	}
+	for x, expected := range valid {
+		p, err := parseRESTv2Pair(x)
+		if err != nil || p.Pair().String() != expected {
+			t.Errorf("Test failed. parseRESTv2Pair %s returned %s %v", x, p.Pair(), err)
+		}
+	}
+
+	p, _ := parseRESTv2Pair("USDTBTC")
+	if p.FirstCurrency != "USDT" || p.SecondCurrency != "BTC" {
+		t.Errorf("Test failed. parseRESTv2Pair split USDTBTC as %s/%s", p.FirstCurrency, p.SecondCurrency)
+	}
+
+	for _, x := range []string{"DASHBTC", "BTCUSDT", "LTCBTCX", "BTC", "BTC_", ""} {
+		if _, err := parseRESTv2Pair(x); err != errRESTv2InvalidPair {
+			t.Errorf("Test failed. parseRESTv2Pair %q should be rejected, got %v", x, err)
+		}
+	}
+}
+
+func TestRESTv2Paginate(t *testing.T) {
+	query := RESTv2Query{Limit: 10, Offset: 5}
+	start, end := query.paginate(12)
+	if start != 5 || end != 12 {
+		t.Errorf("Test failed. paginate returned %d %d", start, end)
+	}
+
+	query.Offset = 20
+	start, end = query.paginate(12)
+	if start != 12 || end != 12 {
+		t.Errorf("Test failed. paginate returned %d %d", start, end)
+	}
+}
+
+func TestSelectRESTv2Fields(t *testing.T) {
+	tickers := []RESTv2Ticker{{Exchange: "Bitfinex", Pair: "BTCUSD", Last: 1000}}
+	result, err := SelectRESTv2Fields(tickers, []string{"pair", "last"})
+	if err != nil {
+		t.Fatalf("Test failed. SelectRESTv2Fields: %s", err)
+	}
+
+	encoded, _ := json.Marshal(result)
+	if string(encoded) != `[{"last":1000,"pair":"BTCUSD"}]` {
+		t.Errorf("Test failed. SelectRESTv2Fields unexpected result: %s", encoded)
+	}
+
+	_, err = SelectRESTv2Fields(tickers[0], []string{"invalid"})
+	if err == nil {
+		t.Error("Test failed. SelectRESTv2Fields should have thrown an error for an unknown field")
+	}
+
+	result, err = SelectRESTv2Fields(tickers, nil)
+	if err != nil || len(result.([]RESTv2Ticker)) != 1 {
+		t.Error("Test failed. SelectRESTv2Fields should return the data unchanged")
+	}
+}
+
+func TestRESTv2JSONResponseETag(t *testing.T) {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/v2/portfolio", nil)
+	RESTv2JSONResponse(w, r, http.StatusOK, RESTv2Pagination{Total: 1})
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("Test failed. RESTv2JSONResponse returned %d with ETag %s",
+			w.Code, etag)
+	}
+
+	w = httptest.NewRecorder()
+	r.Header.Set("If-None-Match", etag)
+	RESTv2JSONResponse(w, r, http.StatusOK, RESTv2Pagination{Total: 1})
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Test failed. RESTv2JSONResponse returned %d expected %d",
+			w.Code, http.StatusNotModified)
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2JSONResponse(w, r, http.StatusOK, RESTv2Pagination{Total: 2})
+	if w.Code != http.StatusOK {
+		t.Errorf("Test failed. RESTv2JSONResponse returned %d expected %d",
+			w.Code, http.StatusOK)
+	}
+}
+
+func TestRESTv2NotFound(t *testing.T) {
+	w := httptest.NewRecorder()
+	RESTv2NotFound(w, httptest.NewRequest("GET", "/v2/invalid", nil))
+
+	var resp RESTv2ErrorResponse
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("Test failed. RESTv2NotFound returned invalid JSON: %s", err)
+	}
+
+	if w.Code != http.StatusNotFound || resp.Error.Status != http.StatusNotFound {
+		t.Errorf("Test failed. RESTv2NotFound returned %d", w.Code)
+	}
+}
+
+func TestGenerateOpenAPIDocument(t *testing.T) {
+	NewRouter(nil)
+	doc := GenerateOpenAPIDocument(routes)
+
+	for _, route := range routes {
+		if route.Name == "" {
+			continue
+		}
+
+		if _, ok := routeDocs[route.Name]; !ok {
+			t.Errorf("Test failed. Route %s is not documented", route.Name)
+		}
+	}
+
+	op, ok := doc.Paths["/v2/exchanges/{exchangeName}/tickers/{currency}"]["get"]
+	if !ok {
+		t.Fatal("Test failed. OpenAPI document missing V2GetTicker route")
+	}
+
+	if op.OperationID != "V2GetTicker" || len(op.Parameters) != 4 ||
+		op.Parameters[0].In != "path" || !op.Parameters[0].Required {
+		t.Errorf("Test failed. OpenAPI V2GetTicker operation incorrect: %v", op)
+	}
+}
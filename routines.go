@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,6 +16,12 @@ import (
 	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
 )
 
+// Const vars for the trade updater routine
+const (
+	tradeUpdaterInterval   = time.Second * 10
+	tradeUpdaterMaxBackoff = time.Minute * 10
+)
+
 func printCurrencyFormat(price float64) string {
 	displaySymbol, err := symbol.GetSymbolByCurrencyName(bot.Config.Currency.FiatDisplayCurrency)
 	if err != nil {
@@ -167,6 +174,11 @@ func relayWebsocketEvent(result interface{}, event, assetType, exchangeName stri
 		AssetType: assetType,
 		Exchange:  exchangeName,
 	}
+	PublishFeedEvent(evt)
+	if !bot.Config.Webserver.Enabled {
+		return
+	}
+
 	err := BroadcastWebsocketMessage(evt)
 	if err != nil {
 		log.Println(fmt.Errorf("Failed to broadcast websocket event. Error: %s",
@@ -206,11 +218,11 @@ func TickerUpdaterRoutine() {
 						result, err = exch.GetTickerPrice(c, assetType)
 					}
 					printTickerSummary(result, c, assetType, exchangeName, err)
+					ReportExchangeHealth(exchangeName, webhookComponentTicker, c, assetType, err)
 					if err == nil {
 						bot.Comms.StageTickerData(exchangeName, assetType, result)
-						if bot.Config.Webserver.Enabled {
-							relayWebsocketEvent(result, "ticker_update", assetType, exchangeName)
-						}
+						bot.Sinks.PublishTicker(exchangeName, assetType, result)
+						relayWebsocketEvent(result, FeedEventTicker, assetType, exchangeName)
 					}
 				}
 
@@ -257,11 +269,11 @@ func OrderbookUpdaterRoutine() {
 				processOrderbook := func(exch exchange.IBotExchange, c pair.CurrencyPair, assetType string) {
 					result, err := exch.UpdateOrderbook(c, assetType)
 					printOrderbookSummary(result, c, assetType, exchangeName, err)
+					ReportExchangeHealth(exchangeName, webhookComponentOrderbook, c, assetType, err)
 					if err == nil {
 						bot.Comms.StageOrderbookData(exchangeName, assetType, result)
-						if bot.Config.Webserver.Enabled {
-							relayWebsocketEvent(result, "orderbook_update", assetType, exchangeName)
-						}
+						bot.Sinks.PublishOrderbookSummary(exchangeName, assetType, result)
+						relayWebsocketEvent(result, FeedEventOrderbook, assetType, exchangeName)
 					}
 				}
 
@@ -277,3 +289,123 @@ func OrderbookUpdaterRoutine() {
 		time.Sleep(time.Second * 10)
 	}
 }
+
+// lastTrade holds the most recent trade sent to the sinks for a currency pair
+type lastTrade struct {
+	tid       int64
+	timestamp int64
+}
+
+// isNewer returns whether or not the trade is newer than the last trade
+func (l lastTrade) isNewer(t exchange.TradeHistory) bool {
+	if t.TID != 0 && l.tid != 0 {
+		return t.TID > l.tid
+	}
+	return t.Timestamp > l.timestamp
+}
+
+// tradeUpdaterBackoff returns the delay before fetching the trade history of
+// an exchange again after consecutive failures, doubling from the updater
+// interval up to tradeUpdaterMaxBackoff
+func tradeUpdaterBackoff(failures int) time.Duration {
+	delay := tradeUpdaterInterval
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= tradeUpdaterMaxBackoff {
+			return tradeUpdaterMaxBackoff
+		}
+	}
+	return delay
+}
+
+// updateExchangeTrades fetches the trade history of the enabled currency pairs
+// of an exchange and sends the trades newer than the last trades sent to the
+// output sinks
+func updateExchangeTrades(exch exchange.IBotExchange, assetTypes []string, lastTrades map[string]lastTrade) error {
+	exchangeName := exch.GetName()
+	enabledCurrencies := exch.GetEnabledCurrencies()
+	for y := range assetTypes {
+		for z := range enabledCurrencies {
+			trades, err := exch.GetExchangeHistory(enabledCurrencies[z], assetTypes[y])
+			if err != nil {
+				return err
+			}
+
+			sort.Slice(trades, func(i, j int) bool {
+				if trades[i].Timestamp == trades[j].Timestamp {
+					return trades[i].TID < trades[j].TID
+				}
+				return trades[i].Timestamp < trades[j].Timestamp
+			})
+
+			currencyPair := enabledCurrencies[z].Pair().String()
+			key := assetTypes[y] + currencyPair
+			last := lastTrades[key]
+			for i := range trades {
+				if !last.isNewer(trades[i]) {
+					continue
+				}
+				bot.Sinks.PublishTrade(exchangeName, assetTypes[y], currencyPair, trades[i])
+				last = lastTrade{tid: trades[i].TID, timestamp: trades[i].Timestamp}
+			}
+			lastTrades[key] = last
+		}
+	}
+	return nil
+}
+
+// TradeUpdaterRoutine fetches the trade history for all enabled currency
+// pairs and exchanges and sends the new trades to the output sinks. Exchanges
+// which don't implement trade history are skipped after the first failure,
+// other failures are retried with a backoff
+func TradeUpdaterRoutine() {
+	log.Println("Starting trade updater routine.")
+	lastTrades := make([]map[string]lastTrade, len(bot.Exchanges))
+	unsupported := make([]bool, len(bot.Exchanges))
+	failures := make([]int, len(bot.Exchanges))
+	retryAfter := make([]time.Time, len(bot.Exchanges))
+	for x := range lastTrades {
+		lastTrades[x] = make(map[string]lastTrade)
+	}
+
+	var wg sync.WaitGroup
+	for {
+		wg.Add(len(bot.Exchanges))
+		for x := range bot.Exchanges {
+			go func(x int, wg *sync.WaitGroup) {
+				defer wg.Done()
+
+				if bot.Exchanges[x] == nil || unsupported[x] || time.Now().Before(retryAfter[x]) {
+					return
+				}
+				exchangeName := bot.Exchanges[x].GetName()
+				assetTypes, err := exchange.GetExchangeAssetTypes(exchangeName)
+				if err != nil {
+					log.Printf("failed to get %s exchange asset types. Error: %s",
+						exchangeName, err)
+					return
+				}
+
+				err = updateExchangeTrades(bot.Exchanges[x], assetTypes, lastTrades[x])
+				if err == exchange.ErrTradeHistoryNotImplemented {
+					log.Printf("%s trade history unsupported, disabling trade updates.",
+						exchangeName)
+					unsupported[x] = true
+					return
+				}
+
+				if err != nil {
+					failures[x]++
+					backoff := tradeUpdaterBackoff(failures[x])
+					retryAfter[x] = time.Now().Add(backoff)
+					log.Printf("%s trade history unavailable, retrying in %s. Error: %s",
+						exchangeName, backoff, err)
+					return
+				}
+				failures[x] = 0
+			}(x, &wg)
+		}
+		wg.Wait()
+		time.Sleep(tradeUpdaterInterval)
+	}
+}
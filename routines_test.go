@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/gdax"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks"
+	sinkbase "github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+func TestTradeUpdaterBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:  tradeUpdaterInterval,
+		2:  tradeUpdaterInterval * 2,
+		4:  tradeUpdaterInterval * 8,
+		50: tradeUpdaterMaxBackoff,
+	}
+
+	for failures, expected := range tests {
+		if backoff := tradeUpdaterBackoff(failures); backoff != expected {
+			t.Errorf("Test failed. tradeUpdaterBackoff(%d) expected %s got %s",
+				failures, expected, backoff)
+		}
+	}
+}
+
+func TestUpdateExchangeTrades(t *testing.T) {
+	backupSinks := bot.Sinks
+	defer func() { bot.Sinks = backupSinks }()
+
+	err := config.GetConfig().LoadConfig("testdata/configtest.json")
+	if err != nil {
+		t.Fatalf("Test failed. LoadConfig: %s", err)
+	}
+
+	trades := `[{"trade_id":2,"price":"6510.5","size":"0.5","time":"2018-08-01T10:00:01.5Z","side":"sell"},
+		{"trade_id":1,"price":"6500","size":"1.25","time":"2018-08-01T10:00:00Z","side":"buy"}]`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/trades") {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, trades)
+	}))
+	defer server.Close()
+
+	g := new(gdax.GDAX)
+	g.SetDefaults()
+	g.APIUrl = server.URL + "/"
+	g.EnabledPairs = []string{"BTCUSD"}
+
+	path := filepath.Join(os.TempDir(), "pricefeeder-trades-test.json")
+	defer os.Remove(path)
+	os.Remove(path)
+	bot.Sinks = sinks.NewSinks([]config.SinkConfig{
+		{Name: "trades", Type: config.SinkTypeFile, Enabled: true, Path: path, Events: config.SinkEventTrade},
+	})
+
+	lastTrades := make(map[string]lastTrade)
+	for i := 0; i < 2; i++ {
+		err = updateExchangeTrades(g, []string{ticker.Spot}, lastTrades)
+		if err != nil {
+			t.Fatal("Test failed. updateExchangeTrades error", err)
+		}
+	}
+	bot.Sinks.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal("Test failed. Unable to open the sink output", err)
+	}
+	defer file.Close()
+
+	var events []sinkbase.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var evt sinkbase.Event
+		err = json.Unmarshal(scanner.Bytes(), &evt)
+		if err != nil {
+			t.Fatal("Test failed. Unable to decode the sink output", err)
+		}
+		events = append(events, evt)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Test failed. Expected 2 trade events got %d", len(events))
+	}
+
+	if events[0].Type != config.SinkEventTrade || events[0].Exchange != "GDAX" ||
+		events[0].Pair != "BTCUSD" || events[0].TradeID != 1 || events[0].Price != 6500 ||
+		events[0].Amount != 1.25 || events[0].Side != "buy" {
+		t.Errorf("Test failed. Unexpected first trade event %+v", events[0])
+	}
+
+	if events[1].TradeID != 2 || events[1].Side != "sell" ||
+		!events[1].Timestamp.Equal(time.Date(2018, 8, 1, 10, 0, 1, 5e8, time.UTC)) {
+		t.Errorf("Test failed. Unexpected second trade event %+v", events[1])
+	}
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	exchange "github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/portfolio"
+	"github.com/trustfeed/go-crypto-pricefeeder/pricefeedrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Const vars for the gRPC server
+const (
+	rpcStreamBufferSize = 1024
+)
+
+// rpcMethodScopes maps the gRPC methods to their required API token scope
+var rpcMethodScopes = map[string]string{
+	"/pricefeedrpc.PriceFeeder/GetExchanges":        config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetTicker":           config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetTickers":          config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetOrderbook":        config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetOrderbooks":       config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetExchangeRates":    config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/GetPortfolioSummary": config.APIScopeReadPortfolio,
+	"/pricefeedrpc.PriceFeeder/SubscribeTicker":     config.APIScopeReadMarket,
+	"/pricefeedrpc.PriceFeeder/SubscribeOrderbook":  config.APIScopeReadMarket,
+}
+
+// RPCServer implements the pricefeedrpc PriceFeeder service
+type RPCServer struct {
+	pricefeedrpc.UnimplementedPriceFeederServer
+}
+
+// StartRPCServer starts the gRPC server on the configured listen address
+func StartRPCServer() {
+	listenAddr := bot.Config.Webserver.GRPCListenAddress
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		log.Printf("gRPC server failed to listen on %s. Error: %s", listenAddr, err)
+		return
+	}
+
+	s := newRPCServer()
+	log.Printf("gRPC server support enabled. Listen address: %s:%d",
+		common.ExtractHost(listenAddr), common.ExtractPort(listenAddr))
+	go func() {
+		err = s.Serve(lis)
+		if err != nil {
+			log.Printf("gRPC server stopped. Error: %s", err)
+		}
+	}()
+}
+
+// newRPCServer returns a gRPC server with the PriceFeeder service registered
+// behind the token authorisation interceptors
+func newRPCServer() *grpc.Server {
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(rpcUnaryAuth),
+		grpc.StreamInterceptor(rpcStreamAuth),
+	)
+	pricefeedrpc.RegisterPriceFeederServer(s, &RPCServer{})
+	return s
+}
+
+// rpcAuthorise checks the bearer token in the request metadata has the scope
+// required by the gRPC method. Unknown methods require the admin scope
+func rpcAuthorise(ctx context.Context, fullMethod string) error {
+	scope, ok := rpcMethodScopes[fullMethod]
+	if !ok {
+		scope = config.APIScopeAdmin
+	}
+
+	if !IsAuthScopeRequired(scope) {
+		return nil
+	}
+
+	var token string
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		for _, header := range md.Get("authorization") {
+			token = bearerToken(header)
+		}
+	}
+
+	identity, err := AuthenticateToken(token)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !identity.HasScope(scope) {
+		return status.Error(codes.PermissionDenied, errAuthScopeDenied.Error())
+	}
+
+	if _, ok := CheckRateLimit(identity); !ok {
+		return status.Error(codes.ResourceExhausted, errAuthRateLimited.Error())
+	}
+	return nil
+}
+
+func rpcUnaryAuth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := rpcAuthorise(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func rpcStreamAuth(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := rpcAuthorise(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func rpcToPair(p *pricefeedrpc.CurrencyPair) (pair.CurrencyPair, error) {
+	if p == nil || p.Base == "" || p.Quote == "" {
+		return pair.CurrencyPair{}, status.Error(codes.InvalidArgument,
+			"currency pair base and quote must be supplied")
+	}
+	result := pair.NewCurrencyPair(common.StringToUpper(p.Base),
+		common.StringToUpper(p.Quote))
+	result.Delimiter = p.Delimiter
+	return result, nil
+}
+
+func rpcFromPair(p pair.CurrencyPair) *pricefeedrpc.CurrencyPair {
+	return &pricefeedrpc.CurrencyPair{
+		Delimiter: p.Delimiter,
+		Base:      p.FirstCurrency.String(),
+		Quote:     p.SecondCurrency.String(),
+	}
+}
+
+func rpcFromTicker(exchangeName, assetType string, t ticker.Price) *pricefeedrpc.TickerResponse {
+	return &pricefeedrpc.TickerResponse{
+		Exchange:    exchangeName,
+		AssetType:   assetType,
+		Pair:        rpcFromPair(t.Pair),
+		Last:        t.Last,
+		High:        t.High,
+		Low:         t.Low,
+		Bid:         t.Bid,
+		Ask:         t.Ask,
+		Volume:      t.Volume,
+		PriceAth:    t.PriceATH,
+		LastUpdated: t.LastUpdated.Unix(),
+	}
+}
+
+func rpcFromOrderbook(exchangeName, assetType string, ob orderbook.Base) *pricefeedrpc.OrderbookResponse {
+	resp := &pricefeedrpc.OrderbookResponse{
+		Exchange:    exchangeName,
+		AssetType:   assetType,
+		Pair:        rpcFromPair(ob.Pair),
+		LastUpdated: ob.LastUpdated.Unix(),
+	}
+
+	for x := range ob.Bids {
+		resp.Bids = append(resp.Bids, &pricefeedrpc.OrderbookItem{
+			Amount: ob.Bids[x].Amount,
+			Price:  ob.Bids[x].Price,
+		})
+	}
+
+	for x := range ob.Asks {
+		resp.Asks = append(resp.Asks, &pricefeedrpc.OrderbookItem{
+			Amount: ob.Asks[x].Amount,
+			Price:  ob.Asks[x].Price,
+		})
+	}
+	return resp
+}
+
+func rpcFromCoins(coins []portfolio.Coin) []*pricefeedrpc.Coin {
+	var result []*pricefeedrpc.Coin
+	for x := range coins {
+		result = append(result, &pricefeedrpc.Coin{
+			Coin:       coins[x].Coin,
+			Balance:    coins[x].Balance,
+			Address:    coins[x].Address,
+			Percentage: coins[x].Percentage,
+		})
+	}
+	return result
+}
+
+// rpcGetExchange returns a loaded and enabled exchange by name
+func rpcGetExchange(exchangeName string) (exchange.IBotExchange, error) {
+	exch := GetExchangeByName(bot, exchangeName)
+	if exch == nil || !exch.IsEnabled() {
+		return nil, status.Errorf(codes.NotFound, "exchange %s not loaded",
+			exchangeName)
+	}
+	return exch, nil
+}
+
+// rpcStreamMatches returns whether or not a feed event matches the supplied
+// stream filters, empty filters match everything
+func rpcStreamMatches(evt WebsocketEvent, p pair.CurrencyPair, exchangeName, assetType string) bool {
+	if exchangeName != "" && common.StringToLower(evt.Exchange) != common.StringToLower(exchangeName) {
+		return false
+	}
+
+	if assetType != "" && common.StringToUpper(evt.AssetType) != common.StringToUpper(assetType) {
+		return false
+	}
+
+	if p.FirstCurrency == "" {
+		return true
+	}
+
+	switch d := evt.Data.(type) {
+	case ticker.Price:
+		return d.Pair.Equal(p, true)
+	case orderbook.Base:
+		return d.Pair.Equal(p, true)
+	}
+	return false
+}
+
+// GetExchanges returns the status of the configured exchanges
+func (s *RPCServer) GetExchanges(ctx context.Context, r *pricefeedrpc.GetExchangesRequest) (*pricefeedrpc.GetExchangesResponse, error) {
+	var resp pricefeedrpc.GetExchangesResponse
+	exchanges := bot.Config.GetAllExchangeConfigs()
+	for x := range exchanges {
+		if r.Enabled && !exchanges[x].Enabled {
+			continue
+		}
+
+		exchStatus := &pricefeedrpc.ExchangeStatus{
+			Name:                    exchanges[x].Name,
+			Enabled:                 exchanges[x].Enabled,
+			Websocket:               exchanges[x].Websocket,
+			AuthenticatedApiSupport: exchanges[x].AuthenticatedAPISupport,
+			AssetTypes:              common.SplitStrings(exchanges[x].AssetTypes, ","),
+			PairsLastUpdated:        exchanges[x].PairsLastUpdated,
+		}
+
+		exch := GetExchangeByName(bot, exchanges[x].Name)
+		if exch != nil {
+			exchStatus.Loaded = true
+			enabledPairs := exch.GetEnabledCurrencies()
+			for y := range enabledPairs {
+				exchStatus.EnabledPairs = append(exchStatus.EnabledPairs,
+					enabledPairs[y].Pair().String())
+			}
+		}
+		resp.Exchanges = append(resp.Exchanges, exchStatus)
+	}
+	return &resp, nil
+}
+
+// GetTicker returns the ticker for an exchange, currency pair and asset type
+func (s *RPCServer) GetTicker(ctx context.Context, r *pricefeedrpc.GetTickerRequest) (*pricefeedrpc.TickerResponse, error) {
+	exch, err := rpcGetExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := rpcToPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	assetType := r.AssetType
+	if assetType == "" {
+		assetType = ticker.Spot
+	}
+
+	result, err := exch.GetTickerPrice(p, assetType)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return rpcFromTicker(exch.GetName(), assetType, result), nil
+}
+
+// GetTickers returns the tickers for all enabled exchanges
+func (s *RPCServer) GetTickers(ctx context.Context, r *pricefeedrpc.GetTickersRequest) (*pricefeedrpc.GetTickersResponse, error) {
+	var resp pricefeedrpc.GetTickersResponse
+	activeTickers := GetAllActiveTickers()
+	for x := range activeTickers {
+		tickers := &pricefeedrpc.Tickers{Exchange: activeTickers[x].ExchangeName}
+		for y := range activeTickers[x].ExchangeValues {
+			tickers.Tickers = append(tickers.Tickers,
+				rpcFromTicker(activeTickers[x].ExchangeName, "",
+					activeTickers[x].ExchangeValues[y]))
+		}
+		resp.Tickers = append(resp.Tickers, tickers)
+	}
+	return &resp, nil
+}
+
+// GetOrderbook returns the orderbook for an exchange, currency pair and asset
+// type
+func (s *RPCServer) GetOrderbook(ctx context.Context, r *pricefeedrpc.GetOrderbookRequest) (*pricefeedrpc.OrderbookResponse, error) {
+	exch, err := rpcGetExchange(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := rpcToPair(r.Pair)
+	if err != nil {
+		return nil, err
+	}
+
+	assetType := r.AssetType
+	if assetType == "" {
+		assetType = orderbook.Spot
+	}
+
+	result, err := exch.GetOrderbookEx(p, assetType)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return rpcFromOrderbook(exch.GetName(), assetType, result), nil
+}
+
+// GetOrderbooks returns the orderbooks for all enabled exchanges
+func (s *RPCServer) GetOrderbooks(ctx context.Context, r *pricefeedrpc.GetOrderbooksRequest) (*pricefeedrpc.GetOrderbooksResponse, error) {
+	var resp pricefeedrpc.GetOrderbooksResponse
+	activeOrderbooks := GetAllActiveOrderbooks()
+	for x := range activeOrderbooks {
+		orderbooks := &pricefeedrpc.Orderbooks{Exchange: activeOrderbooks[x].ExchangeName}
+		for y := range activeOrderbooks[x].ExchangeValues {
+			orderbooks.Orderbooks = append(orderbooks.Orderbooks,
+				rpcFromOrderbook(activeOrderbooks[x].ExchangeName, "",
+					activeOrderbooks[x].ExchangeValues[y]))
+		}
+		resp.Orderbooks = append(resp.Orderbooks, orderbooks)
+	}
+	return &resp, nil
+}
+
+// GetExchangeRates returns the current forex exchange rates
+func (s *RPCServer) GetExchangeRates(ctx context.Context, r *pricefeedrpc.GetExchangeRatesRequest) (*pricefeedrpc.GetExchangeRatesResponse, error) {
+	return &pricefeedrpc.GetExchangeRatesResponse{
+		Rates: currency.GetExchangeRates(),
+	}, nil
+}
+
+// GetPortfolioSummary returns the bot portfolio summary
+func (s *RPCServer) GetPortfolioSummary(ctx context.Context, r *pricefeedrpc.GetPortfolioSummaryRequest) (*pricefeedrpc.GetPortfolioSummaryResponse, error) {
+	result := bot.Portfolio.GetPortfolioSummary()
+	return &pricefeedrpc.GetPortfolioSummaryResponse{
+		CoinTotals:   rpcFromCoins(result.Totals),
+		CoinsOffline: rpcFromCoins(result.Offline),
+		CoinsOnline:  rpcFromCoins(result.Online),
+	}, nil
+}
+
+// SubscribeTicker streams ticker updates filtered by exchange, currency pair
+// and asset type
+func (s *RPCServer) SubscribeTicker(r *pricefeedrpc.SubscribeTickerRequest, stream pricefeedrpc.PriceFeeder_SubscribeTickerServer) error {
+	var p pair.CurrencyPair
+	var err error
+	if r.Pair != nil {
+		p, err = rpcToPair(r.Pair)
+		if err != nil {
+			return err
+		}
+	}
+
+	feed := SubscribeFeed(rpcStreamBufferSize)
+	defer UnsubscribeFeed(feed)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case evt := <-feed:
+			if evt.Event != FeedEventTicker || !rpcStreamMatches(evt, p, r.Exchange, r.AssetType) {
+				continue
+			}
+
+			err = stream.Send(rpcFromTicker(evt.Exchange, evt.AssetType,
+				evt.Data.(ticker.Price)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeOrderbook streams orderbook updates filtered by exchange, currency
+// pair and asset type
+func (s *RPCServer) SubscribeOrderbook(r *pricefeedrpc.SubscribeOrderbookRequest, stream pricefeedrpc.PriceFeeder_SubscribeOrderbookServer) error {
+	var p pair.CurrencyPair
+	var err error
+	if r.Pair != nil {
+		p, err = rpcToPair(r.Pair)
+		if err != nil {
+			return err
+		}
+	}
+
+	feed := SubscribeFeed(rpcStreamBufferSize)
+	defer UnsubscribeFeed(feed)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case evt := <-feed:
+			if evt.Event != FeedEventOrderbook || !rpcStreamMatches(evt, p, r.Exchange, r.AssetType) {
+				continue
+			}
+
+			err = stream.Send(rpcFromOrderbook(evt.Exchange, evt.AssetType,
+				evt.Data.(orderbook.Base)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/pricefeedrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestRPCClient serves the gRPC server over an in-memory listener and
+// returns a client connected to it
+func newTestRPCClient(t *testing.T) (pricefeedrpc.PriceFeederClient, func()) {
+	lis := bufconn.Listen(1024 * 1024)
+	s := newRPCServer()
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Test failed. Unable to dial the gRPC server: %s", err)
+	}
+
+	return pricefeedrpc.NewPriceFeederClient(conn), func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+// rpcTestContext returns a context with the bearer token set, if supplied
+func rpcTestContext(token string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	if token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	return ctx, cancel
+}
+
+func TestRPCServerAuth(t *testing.T) {
+	backupConfig := bot.Config
+	defer func() { bot.Config = backupConfig }()
+
+	bot.Config = &config.Config{}
+	bot.Config.Webserver = config.WebserverConfig{
+		RequireMarketDataAuth: true,
+		APIRateLimit:          100,
+		APITokens: []config.APITokenConfig{
+			{Name: "market", Enabled: true, Token: "rpcmarkettoken", Scopes: "read-market"},
+		},
+	}
+	currency.SetExchangeRates(map[string]float64{"USDAUD": 1.35}, time.Now())
+
+	client, stop := newTestRPCClient(t)
+	defer stop()
+
+	tests := []struct {
+		token    string
+		method   func(context.Context) error
+		expected codes.Code
+	}{
+		{"", func(ctx context.Context) error {
+			_, err := client.GetExchangeRates(ctx, &pricefeedrpc.GetExchangeRatesRequest{})
+			return err
+		}, codes.Unauthenticated},
+		{"invalid", func(ctx context.Context) error {
+			_, err := client.GetExchangeRates(ctx, &pricefeedrpc.GetExchangeRatesRequest{})
+			return err
+		}, codes.Unauthenticated},
+		{"rpcmarkettoken", func(ctx context.Context) error {
+			_, err := client.GetPortfolioSummary(ctx, &pricefeedrpc.GetPortfolioSummaryRequest{})
+			return err
+		}, codes.PermissionDenied},
+		{"rpcmarkettoken", func(ctx context.Context) error {
+			resp, err := client.GetExchangeRates(ctx, &pricefeedrpc.GetExchangeRatesRequest{})
+			if err == nil && resp.Rates["USDAUD"] != 1.35 {
+				t.Errorf("Test failed. GetExchangeRates unexpected rates %v", resp.Rates)
+			}
+			return err
+		}, codes.OK},
+		{"rpcmarkettoken", func(ctx context.Context) error {
+			_, err := client.GetTicker(ctx, &pricefeedrpc.GetTickerRequest{Exchange: "invalid"})
+			return err
+		}, codes.NotFound},
+		{"", func(ctx context.Context) error {
+			stream, err := client.SubscribeTicker(ctx, &pricefeedrpc.SubscribeTickerRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.Unauthenticated},
+	}
+
+	for x := range tests {
+		ctx, cancel := rpcTestContext(tests[x].token)
+		err := tests[x].method(ctx)
+		cancel()
+		if status.Code(err) != tests[x].expected {
+			t.Errorf("Test failed. gRPC test %d expected %s got %v", x,
+				tests[x].expected, err)
+		}
+	}
+}
+
+func TestRPCServerSubscribeTicker(t *testing.T) {
+	backupConfig := bot.Config
+	defer func() { bot.Config = backupConfig }()
+	bot.Config = &config.Config{}
+
+	client, stop := newTestRPCClient(t)
+	defer stop()
+
+	ctx, cancel := rpcTestContext("")
+	defer cancel()
+
+	stream, err := client.SubscribeTicker(ctx, &pricefeedrpc.SubscribeTickerRequest{
+		Exchange: "bitfinex",
+		Pair:     &pricefeedrpc.CurrencyPair{Base: "btc", Quote: "usd"},
+	})
+	if err != nil {
+		t.Fatalf("Test failed. SubscribeTicker: %s", err)
+	}
+
+	// The subscription is registered asynchronously, keep publishing until
+	// the first update is received
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		tick := time.NewTicker(time.Millisecond * 10)
+		defer tick.Stop()
+		for {
+			PublishFeedEvent(WebsocketEvent{
+				Exchange:  "Kraken",
+				AssetType: ticker.Spot,
+				Event:     FeedEventTicker,
+				Data:      ticker.Price{Pair: pair.NewCurrencyPair("BTC", "USD"), Last: 1},
+			})
+			PublishFeedEvent(WebsocketEvent{
+				Exchange:  "Bitfinex",
+				AssetType: ticker.Spot,
+				Event:     FeedEventTicker,
+				Data:      ticker.Price{Pair: pair.NewCurrencyPair("BTC", "USD"), Last: 6500},
+			})
+
+			select {
+			case <-done:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Test failed. SubscribeTicker Recv: %s", err)
+	}
+
+	if resp.Exchange != "Bitfinex" || resp.Last != 6500 || resp.Pair.Base != "BTC" {
+		t.Errorf("Test failed. SubscribeTicker unexpected update %v", resp)
+	}
+}
@@ -0,0 +1,194 @@
+// Package schedule parses cron expressions with minute, hour, day of month,
+// month and day of week fields and calculates when they next occur
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearch bounds the search for the next occurrence of impossible
+// expressions such as the 31st of February
+const maxSearch = time.Hour * 24 * 366 * 5
+
+var (
+	errInvalidFields = errors.New("cron expression requires 5 fields: minute hour day-of-month month day-of-week")
+	errNoOccurrence  = errors.New("cron expression never occurs")
+)
+
+// descriptors are the supported shorthand expressions
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field holds the allowed values of a cron field
+type field struct {
+	min, max int
+}
+
+var fields = []field{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 6},  // day of week, 7 is also Sunday
+}
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record unrestricted day fields, if both day fields
+	// are restricted a day matching either occurs
+	domAny, dowAny bool
+}
+
+// Parse parses a cron expression such as "0 8 * * 1-5" or a descriptor such
+// as "@daily". Fields support *, values, ranges, lists and steps
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, errInvalidFields
+	}
+
+	bits := make([]uint64, len(fields))
+	for i := range parts {
+		var err error
+		bits[i], err = parseField(parts[i], fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron field %q: %s", parts[i], err)
+		}
+	}
+
+	// 7 is an alias of Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	s := &Schedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: strings.HasPrefix(parts[2], "*"),
+		dowAny: strings.HasPrefix(parts[4], "*"),
+	}
+
+	if _, err := s.next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// parseField returns the bit set of the values matched by a field
+func parseField(expr string, f field) (uint64, error) {
+	max := f.max
+	if f.min == 0 && f.max == 6 {
+		max = 7
+	}
+
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.New("invalid step")
+			}
+			part = part[:i]
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.New("invalid range")
+			}
+			if end, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, errors.New("invalid range")
+			}
+		default:
+			var err error
+			if start, err = strconv.Atoi(part); err != nil {
+				return 0, errors.New("invalid value")
+			}
+			end = start
+			if step > 1 {
+				end = f.max
+			}
+		}
+
+		if start < f.min || end > max || start > end {
+			return 0, fmt.Errorf("value out of range %d-%d", f.min, f.max)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// matches returns whether the bit of the value is set
+func matches(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+// matchesDay returns whether the day of month and day of week fields match
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := matches(s.dom, t.Day())
+	dow := matches(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first occurrence after t in the location of t
+func (s *Schedule) Next(t time.Time) time.Time {
+	next, _ := s.next(t)
+	return next
+}
+
+// next returns the first occurrence after t, skipping the months, days and
+// hours which do not match
+func (s *Schedule) next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxSearch)
+
+	for t.Before(limit) {
+		switch {
+		case !matches(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !matches(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !matches(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+	}
+	return time.Time{}, errNoOccurrence
+}
@@ -0,0 +1,58 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "0 0 31 2 *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("test failed - Parse() accepted %q", expr)
+		}
+	}
+
+	for _, expr := range []string{"* * * * *", "@daily", "0 8 * * 1-5", "*/15 0-6,18 1,15 * 7", "5/20 * * * *"} {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("test failed - Parse() rejected %q: %s", expr, err)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+
+	// Friday 1 June 2018
+	start := time.Date(2018, 6, 1, 8, 30, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{"* * * * *", start, start.Add(time.Minute)},
+		{"@hourly", start, time.Date(2018, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{"@daily", start, time.Date(2018, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 8 * * 1-5", start, time.Date(2018, 6, 4, 8, 0, 0, 0, time.UTC)},
+		{"30 8 * * *", start, time.Date(2018, 6, 2, 8, 30, 0, 0, time.UTC)},
+		{"*/20 9 * * *", start, time.Date(2018, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", start, time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", start, time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either day field matches when both are restricted
+		{"0 0 15 * 0", start, time.Date(2018, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 7 * * 7", start, time.Date(2018, 6, 3, 7, 0, 0, 0, time.UTC)},
+		{"0 8 * * *", start.In(sydney), time.Date(2018, 6, 2, 8, 0, 0, 0, sydney)},
+	} {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("test failed - Parse(%q) error %s", tc.expr, err)
+		}
+
+		if next := s.Next(tc.from); !next.Equal(tc.expected) {
+			t.Errorf("test failed - Next(%q) expected %s got %s", tc.expr, tc.expected, next)
+		}
+	}
+}
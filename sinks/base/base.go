@@ -0,0 +1,281 @@
+package base
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// CSVHeader holds the column names of the CSV format, shared by every event
+// type so mixed events can be written to the same file
+var CSVHeader = []string{
+	"type", "timestamp", "exchange", "assetType", "pair", "last", "high", "low",
+	"bid", "ask", "volume", "spread", "bidDepth", "askDepth", "totalBids",
+	"totalAsks", "tradeId", "price", "amount", "side",
+}
+
+var pairReplacer = strings.NewReplacer("-", "", "_", "", "/", "")
+
+// Event is a ticker, orderbook summary or trade event sent to the output sinks
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Exchange  string    `json:"exchange"`
+	AssetType string    `json:"assetType,omitempty"`
+	Pair      string    `json:"pair"`
+	Last      float64   `json:"last,omitempty"`
+	High      float64   `json:"high,omitempty"`
+	Low       float64   `json:"low,omitempty"`
+	Bid       float64   `json:"bid,omitempty"`
+	Ask       float64   `json:"ask,omitempty"`
+	Volume    float64   `json:"volume,omitempty"`
+	Spread    float64   `json:"spread,omitempty"`
+	BidDepth  int       `json:"bidDepth,omitempty"`
+	AskDepth  int       `json:"askDepth,omitempty"`
+	TotalBids float64   `json:"totalBids,omitempty"`
+	TotalAsks float64   `json:"totalAsks,omitempty"`
+	TradeID   int64     `json:"tradeId,omitempty"`
+	Price     float64   `json:"price,omitempty"`
+	Amount    float64   `json:"amount,omitempty"`
+	Side      string    `json:"side,omitempty"`
+}
+
+// Base enforces standard variables across the sink packages
+type Base struct {
+	Name      string
+	Enabled   bool
+	Verbose   bool
+	Format    string
+	exchanges map[string]bool
+	pairs     map[string]bool
+	events    map[string]bool
+}
+
+// NewTickerEvent returns a ticker event
+func NewTickerEvent(exchangeName, assetType string, t ticker.Price) Event {
+	timestamp := t.LastUpdated
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	return Event{
+		Type:      config.SinkEventTicker,
+		Timestamp: timestamp.UTC(),
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Pair:      t.Pair.Pair().String(),
+		Last:      t.Last,
+		High:      t.High,
+		Low:       t.Low,
+		Bid:       t.Bid,
+		Ask:       t.Ask,
+		Volume:    t.Volume,
+	}
+}
+
+// NewOrderbookSummaryEvent returns an orderbook summary event holding the best
+// bid and ask, the spread, the depth and the total amounts of each side
+func NewOrderbookSummaryEvent(exchangeName, assetType string, o orderbook.Base) Event {
+	timestamp := o.LastUpdated
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	evt := Event{
+		Type:      config.SinkEventOrderbookSummary,
+		Timestamp: timestamp.UTC(),
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Pair:      o.Pair.Pair().String(),
+		BidDepth:  len(o.Bids),
+		AskDepth:  len(o.Asks),
+	}
+
+	evt.TotalBids, _ = o.CalculateTotalBids()
+	evt.TotalAsks, _ = o.CalculateTotalAsks()
+
+	if len(o.Bids) > 0 {
+		evt.Bid = o.Bids[0].Price
+	}
+
+	if len(o.Asks) > 0 {
+		evt.Ask = o.Asks[0].Price
+	}
+
+	if evt.Bid > 0 && evt.Ask > 0 {
+		evt.Spread = evt.Ask - evt.Bid
+	}
+	return evt
+}
+
+// NewTradeEvent returns a trade event. Exchanges report trade timestamps in
+// either seconds or milliseconds, values beyond the seconds range are treated
+// as milliseconds
+func NewTradeEvent(exchangeName, assetType, currencyPair string, t exchange.TradeHistory) Event {
+	var timestamp time.Time
+	switch {
+	case t.Timestamp > 1e12:
+		timestamp = time.Unix(0, t.Timestamp*int64(time.Millisecond))
+	case t.Timestamp > 0:
+		timestamp = time.Unix(t.Timestamp, 0)
+	default:
+		timestamp = time.Now()
+	}
+
+	return Event{
+		Type:      config.SinkEventTrade,
+		Timestamp: timestamp.UTC(),
+		Exchange:  exchangeName,
+		AssetType: assetType,
+		Pair:      currencyPair,
+		TradeID:   t.TID,
+		Price:     t.Price,
+		Amount:    t.Amount,
+		Side:      common.StringToLower(t.Type),
+	}
+}
+
+// normalisePair strips the delimiter from a currency pair
+func normalisePair(p string) string {
+	return pairReplacer.Replace(common.StringToUpper(p))
+}
+
+// filterSet converts a comma separated filter to a lookup map, nil matches
+// everything
+func filterSet(filter string, normalise func(string) string) map[string]bool {
+	if filter == "" {
+		return nil
+	}
+
+	set := make(map[string]bool)
+	for _, item := range common.SplitStrings(filter, ",") {
+		item = common.StringToLower(strings.TrimSpace(item))
+		if normalise != nil {
+			item = normalise(item)
+		}
+		set[item] = true
+	}
+	return set
+}
+
+// SetupBase sets the common sink variables and filters
+func (b *Base) SetupBase(cfg config.SinkConfig) {
+	b.Name = cfg.Name
+	b.Enabled = cfg.Enabled
+	b.Verbose = cfg.Verbose
+	b.Format = common.StringToLower(cfg.Format)
+	if b.Format == "" {
+		b.Format = config.SinkFormatJSON
+	}
+	b.exchanges = filterSet(cfg.Exchanges, nil)
+	b.pairs = filterSet(cfg.Pairs, normalisePair)
+	b.events = filterSet(cfg.Events, nil)
+}
+
+// IsEnabled returns if the sink has been enabled in the configuration
+func (b *Base) IsEnabled() bool {
+	return b.Enabled
+}
+
+// GetName returns the sink name
+func (b *Base) GetName() string {
+	return b.Name
+}
+
+// WantsEvent returns whether or not the sink accepts the event type
+func (b *Base) WantsEvent(eventType string) bool {
+	return b.events == nil || b.events[eventType]
+}
+
+// Matches returns whether or not the event passes the sink filters
+func (b *Base) Matches(evt Event) bool {
+	if !b.WantsEvent(evt.Type) {
+		return false
+	}
+
+	if b.exchanges != nil && !b.exchanges[common.StringToLower(evt.Exchange)] {
+		return false
+	}
+	return b.pairs == nil || b.pairs[normalisePair(evt.Pair)]
+}
+
+// EncodeCSVHeader returns the CSV header line
+func EncodeCSVHeader() []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(CSVHeader)
+	w.Flush()
+	return buf.Bytes()
+}
+
+// formatFloat formats a float without trailing zeros, zero is left empty
+func formatFloat(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatInt formats an integer, zero is left empty
+func formatInt(i int64) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+// CSVRecord returns the event as a CSV record in the CSVHeader column order
+func (e *Event) CSVRecord() []string {
+	return []string{
+		e.Type,
+		e.Timestamp.Format(time.RFC3339Nano),
+		e.Exchange,
+		e.AssetType,
+		e.Pair,
+		formatFloat(e.Last),
+		formatFloat(e.High),
+		formatFloat(e.Low),
+		formatFloat(e.Bid),
+		formatFloat(e.Ask),
+		formatFloat(e.Volume),
+		formatFloat(e.Spread),
+		formatInt(int64(e.BidDepth)),
+		formatInt(int64(e.AskDepth)),
+		formatFloat(e.TotalBids),
+		formatFloat(e.TotalAsks),
+		formatInt(e.TradeID),
+		formatFloat(e.Price),
+		formatFloat(e.Amount),
+		e.Side,
+	}
+}
+
+// Encode returns the event as a newline terminated JSON object or CSV record
+// depending on the sink format
+func (b *Base) Encode(evt Event) ([]byte, error) {
+	if b.Format == config.SinkFormatCSV {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		err := w.Write(evt.CSVRecord())
+		if err != nil {
+			return nil, err
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
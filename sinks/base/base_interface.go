@@ -0,0 +1,70 @@
+package base
+
+import (
+	"log"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+// ISinks is the main interface array across the sink packages
+type ISinks []ISink
+
+// ISink enforces standard functions across the sink packages
+type ISink interface {
+	Setup(cfg config.SinkConfig) error
+	Write(evt Event) error
+	Close() error
+	IsEnabled() bool
+	GetName() string
+	WantsEvent(eventType string) bool
+	Matches(evt Event) bool
+}
+
+// Publish writes the event to every enabled sink whose filters it matches
+func (s ISinks) Publish(evt Event) {
+	for i := range s {
+		if !s[i].IsEnabled() || !s[i].Matches(evt) {
+			continue
+		}
+
+		err := s[i].Write(evt)
+		if err != nil {
+			log.Printf("Sinks: %s failed to write %s event. Err: %s",
+				s[i].GetName(), evt.Type, err)
+		}
+	}
+}
+
+// WantsEvent returns whether or not an enabled sink accepts the event type
+func (s ISinks) WantsEvent(eventType string) bool {
+	for i := range s {
+		if s[i].IsEnabled() && s[i].WantsEvent(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close closes every sink
+func (s ISinks) Close() {
+	for i := range s {
+		err := s[i].Close()
+		if err != nil {
+			log.Printf("Sinks: %s failed to close. Err: %s", s[i].GetName(), err)
+		}
+	}
+}
+
+// GetEnabledSinks prints out the enabled sinks
+func (s ISinks) GetEnabledSinks() {
+	var count int
+	for i := range s {
+		if s[i].IsEnabled() {
+			log.Printf("Sinks: Sink %s is enabled.", s[i].GetName())
+			count++
+		}
+	}
+	if count == 0 {
+		log.Println("Sinks: No output sinks are enabled.")
+	}
+}
@@ -0,0 +1,130 @@
+package base
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+var b Base
+
+func TestSetupBase(t *testing.T) {
+	b.SetupBase(config.SinkConfig{
+		Name:      "test",
+		Enabled:   true,
+		Exchanges: "Bitfinex,Kraken",
+		Pairs:     "BTC-USD,XBTUSD",
+		Events:    "ticker,orderbook_summary",
+	})
+
+	if !b.IsEnabled() || b.GetName() != "test" || b.Format != config.SinkFormatJSON {
+		t.Error("test failed - base SetupBase() error")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	evt := Event{Type: config.SinkEventTicker, Exchange: "Bitfinex", Pair: "BTCUSD"}
+	if !b.Matches(evt) {
+		t.Error("test failed - base Matches() error")
+	}
+
+	evt.Exchange = "GDAX"
+	if b.Matches(evt) {
+		t.Error("test failed - base Matches() exchange filter error")
+	}
+
+	evt.Exchange = "Kraken"
+	evt.Pair = "ETHUSD"
+	if b.Matches(evt) {
+		t.Error("test failed - base Matches() pair filter error")
+	}
+
+	evt.Pair = "XBT_USD"
+	evt.Type = config.SinkEventTrade
+	if b.Matches(evt) || b.WantsEvent(config.SinkEventTrade) {
+		t.Error("test failed - base Matches() event filter error")
+	}
+
+	var all Base
+	all.SetupBase(config.SinkConfig{Enabled: true})
+	if !all.Matches(evt) {
+		t.Error("test failed - base Matches() empty filter error")
+	}
+}
+
+func TestNewOrderbookSummaryEvent(t *testing.T) {
+	evt := NewOrderbookSummaryEvent("Bitfinex", "SPOT", orderbook.Base{
+		Pair: pair.NewCurrencyPair("BTC", "USD"),
+		Bids: []orderbook.Item{{Price: 999, Amount: 1}, {Price: 998, Amount: 2}},
+		Asks: []orderbook.Item{{Price: 1001, Amount: 0.5}},
+	})
+
+	if evt.Type != config.SinkEventOrderbookSummary || evt.Pair != "BTCUSD" ||
+		evt.Bid != 999 || evt.Ask != 1001 || evt.Spread != 2 ||
+		evt.BidDepth != 2 || evt.AskDepth != 1 || evt.TotalBids != 3 ||
+		evt.TotalAsks != 0.5 {
+		t.Errorf("test failed - base NewOrderbookSummaryEvent() error %v", evt)
+	}
+}
+
+func TestNewTradeEvent(t *testing.T) {
+	evt := NewTradeEvent("Bitfinex", "SPOT", "BTCUSD", exchange.TradeHistory{
+		Timestamp: 1514764800000,
+		TID:       1,
+		Price:     1000,
+		Amount:    1,
+		Type:      "BUY",
+	})
+
+	if evt.Type != config.SinkEventTrade || evt.Side != "buy" ||
+		!evt.Timestamp.Equal(time.Unix(1514764800, 0)) {
+		t.Errorf("test failed - base NewTradeEvent() error %v", evt)
+	}
+
+	evt = NewTradeEvent("Bitfinex", "SPOT", "BTCUSD", exchange.TradeHistory{
+		Timestamp: 1514764800,
+	})
+	if !evt.Timestamp.Equal(time.Unix(1514764800, 0)) {
+		t.Error("test failed - base NewTradeEvent() seconds timestamp error")
+	}
+}
+
+func TestEncode(t *testing.T) {
+	evt := NewTickerEvent("Bitfinex", "SPOT", ticker.Price{
+		Pair:        pair.NewCurrencyPair("BTC", "USD"),
+		Last:        1000,
+		LastUpdated: time.Unix(1514764800, 0),
+	})
+
+	data, err := b.Encode(evt)
+	if err != nil {
+		t.Fatalf("test failed - base Encode() error %s", err)
+	}
+
+	expected := `{"type":"ticker","timestamp":"2018-01-01T00:00:00Z","exchange":"Bitfinex","assetType":"SPOT","pair":"BTCUSD","last":1000}` + "\n"
+	if string(data) != expected {
+		t.Errorf("test failed - base Encode() JSON error %s", data)
+	}
+
+	var csvBase Base
+	csvBase.SetupBase(config.SinkConfig{Format: "CSV"})
+	data, err = csvBase.Encode(evt)
+	if err != nil {
+		t.Fatalf("test failed - base Encode() error %s", err)
+	}
+
+	if string(data) != "ticker,2018-01-01T00:00:00Z,Bitfinex,SPOT,BTCUSD,1000,,,,,,,,,,,,,,\n" {
+		t.Errorf("test failed - base Encode() CSV error %s", data)
+	}
+
+	header := string(EncodeCSVHeader())
+	if strings.Count(header, ",") != strings.Count(string(data), ",") {
+		t.Error("test failed - base EncodeCSVHeader() column count mismatch")
+	}
+}
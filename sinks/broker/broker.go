@@ -0,0 +1,336 @@
+// Package broker batches sink events and publishes them to a message broker
+// client with at-least-once delivery. Messages are buffered locally and only
+// removed once the broker has acknowledged them
+package broker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+// Const vars for the broker publisher
+const (
+	initialRetryDelay = time.Millisecond * 500
+	maxRetryDelay     = time.Second * 30
+	closeFlushTimeout = time.Second * 5
+)
+
+var (
+	errPublisherClosed = errors.New("publisher closed")
+
+	topicPlaceholder  = regexp.MustCompile(`{[a-zA-Z]+}`)
+	topicInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_\-]`)
+)
+
+// Message is a single message published to a topic
+type Message struct {
+	Topic     string
+	Key       []byte
+	Value     []byte
+	Timestamp time.Time
+}
+
+// Client is implemented by the message broker clients
+type Client interface {
+	Connect() error
+	Send(msgs []Message) error
+	Close() error
+}
+
+// Publisher batches messages and sends them using a broker client, retrying
+// failed batches with an exponential backoff
+type Publisher struct {
+	Name          string
+	Verbose       bool
+	Topic         string
+	BatchSize     int
+	BatchInterval time.Duration
+	BufferSize    int
+
+	client    Client
+	connected bool
+	buffer    []Message
+	dropped   int
+	notify    chan struct{}
+	shutdown  chan struct{}
+	wg        sync.WaitGroup
+	mtx       sync.Mutex
+	sendMtx   sync.Mutex
+}
+
+// NewPublisher returns a publisher for the supplied client using the topic,
+// batching and buffer settings of the sink config
+func NewPublisher(client Client, cfg config.SinkConfig) *Publisher {
+	return &Publisher{
+		Name:          cfg.Name,
+		Verbose:       cfg.Verbose,
+		Topic:         cfg.Topic,
+		BatchSize:     cfg.BatchSize,
+		BatchInterval: cfg.BatchInterval,
+		BufferSize:    cfg.BufferSize,
+		client:        client,
+		notify:        make(chan struct{}, 1),
+		shutdown:      make(chan struct{}),
+	}
+}
+
+// RenderTopic replaces the {exchange}, {pair}, {assetType} and {event}
+// placeholders of a topic template with the event values. Values are stripped
+// of characters which aren't valid in Kafka topics or NATS subject tokens
+func RenderTopic(template string, evt base.Event) string {
+	return topicPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		var value string
+		switch placeholder {
+		case "{exchange}":
+			value = evt.Exchange
+		case "{pair}":
+			value = evt.Pair
+		case "{assetType}":
+			value = evt.AssetType
+		case "{event}":
+			value = evt.Type
+		default:
+			return placeholder
+		}
+		return topicInvalidChars.ReplaceAllString(value, "_")
+	})
+}
+
+// NewMessage returns the message for an encoded event, keyed by exchange and
+// pair so a pair's updates keep their order within a partition
+func (p *Publisher) NewMessage(evt base.Event, value []byte) Message {
+	return Message{
+		Topic:     RenderTopic(p.Topic, evt),
+		Key:       []byte(evt.Exchange + "." + evt.Pair),
+		Value:     bytes.TrimRight(value, "\n"),
+		Timestamp: evt.Timestamp,
+	}
+}
+
+// Start starts the batching routine
+func (p *Publisher) Start() {
+	p.wg.Add(1)
+	go p.run()
+}
+
+// Enqueue adds a message to the buffer, dropping the oldest message if the
+// buffer is full
+func (p *Publisher) Enqueue(msg Message) error {
+	select {
+	case <-p.shutdown:
+		return errPublisherClosed
+	default:
+	}
+
+	p.mtx.Lock()
+	if len(p.buffer) >= p.BufferSize {
+		p.buffer = p.buffer[1:]
+		p.dropped++
+		if p.dropped == 1 || p.dropped%p.BufferSize == 0 {
+			log.Printf("Sinks: %s buffer full, dropped %d messages", p.Name, p.dropped)
+		}
+	}
+	p.buffer = append(p.buffer, msg)
+	full := len(p.buffer) >= p.BatchSize
+	p.mtx.Unlock()
+
+	if full {
+		select {
+		case p.notify <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Buffered returns the number of messages awaiting delivery
+func (p *Publisher) Buffered() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return len(p.buffer)
+}
+
+// run sends the buffered batches every batch interval or whenever a full
+// batch is available
+func (p *Publisher) run() {
+	defer p.wg.Done()
+	timer := time.NewTimer(p.BatchInterval)
+	defer timer.Stop()
+
+	retryDelay := initialRetryDelay
+	for {
+		select {
+		case <-p.shutdown:
+			return
+		case <-timer.C:
+		case <-p.notify:
+		}
+
+		err := p.flush()
+		if err != nil {
+			log.Printf("Sinks: %s failed to publish, %d messages buffered, retrying in %v. Err: %s",
+				p.Name, p.Buffered(), retryDelay, err)
+			timer.Reset(retryDelay)
+			retryDelay *= 2
+			if retryDelay > maxRetryDelay {
+				retryDelay = maxRetryDelay
+			}
+			continue
+		}
+
+		retryDelay = initialRetryDelay
+		timer.Reset(p.BatchInterval)
+	}
+}
+
+// flush sends the buffered messages in batches, messages are only removed
+// from the buffer once the batch has been acknowledged
+func (p *Publisher) flush() error {
+	p.sendMtx.Lock()
+	defer p.sendMtx.Unlock()
+
+	for {
+		p.mtx.Lock()
+		size := len(p.buffer)
+		if size > p.BatchSize {
+			size = p.BatchSize
+		}
+		batch := make([]Message, size)
+		copy(batch, p.buffer)
+		dropped := p.dropped
+		p.mtx.Unlock()
+
+		if size == 0 {
+			return nil
+		}
+
+		if !p.connected {
+			err := p.client.Connect()
+			if err != nil {
+				return err
+			}
+			p.connected = true
+		}
+
+		err := p.client.Send(batch)
+		if err != nil {
+			p.connected = false
+			p.client.Close()
+			return err
+		}
+
+		if p.Verbose {
+			log.Printf("Sinks: %s published %d messages", p.Name, size)
+		}
+
+		// Enqueue may have dropped messages from the front of the buffer while
+		// the batch was being sent, those were the oldest messages of the batch
+		p.mtx.Lock()
+		sent := size - (p.dropped - dropped)
+		if sent > 0 {
+			p.buffer = p.buffer[sent:]
+		}
+		p.mtx.Unlock()
+	}
+}
+
+// Close stops the batching routine, attempts to send the remaining buffered
+// messages and closes the client
+func (p *Publisher) Close() error {
+	close(p.shutdown)
+	p.wg.Wait()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.flush()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Printf("Sinks: %s failed to publish %d buffered messages on close. Err: %s",
+				p.Name, p.Buffered(), err)
+		}
+	case <-time.After(closeFlushTimeout):
+		log.Printf("Sinks: %s timed out publishing %d buffered messages on close",
+			p.Name, p.Buffered())
+	}
+	return p.client.Close()
+}
+
+// Gzip compresses a payload
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SplitBrokers returns the broker addresses of a comma separated list
+func SplitBrokers(brokers string) []string {
+	var addresses []string
+	for _, broker := range strings.Split(brokers, ",") {
+		broker = strings.TrimSpace(broker)
+		if broker != "" {
+			addresses = append(addresses, broker)
+		}
+	}
+	return addresses
+}
+
+// TLSConfig returns the TLS config of a broker sink, or nil if TLS is disabled.
+// The server certificate is verified against the system roots or the
+// TLSCAFile and the optional client certificate is loaded from TLSCertFile
+// and TLSKeyFile
+func TLSConfig(cfg config.SinkConfig) (*tls.Config, error) {
+	if !cfg.TLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.TLSSkipVerify,
+	}
+
+	if cfg.TLSCAFile != "" {
+		ca, err := ioutil.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.TLSCAFile)
+		}
+	}
+
+	if cfg.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
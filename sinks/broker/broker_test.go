@@ -0,0 +1,273 @@
+package broker
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+func testPublisher(client Client, batchSize, bufferSize int) *Publisher {
+	return NewPublisher(client, config.SinkConfig{
+		Name:          "test",
+		Topic:         "prices.{exchange}.{pair}",
+		BatchSize:     batchSize,
+		BatchInterval: time.Millisecond * 10,
+		BufferSize:    bufferSize,
+	})
+}
+
+func waitForMessages(t *testing.T, m *Memory, count int) []Message {
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		msgs := m.Published()
+		if len(msgs) >= count {
+			return msgs
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	t.Fatalf("test failed - timed out waiting for %d messages", count)
+	return nil
+}
+
+func TestRenderTopic(t *testing.T) {
+	evt := base.Event{
+		Type:      config.SinkEventTicker,
+		Exchange:  "Bitfinex",
+		AssetType: "SPOT",
+		Pair:      "BTC/USD",
+	}
+
+	topic := RenderTopic("prices.{exchange}.{pair}.{assetType}.{event}.{unknown}", evt)
+	if topic != "prices.Bitfinex.BTC_USD.SPOT.ticker.{unknown}" {
+		t.Errorf("test failed - RenderTopic() returned %s", topic)
+	}
+}
+
+func TestPublisherBatching(t *testing.T) {
+	m := new(Memory)
+	p := testPublisher(m, 2, 10)
+	p.Start()
+
+	for i := 0; i < 5; i++ {
+		err := p.Enqueue(p.NewMessage(base.Event{Exchange: "Bitfinex", Pair: "BTCUSD"},
+			[]byte(fmt.Sprintf("%d\n", i))))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs := waitForMessages(t, m, 5)
+	for i := range msgs {
+		if string(msgs[i].Value) != fmt.Sprintf("%d", i) ||
+			msgs[i].Topic != "prices.Bitfinex.BTCUSD" ||
+			string(msgs[i].Key) != "Bitfinex.BTCUSD" {
+			t.Errorf("test failed - unexpected message %v", msgs[i])
+		}
+	}
+
+	if m.Batches < 3 {
+		t.Errorf("test failed - expected at least 3 batches got %d", m.Batches)
+	}
+
+	err := p.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Enqueue(Message{}) == nil {
+		t.Error("test failed - Enqueue() after Close() should return an error")
+	}
+}
+
+func TestPublisherOutage(t *testing.T) {
+	m := new(Memory)
+	m.SetAvailable(false)
+	p := testPublisher(m, 10, 3)
+
+	for i := 0; i < 5; i++ {
+		p.Enqueue(Message{Value: []byte(fmt.Sprintf("%d", i))})
+	}
+
+	if p.Buffered() != 3 {
+		t.Fatalf("test failed - expected 3 buffered messages got %d", p.Buffered())
+	}
+
+	if p.flush() == nil || p.Buffered() != 3 {
+		t.Fatal("test failed - flush() should keep the messages during an outage")
+	}
+
+	m.SetAvailable(true)
+	p.Start()
+	msgs := waitForMessages(t, m, 3)
+	if string(msgs[0].Value) != "2" || string(msgs[2].Value) != "4" {
+		t.Errorf("test failed - unexpected messages after outage %v", msgs)
+	}
+
+	err := p.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPublisherCloseFlush(t *testing.T) {
+	m := new(Memory)
+	p := testPublisher(m, 100, 100)
+	p.BatchInterval = time.Hour
+	p.Start()
+
+	p.Enqueue(Message{Value: []byte("pending")})
+	err := p.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(m.Published()) != 1 || p.Buffered() != 0 {
+		t.Error("test failed - Close() did not flush the buffered messages")
+	}
+}
+
+// blockingClient blocks the first Send until released
+type blockingClient struct {
+	Memory
+	sending chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingClient) Send(msgs []Message) error {
+	b.once.Do(func() {
+		close(b.sending)
+		<-b.release
+	})
+	return b.Memory.Send(msgs)
+}
+
+func TestPublisherOverflowDuringSend(t *testing.T) {
+	b := &blockingClient{sending: make(chan struct{}), release: make(chan struct{})}
+	p := testPublisher(b, 2, 3)
+
+	for i := 0; i < 3; i++ {
+		p.Enqueue(Message{Value: []byte(fmt.Sprintf("%d", i))})
+	}
+
+	errs := make(chan error)
+	go func() { errs <- p.flush() }()
+	<-b.sending
+
+	// Overflow the buffer while the first batch is being sent, dropping the
+	// messages of the batch in flight
+	for i := 3; i < 5; i++ {
+		p.Enqueue(Message{Value: []byte(fmt.Sprintf("%d", i))})
+	}
+	close(b.release)
+
+	err := <-errs
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := b.Published()
+	if len(msgs) != 5 || p.Buffered() != 0 {
+		t.Fatalf("test failed - expected 5 published messages got %d with %d buffered",
+			len(msgs), p.Buffered())
+	}
+
+	for i := range msgs {
+		if string(msgs[i].Value) != fmt.Sprintf("%d", i) {
+			t.Errorf("test failed - unsent message lost or sent message resent %v", msgs)
+			break
+		}
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its key to dir
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestTLSConfig(t *testing.T) {
+	tlsConfig, err := TLSConfig(config.SinkConfig{})
+	if err != nil || tlsConfig != nil {
+		t.Error("test failed - TLSConfig() returned a config with TLS disabled", err)
+	}
+
+	dir, err := ioutil.TempDir("", "broker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeTestCertificate(t, dir)
+	tlsConfig, err = TLSConfig(config.SinkConfig{
+		TLS:         true,
+		TLSCAFile:   certFile,
+		TLSCertFile: certFile,
+		TLSKeyFile:  keyFile,
+	})
+	if err != nil {
+		t.Fatal("test failed - TLSConfig() error", err)
+	}
+
+	if tlsConfig.RootCAs == nil || len(tlsConfig.Certificates) != 1 || tlsConfig.InsecureSkipVerify {
+		t.Errorf("test failed - TLSConfig() unexpected config %+v", tlsConfig)
+	}
+
+	_, err = TLSConfig(config.SinkConfig{TLS: true, TLSCAFile: keyFile})
+	if err == nil {
+		t.Error("test failed - TLSConfig() accepted a CA file without certificates")
+	}
+
+	_, err = TLSConfig(config.SinkConfig{TLS: true, TLSCertFile: certFile, TLSKeyFile: certFile})
+	if err == nil {
+		t.Error("test failed - TLSConfig() accepted an invalid key file")
+	}
+}
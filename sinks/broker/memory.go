@@ -0,0 +1,64 @@
+package broker
+
+import (
+	"errors"
+	"sync"
+)
+
+var errMemoryUnavailable = errors.New("memory broker unavailable")
+
+// Memory is an in-process broker client which stores the published messages.
+// It can be marked unavailable to simulate broker outages
+type Memory struct {
+	Messages    []Message
+	Batches     int
+	unavailable bool
+	mtx         sync.Mutex
+}
+
+// SetAvailable sets whether or not the broker accepts connections and messages
+func (m *Memory) SetAvailable(available bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.unavailable = !available
+}
+
+// Connect returns an error if the broker is unavailable
+func (m *Memory) Connect() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.unavailable {
+		return errMemoryUnavailable
+	}
+	return nil
+}
+
+// Send stores the messages
+func (m *Memory) Send(msgs []Message) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.unavailable {
+		return errMemoryUnavailable
+	}
+
+	m.Messages = append(m.Messages, msgs...)
+	m.Batches++
+	return nil
+}
+
+// Close is a no-op
+func (m *Memory) Close() error {
+	return nil
+}
+
+// Published returns a copy of the stored messages
+func (m *Memory) Published() []Message {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	msgs := make([]Message, len(m.Messages))
+	copy(msgs, m.Messages)
+	return msgs
+}
@@ -0,0 +1,156 @@
+// Package file writes sink events to a JSON lines or CSV file which is rotated
+// once it reaches its maximum size or age
+package file
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+// rotatedTimeFormat is appended to the name of rotated files
+const rotatedTimeFormat = "20060102T150405.000000000"
+
+// File is a rotating file sink
+type File struct {
+	base.Base
+	Path    string
+	MaxSize int64
+	MaxAge  time.Duration
+
+	file   *os.File
+	size   int64
+	opened time.Time
+	mtx    sync.Mutex
+}
+
+// Setup takes in a sink config and opens the output file
+func (f *File) Setup(cfg config.SinkConfig) error {
+	f.SetupBase(cfg)
+	f.Path = cfg.Path
+	f.MaxSize = cfg.MaxSize
+	f.MaxAge = cfg.MaxAge
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.open()
+}
+
+// open opens or creates the output file, writing the CSV header to new files
+func (f *File) open() error {
+	dir := filepath.Dir(f.Path)
+	err := os.MkdirAll(dir, 0770)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.opened = time.Now()
+
+	if f.size == 0 && f.Format == config.SinkFormatCSV {
+		n, err := f.file.Write(base.EncodeCSVHeader())
+		f.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RotatedPath returns the path a file is moved to when rotated at the
+// supplied time
+func RotatedPath(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s-%s%s", path[:len(path)-len(ext)],
+		t.UTC().Format(rotatedTimeFormat), ext)
+}
+
+// shouldRotate returns whether or not the file has reached its maximum size
+// or age
+func (f *File) shouldRotate(pending int) bool {
+	if f.MaxSize > 0 && f.size > 0 && f.size+int64(pending) > f.MaxSize {
+		return true
+	}
+	return f.MaxAge > 0 && time.Since(f.opened) >= f.MaxAge
+}
+
+// rotate closes the current file, renames it and opens a new one
+func (f *File) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+
+	rotated := RotatedPath(f.Path, time.Now())
+	err = os.Rename(f.Path, rotated)
+	if err != nil {
+		return err
+	}
+
+	if f.Verbose {
+		log.Printf("Sinks: %s rotated %s to %s", f.Name, f.Path, rotated)
+	}
+	return f.open()
+}
+
+// Write appends the event to the file, rotating it beforehand if required
+func (f *File) Write(evt base.Event) error {
+	data, err := f.Encode(evt)
+	if err != nil {
+		return err
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil {
+		err = f.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if f.shouldRotate(len(data)) {
+		err = f.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return err
+}
+
+// Close closes the output file
+func (f *File) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,96 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+func TestRotatedPath(t *testing.T) {
+	p := RotatedPath("/tmp/feed.jsonl", time.Date(2018, 1, 2, 3, 4, 5, 6, time.UTC))
+	if p != "/tmp/feed-20180102T030405.000000006.jsonl" {
+		t.Errorf("test failed - file RotatedPath() error %s", p)
+	}
+}
+
+func TestWriteRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sinks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var f File
+	err = f.Setup(config.SinkConfig{
+		Name:    "test",
+		Enabled: true,
+		Format:  config.SinkFormatCSV,
+		Path:    filepath.Join(dir, "feed.csv"),
+		MaxSize: 300,
+	})
+	if err != nil {
+		t.Fatalf("test failed - file Setup() error %s", err)
+	}
+
+	evt := base.Event{
+		Type:      config.SinkEventTicker,
+		Timestamp: time.Now(),
+		Exchange:  "Bitfinex",
+		Pair:      "BTCUSD",
+		Last:      1000,
+	}
+
+	for i := 0; i < 5; i++ {
+		err = f.Write(evt)
+		if err != nil {
+			t.Fatalf("test failed - file Write() error %s", err)
+		}
+	}
+
+	err = f.Close()
+	if err != nil {
+		t.Fatalf("test failed - file Close() error %s", err)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) < 2 {
+		t.Fatalf("test failed - file Write() did not rotate, %d files", len(files))
+	}
+
+	for x := range files {
+		data, err := ioutil.ReadFile(filepath.Join(dir, files[x].Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if files[x].Size() > 300 {
+			t.Errorf("test failed - file %s exceeded the maximum size", files[x].Name())
+		}
+
+		if !strings.HasPrefix(string(data), "type,timestamp,") {
+			t.Errorf("test failed - file %s is missing the CSV header", files[x].Name())
+		}
+	}
+}
+
+func TestShouldRotateAge(t *testing.T) {
+	f := File{MaxAge: time.Minute, opened: time.Now().Add(-time.Hour)}
+	if !f.shouldRotate(1) {
+		t.Error("test failed - file shouldRotate() age error")
+	}
+
+	f.opened = time.Now()
+	if f.shouldRotate(1) {
+		t.Error("test failed - file shouldRotate() age error")
+	}
+}
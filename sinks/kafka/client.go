@@ -0,0 +1,162 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+// Const vars for the Kafka client
+const (
+	clientID       = "pricefeeder"
+	requestTimeout = time.Second * 10
+
+	// batchTimeout is how long the writer waits to fill a partition batch,
+	// the publisher already batches the messages so it is kept short
+	batchTimeout = time.Millisecond * 10
+)
+
+var errNoBrokers = errors.New("kafka: no brokers available")
+
+// Client publishes batches with a kafka-go writer. Messages are partitioned
+// by key and each batch is acknowledged once all in-sync replicas have it
+type Client struct {
+	Brokers     []string
+	BatchSize   int
+	Compression kafkago.Compression
+
+	transport *kafkago.Transport
+	writer    *kafkago.Writer
+	mtx       sync.Mutex
+}
+
+// NewClient returns a client for the brokers of the sink config, connecting
+// with TLS and SASL authentication if configured
+func NewClient(cfg config.SinkConfig) (*Client, error) {
+	tlsConfig, err := broker.TLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	mechanism, err := saslMechanism(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		Brokers:   broker.SplitBrokers(cfg.Brokers),
+		BatchSize: cfg.BatchSize,
+		transport: &kafkago.Transport{
+			ClientID:    clientID,
+			DialTimeout: requestTimeout,
+			TLS:         tlsConfig,
+			SASL:        mechanism,
+		},
+	}
+
+	if cfg.Compression == config.SinkCompressionGzip {
+		c.Compression = kafkago.Gzip
+	}
+	return c, nil
+}
+
+// saslMechanism returns the SASL mechanism of the sink config, or nil if
+// authentication is disabled
+func saslMechanism(cfg config.SinkConfig) (sasl.Mechanism, error) {
+	switch cfg.SASLMechanism {
+	case "":
+		return nil, nil
+	case config.SinkSASLPlain:
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case config.SinkSASLSCRAMSHA256:
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case config.SinkSASLSCRAMSHA512:
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	}
+	return nil, fmt.Errorf("kafka: unsupported SASL mechanism %s", cfg.SASLMechanism)
+}
+
+// Connect requests the cluster metadata to check the brokers are reachable
+// and accept the credentials, then creates the writer
+func (c *Client) Connect() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if len(c.Brokers) == 0 {
+		return errNoBrokers
+	}
+
+	addr := kafkago.TCP(c.Brokers...)
+	client := kafkago.Client{Addr: addr, Timeout: requestTimeout, Transport: c.transport}
+	_, err := client.Metadata(context.Background(), &kafkago.MetadataRequest{})
+	if err != nil {
+		return err
+	}
+
+	c.close()
+	c.writer = &kafkago.Writer{
+		Addr:                   addr,
+		Balancer:               &kafkago.Hash{},
+		MaxAttempts:            1,
+		BatchSize:              c.BatchSize,
+		BatchTimeout:           batchTimeout,
+		WriteTimeout:           requestTimeout,
+		RequiredAcks:           kafkago.RequireAll,
+		Compression:            c.Compression,
+		Transport:              c.transport,
+		AllowAutoTopicCreation: true,
+	}
+	return nil
+}
+
+// Send writes the messages and waits for them to be acknowledged. Failed
+// batches are retried by the publisher
+func (c *Client) Send(msgs []broker.Message) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.writer == nil {
+		return errNoBrokers
+	}
+
+	records := make([]kafkago.Message, len(msgs))
+	for x := range msgs {
+		records[x] = kafkago.Message{
+			Topic: msgs[x].Topic,
+			Key:   msgs[x].Key,
+			Value: msgs[x].Value,
+			Time:  msgs[x].Timestamp,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return c.writer.WriteMessages(ctx, records...)
+}
+
+// close closes the writer. The caller must hold the lock
+func (c *Client) close() {
+	if c.writer != nil {
+		c.writer.Close()
+		c.writer = nil
+	}
+}
+
+// Close closes the writer and the idle broker connections
+func (c *Client) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.close()
+	c.transport.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package kafka publishes sink events to Kafka topics
+package kafka
+
+import (
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+// Kafka is a Kafka topic sink
+type Kafka struct {
+	base.Base
+	publisher *broker.Publisher
+}
+
+// Setup takes in a sink config and starts the batching publisher, the brokers
+// are connected on the first batch
+func (k *Kafka) Setup(cfg config.SinkConfig) error {
+	k.SetupBase(cfg)
+	client, err := NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	k.publisher = broker.NewPublisher(client, cfg)
+	k.publisher.Start()
+	return nil
+}
+
+// Write queues the event for the next batch
+func (k *Kafka) Write(evt base.Event) error {
+	data, err := k.Encode(evt)
+	if err != nil {
+		return err
+	}
+	return k.publisher.Enqueue(k.publisher.NewMessage(evt, data))
+}
+
+// Close publishes the buffered events and closes the broker connections
+func (k *Kafka) Close() error {
+	return k.publisher.Close()
+}
@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"net"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := map[string]string{
+		"":                         "",
+		config.SinkSASLPlain:       "PLAIN",
+		config.SinkSASLSCRAMSHA256: "SCRAM-SHA-256",
+		config.SinkSASLSCRAMSHA512: "SCRAM-SHA-512",
+	}
+
+	for mechanism, expected := range tests {
+		client, err := NewClient(config.SinkConfig{
+			Brokers:       "localhost:9092, localhost:9093",
+			BatchSize:     50,
+			Compression:   config.SinkCompressionGzip,
+			TLS:           true,
+			SASLMechanism: mechanism,
+			Username:      "user",
+			Password:      "pass",
+		})
+		if err != nil {
+			t.Fatalf("test failed - NewClient() %s error %s", mechanism, err)
+		}
+
+		var name string
+		if client.transport.SASL != nil {
+			name = client.transport.SASL.Name()
+		}
+
+		if name != expected || client.transport.TLS == nil ||
+			client.Compression != kafkago.Gzip || client.BatchSize != 50 ||
+			len(client.Brokers) != 2 || client.Brokers[1] != "localhost:9093" {
+			t.Errorf("test failed - NewClient() %s unexpected client %+v", mechanism, client)
+		}
+	}
+
+	_, err := NewClient(config.SinkConfig{SASLMechanism: "gssapi"})
+	if err == nil {
+		t.Error("test failed - NewClient() accepted an unsupported SASL mechanism")
+	}
+
+	_, err = NewClient(config.SinkConfig{TLS: true, TLSCAFile: "missing.pem"})
+	if err == nil {
+		t.Error("test failed - NewClient() accepted a missing CA file")
+	}
+}
+
+func TestClientConnectFailure(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	client, err := NewClient(config.SinkConfig{Brokers: address})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.Connect() == nil {
+		t.Error("test failed - Connect() should fail when the brokers are unavailable")
+	}
+
+	if client.Send([]broker.Message{{Topic: "test"}}) != errNoBrokers {
+		t.Error("test failed - Send() should fail when not connected")
+	}
+
+	if client.Close() != nil {
+		t.Error("test failed - Close() error")
+	}
+}
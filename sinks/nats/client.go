@@ -0,0 +1,144 @@
+package nats
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+// Const vars for the NATS client
+const (
+	clientName     = "pricefeeder"
+	requestTimeout = time.Second * 10
+
+	headerContentEncoding = "Content-Encoding"
+)
+
+var (
+	errNoServers          = errors.New("nats: no servers available")
+	errHeadersUnsupported = errors.New("nats: server does not support headers required for compression")
+)
+
+// Client publishes batches with a nats.go connection. Each batch is flushed
+// so it is acknowledged once the server has processed it. Reconnects are left
+// to the publisher so a failed batch is never reported as sent
+type Client struct {
+	Servers []string
+	Gzip    bool
+
+	options []natsgo.Option
+	conn    *natsgo.Conn
+	mtx     sync.Mutex
+}
+
+// NewClient returns a client for the servers of the sink config, connecting
+// with TLS and user or token authentication if configured
+func NewClient(cfg config.SinkConfig) (*Client, error) {
+	tlsConfig, err := broker.TLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		Servers: broker.SplitBrokers(cfg.Brokers),
+		Gzip:    cfg.Compression == config.SinkCompressionGzip,
+		options: []natsgo.Option{
+			natsgo.Name(clientName),
+			natsgo.Timeout(requestTimeout),
+			natsgo.NoReconnect(),
+			natsgo.DontRandomize(),
+		},
+	}
+
+	if tlsConfig != nil {
+		c.options = append(c.options, natsgo.Secure(tlsConfig))
+	}
+
+	if cfg.Username != "" {
+		c.options = append(c.options, natsgo.UserInfo(cfg.Username, cfg.Password))
+	}
+
+	if cfg.Token != "" {
+		c.options = append(c.options, natsgo.Token(cfg.Token))
+	}
+	return c, nil
+}
+
+// Connect connects to the first available server
+func (c *Client) Connect() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.close()
+	if len(c.Servers) == 0 {
+		return errNoServers
+	}
+
+	urls := make([]string, len(c.Servers))
+	for x := range c.Servers {
+		urls[x] = "nats://" + c.Servers[x]
+	}
+
+	conn, err := natsgo.Connect(strings.Join(urls, ","), c.options...)
+	if err != nil {
+		return err
+	}
+
+	if c.Gzip && !conn.HeadersSupported() {
+		conn.Close()
+		return errHeadersUnsupported
+	}
+	c.conn = conn
+	return nil
+}
+
+// Send publishes the messages and flushes the connection
+func (c *Client) Send(msgs []broker.Message) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.conn == nil {
+		return errNoServers
+	}
+
+	for x := range msgs {
+		msg := &natsgo.Msg{Subject: msgs[x].Topic, Data: msgs[x].Value}
+		if c.Gzip {
+			payload, err := broker.Gzip(msgs[x].Value)
+			if err != nil {
+				return err
+			}
+
+			msg.Data = payload
+			msg.Header = natsgo.Header{}
+			msg.Header.Set(headerContentEncoding, "gzip")
+		}
+
+		err := c.conn.PublishMsg(msg)
+		if err != nil {
+			return err
+		}
+	}
+	return c.conn.FlushTimeout(requestTimeout)
+}
+
+// close closes the connection. The caller must hold the lock
+func (c *Client) close() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// Close closes the server connection
+func (c *Client) Close() error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.close()
+	return nil
+}
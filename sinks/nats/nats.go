@@ -0,0 +1,42 @@
+// Package nats publishes sink events to NATS subjects
+package nats
+
+import (
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+// NATS is a NATS subject sink
+type NATS struct {
+	base.Base
+	publisher *broker.Publisher
+}
+
+// Setup takes in a sink config and starts the batching publisher, the servers
+// are connected on the first batch
+func (n *NATS) Setup(cfg config.SinkConfig) error {
+	n.SetupBase(cfg)
+	client, err := NewClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	n.publisher = broker.NewPublisher(client, cfg)
+	n.publisher.Start()
+	return nil
+}
+
+// Write queues the event for the next batch
+func (n *NATS) Write(evt base.Event) error {
+	data, err := n.Encode(evt)
+	if err != nil {
+		return err
+	}
+	return n.publisher.Enqueue(n.publisher.NewMessage(evt, data))
+}
+
+// Close publishes the buffered events and closes the server connection
+func (n *NATS) Close() error {
+	return n.publisher.Close()
+}
@@ -0,0 +1,214 @@
+package nats
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/broker"
+)
+
+// fakeServer is a NATS server which records published payloads by subject
+type fakeServer struct {
+	listener net.Listener
+	headers  bool
+	payloads map[string][]string
+	connect  map[string]interface{}
+	mtx      sync.Mutex
+}
+
+func newFakeServer(t *testing.T, headers bool) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeServer{listener: listener, headers: headers, payloads: make(map[string][]string)}
+	go func() {
+		for {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(t, c)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(t *testing.T, c net.Conn) {
+	defer c.Close()
+	fmt.Fprintf(c, "INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"headers\":%t}\r\n", s.headers)
+
+	r := bufio.NewReader(c)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "CONNECT":
+			var options map[string]interface{}
+			err = json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &options)
+			if err != nil {
+				t.Errorf("test failed - invalid CONNECT options: %s", err)
+			}
+
+			s.mtx.Lock()
+			s.connect = options
+			s.mtx.Unlock()
+		case "PING":
+			fmt.Fprint(c, "PONG\r\n")
+		case "PUB", "HPUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			_, err = io.ReadFull(r, payload)
+			if err != nil {
+				return
+			}
+			payload = payload[:size]
+
+			if fields[0] == "HPUB" {
+				headerSize, _ := strconv.Atoi(fields[2])
+				if !strings.Contains(string(payload[:headerSize]), "Content-Encoding: gzip") {
+					t.Error("test failed - missing content encoding header")
+				}
+
+				gz, err := gzip.NewReader(bytes.NewReader(payload[headerSize:]))
+				if err != nil {
+					t.Errorf("test failed - invalid gzip payload: %s", err)
+					return
+				}
+
+				payload, err = ioutil.ReadAll(gz)
+				if err != nil {
+					t.Errorf("test failed - invalid gzip payload: %s", err)
+					return
+				}
+			}
+
+			s.mtx.Lock()
+			s.payloads[fields[1]] = append(s.payloads[fields[1]], string(payload))
+			s.mtx.Unlock()
+		default:
+			fmt.Fprintf(c, "-ERR 'Unknown Protocol Operation'\r\n")
+		}
+	}
+}
+
+func TestClientSend(t *testing.T) {
+	for _, gzip := range []bool{false, true} {
+		fake := newFakeServer(t, true)
+		cfg := config.SinkConfig{Brokers: "127.0.0.1:1," + fake.listener.Addr().String()}
+		if gzip {
+			cfg.Compression = config.SinkCompressionGzip
+		}
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = client.Connect()
+		if err != nil {
+			t.Fatalf("test failed - Connect() error %s", err)
+		}
+
+		err = client.Send([]broker.Message{
+			{Topic: "prices.Bitfinex.BTCUSD", Value: []byte("1")},
+			{Topic: "prices.Bitfinex.BTCUSD", Value: []byte("2")},
+			{Topic: "prices.Kraken.XBTUSD", Value: []byte("3")},
+		})
+		if err != nil {
+			t.Fatalf("test failed - Send() error %s", err)
+		}
+
+		fake.mtx.Lock()
+		btc := fake.payloads["prices.Bitfinex.BTCUSD"]
+		xbt := fake.payloads["prices.Kraken.XBTUSD"]
+		fake.mtx.Unlock()
+		if len(btc) != 2 || btc[0] != "1" || btc[1] != "2" || len(xbt) != 1 || xbt[0] != "3" {
+			t.Errorf("test failed - unexpected published payloads %v %v", btc, xbt)
+		}
+
+		client.Close()
+		fake.listener.Close()
+	}
+}
+
+func TestClientConnectFailure(t *testing.T) {
+	fake := newFakeServer(t, false)
+	defer fake.listener.Close()
+
+	client, err := NewClient(config.SinkConfig{
+		Brokers:     fake.listener.Addr().String(),
+		Compression: config.SinkCompressionGzip,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.Connect() != errHeadersUnsupported {
+		t.Error("test failed - Connect() should fail when compression needs headers")
+	}
+
+	if client.Send([]broker.Message{{Topic: "test"}}) == nil {
+		t.Error("test failed - Send() should fail when not connected")
+	}
+}
+
+func TestClientAuth(t *testing.T) {
+	fake := newFakeServer(t, true)
+	defer fake.listener.Close()
+
+	tests := []struct {
+		cfg      config.SinkConfig
+		expected map[string]string
+	}{
+		{config.SinkConfig{Username: "user", Password: "pass"}, map[string]string{"user": "user", "pass": "pass"}},
+		{config.SinkConfig{Token: "token"}, map[string]string{"auth_token": "token"}},
+	}
+
+	for _, test := range tests {
+		test.cfg.Brokers = fake.listener.Addr().String()
+		client, err := NewClient(test.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = client.Connect()
+		if err != nil {
+			t.Fatalf("test failed - Connect() error %s", err)
+		}
+		client.Close()
+
+		fake.mtx.Lock()
+		for key, value := range test.expected {
+			if fake.connect[key] != value {
+				t.Errorf("test failed - expected CONNECT %s %s got %v", key, value, fake.connect[key])
+			}
+		}
+		fake.mtx.Unlock()
+	}
+
+	_, err := NewClient(config.SinkConfig{TLS: true, TLSCAFile: "missing.pem"})
+	if err == nil {
+		t.Error("test failed - NewClient() accepted a missing CA file")
+	}
+}
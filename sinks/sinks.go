@@ -0,0 +1,75 @@
+// Package sinks sends ticker, orderbook summary and trade events to the
+// configured output sinks
+package sinks
+
+import (
+	"log"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/file"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/kafka"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/nats"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/stdout"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/udp"
+)
+
+// Sinks is the overarching type across the sink packages
+type Sinks struct {
+	base.ISinks
+}
+
+// NewSinks sets up and returns a pointer to a Sinks object
+func NewSinks(cfg []config.SinkConfig) *Sinks {
+	var sinks Sinks
+
+	for x := range cfg {
+		if !cfg[x].Enabled {
+			continue
+		}
+
+		var sink base.ISink
+		switch common.StringToLower(cfg[x].Type) {
+		case config.SinkTypeFile:
+			sink = new(file.File)
+		case config.SinkTypeUDP:
+			sink = new(udp.UDP)
+		case config.SinkTypeStdout:
+			sink = new(stdout.Stdout)
+		case config.SinkTypeKafka:
+			sink = new(kafka.Kafka)
+		case config.SinkTypeNATS:
+			sink = new(nats.NATS)
+		default:
+			log.Printf("Sinks: %s has unsupported type %s", cfg[x].Name, cfg[x].Type)
+			continue
+		}
+
+		err := sink.Setup(cfg[x])
+		if err != nil {
+			log.Printf("Sinks: %s failed to setup. Err: %s", cfg[x].Name, err)
+			continue
+		}
+		sinks.ISinks = append(sinks.ISinks, sink)
+	}
+	return &sinks
+}
+
+// PublishTicker sends a ticker event to the sinks
+func (s *Sinks) PublishTicker(exchangeName, assetType string, t ticker.Price) {
+	s.Publish(base.NewTickerEvent(exchangeName, assetType, t))
+}
+
+// PublishOrderbookSummary sends an orderbook summary event to the sinks
+func (s *Sinks) PublishOrderbookSummary(exchangeName, assetType string, o orderbook.Base) {
+	s.Publish(base.NewOrderbookSummaryEvent(exchangeName, assetType, o))
+}
+
+// PublishTrade sends a trade event to the sinks
+func (s *Sinks) PublishTrade(exchangeName, assetType, currencyPair string, t exchange.TradeHistory) {
+	s.Publish(base.NewTradeEvent(exchangeName, assetType, currencyPair, t))
+}
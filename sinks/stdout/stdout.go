@@ -0,0 +1,49 @@
+// Package stdout writes sink events to the standard output
+package stdout
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+// Stdout is a standard output sink
+type Stdout struct {
+	base.Base
+
+	out io.Writer
+	mtx sync.Mutex
+}
+
+// Setup takes in a sink config and writes the CSV header if required
+func (s *Stdout) Setup(cfg config.SinkConfig) error {
+	s.SetupBase(cfg)
+	s.out = os.Stdout
+
+	if s.Format == config.SinkFormatCSV {
+		_, err := s.out.Write(base.EncodeCSVHeader())
+		return err
+	}
+	return nil
+}
+
+// Write writes the event to the standard output
+func (s *Stdout) Write(evt base.Event) error {
+	data, err := s.Encode(evt)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, err = s.out.Write(data)
+	return err
+}
+
+// Close is a no-op as the standard output is left open
+func (s *Stdout) Close() error {
+	return nil
+}
@@ -0,0 +1,49 @@
+// Package udp sends each sink event as a JSON or CSV datagram
+package udp
+
+import (
+	"net"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/sinks/base"
+)
+
+// UDP is a datagram sink
+type UDP struct {
+	base.Base
+	Address string
+
+	conn net.Conn
+}
+
+// Setup takes in a sink config and dials the destination address
+func (u *UDP) Setup(cfg config.SinkConfig) error {
+	u.SetupBase(cfg)
+	u.Address = cfg.Address
+
+	conn, err := net.Dial("udp", u.Address)
+	if err != nil {
+		return err
+	}
+	u.conn = conn
+	return nil
+}
+
+// Write sends the event as a single datagram
+func (u *UDP) Write(evt base.Event) error {
+	data, err := u.Encode(evt)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.conn.Write(data)
+	return err
+}
+
+// Close closes the connection
+func (u *UDP) Close() error {
+	if u.conn == nil {
+		return nil
+	}
+	return u.conn.Close()
+}
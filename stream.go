@@ -0,0 +1,362 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the server-sent events stream
+const (
+	streamReplayBufferSize    = 1024
+	streamSubscriberBuffer    = 256
+	streamFeedBufferSize      = 4096
+	streamHeartbeatInterval   = time.Second * 15
+	streamRetryInterval       = time.Second * 3
+	streamQueryEvents         = "events"
+	streamQueryLastEventID    = "lastEventId"
+	streamHeaderLastEventID   = "Last-Event-ID"
+	streamEventTicker         = "ticker"
+	streamEventOrderbook      = "orderbook"
+	streamEventReset          = "reset"
+	streamDefaultEventsFilter = streamEventTicker + "," + streamEventOrderbook
+)
+
+// StreamEvent is a ticker or orderbook update with its stream event ID
+type StreamEvent struct {
+	ID        uint64
+	Event     string
+	Exchange  string
+	AssetType string
+	Data      interface{}
+}
+
+// StreamReset is sent to a resuming subscriber when events after its
+// Last-Event-ID are no longer in the replay buffer, or the Last-Event-ID was
+// issued before a restart. OldestEventID is the first replayed event ID
+type StreamReset struct {
+	LastEventID   uint64 `json:"lastEventId"`
+	OldestEventID uint64 `json:"oldestEventId,omitempty"`
+}
+
+// StreamBroker assigns IDs to the feed events, keeps a short replay buffer and
+// fans out the events to the stream subscribers
+type StreamBroker struct {
+	replay      []StreamEvent
+	nextID      uint64
+	subscribers map[chan StreamEvent]bool
+	mtx         sync.Mutex
+}
+
+var (
+	streamBroker       *StreamBroker
+	streamBrokerOnce   sync.Once
+	streamPairReplacer = strings.NewReplacer("-", "", "_", "")
+)
+
+// NewStreamBroker returns a new stream broker
+func NewStreamBroker() *StreamBroker {
+	return &StreamBroker{
+		nextID:      1,
+		subscribers: make(map[chan StreamEvent]bool),
+	}
+}
+
+// StartStreamBroker starts the stream broker fed by the same event source as
+// the websocket broadcasts
+func StartStreamBroker() {
+	streamBrokerOnce.Do(func() {
+		streamBroker = NewStreamBroker()
+		go func() {
+			feed := SubscribeFeed(streamFeedBufferSize)
+			for evt := range feed {
+				streamBroker.Publish(evt)
+			}
+		}()
+	})
+}
+
+// Publish converts a feed event to a stream event, stores it in the replay
+// buffer and sends it to the subscribers. Subscribers which can't keep up are
+// disconnected so they can resume using the Last-Event-ID header
+func (s *StreamBroker) Publish(evt WebsocketEvent) {
+	streamEvt := StreamEvent{
+		Exchange:  evt.Exchange,
+		AssetType: evt.AssetType,
+	}
+
+	switch d := evt.Data.(type) {
+	case ticker.Price:
+		streamEvt.Event = streamEventTicker
+		streamEvt.Data = NewRESTv2Ticker(evt.Exchange, evt.AssetType, d)
+	case orderbook.Base:
+		streamEvt.Event = streamEventOrderbook
+		streamEvt.Data = NewRESTv2Orderbook(evt.Exchange, evt.AssetType, d)
+	default:
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	streamEvt.ID = s.nextID
+	s.nextID++
+
+	s.replay = append(s.replay, streamEvt)
+	if len(s.replay) > streamReplayBufferSize {
+		s.replay = s.replay[len(s.replay)-streamReplayBufferSize:]
+	}
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- streamEvt:
+		default:
+			log.Printf("Stream: disconnecting slow subscriber")
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe registers a new subscriber and returns the buffered events with
+// an ID greater than lastEventID. Event IDs restart at 1 with the process, so
+// a lastEventID which hasn't been issued yet is treated as a reset and the
+// whole buffer is replayed. The returned bool reports whether events after
+// lastEventID were lost
+func (s *StreamBroker) Subscribe(lastEventID uint64) (chan StreamEvent, []StreamEvent, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	ch := make(chan StreamEvent, streamSubscriberBuffer)
+	s.subscribers[ch] = true
+
+	if lastEventID == 0 {
+		return ch, nil, false
+	}
+
+	lost := lastEventID >= s.nextID
+	if lost {
+		lastEventID = 0
+	} else if len(s.replay) > 0 && s.replay[0].ID > lastEventID+1 {
+		lost = true
+	}
+
+	var missed []StreamEvent
+	for x := range s.replay {
+		if s.replay[x].ID > lastEventID {
+			missed = append(missed, s.replay[x])
+		}
+	}
+	return ch, missed, lost
+}
+
+// Unsubscribe removes a subscriber
+func (s *StreamBroker) Unsubscribe(ch chan StreamEvent) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.subscribers[ch]; ok {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+// streamFilter holds the stream request filters
+type streamFilter struct {
+	query  RESTv2Query
+	events map[string]bool
+}
+
+// matches returns whether or not the stream event matches the filter
+func (f *streamFilter) matches(evt StreamEvent) bool {
+	if !f.events[evt.Event] || !f.query.MatchesExchange(evt.Exchange) ||
+		!f.query.MatchesAssetType(evt.AssetType) {
+		return false
+	}
+
+	if f.query.Pair.FirstCurrency == "" {
+		return true
+	}
+
+	var p string
+	switch d := evt.Data.(type) {
+	case RESTv2Ticker:
+		p = d.Pair
+	case RESTv2Orderbook:
+		p = d.Pair
+	}
+	return streamPairReplacer.Replace(common.StringToUpper(p)) ==
+		normaliseTopicPair(f.query.Pair)
+}
+
+// parseStreamFilter parses the exchange, pair, assetType and events query
+// parameters
+func parseStreamFilter(r *http.Request) (streamFilter, error) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		return streamFilter{}, err
+	}
+
+	events := r.URL.Query().Get(streamQueryEvents)
+	if events == "" {
+		events = streamDefaultEventsFilter
+	}
+
+	filter := streamFilter{
+		query:  query,
+		events: make(map[string]bool),
+	}
+
+	for _, event := range common.SplitStrings(events, ",") {
+		event = common.StringToLower(event)
+		if event != streamEventTicker && event != streamEventOrderbook {
+			return filter, fmt.Errorf("unsupported event %s", event)
+		}
+		filter.events[event] = true
+	}
+	return filter, nil
+}
+
+// parseLastEventID returns the Last-Event-ID header or lastEventId query
+// parameter
+func parseLastEventID(r *http.Request) (uint64, error) {
+	lastEventID := r.Header.Get(streamHeaderLastEventID)
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get(streamQueryLastEventID)
+	}
+
+	if lastEventID == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(lastEventID, 10, 64)
+}
+
+// writeStreamEvent writes an event in the server-sent events format
+func writeStreamEvent(w http.ResponseWriter, evt StreamEvent) error {
+	data, err := common.JSONEncode(evt.Data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", evt.ID, evt.Event,
+		data)
+	return err
+}
+
+// writeStreamReset writes a reset event, which has no ID so the client keeps
+// its Last-Event-ID until it receives a replayed or live event
+func writeStreamReset(w http.ResponseWriter, reset StreamReset) error {
+	data, err := common.JSONEncode(reset)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", streamEventReset, data)
+	return err
+}
+
+// RESTStream streams ticker and orderbook updates as server-sent events,
+// replaying buffered events newer than the Last-Event-ID and sending
+// heartbeat comments to keep idle connections open. A reset event is sent
+// first if the replay can't cover every event after the Last-Event-ID
+func RESTStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		RESTv2ErrorJSONResponse(w, r, http.StatusInternalServerError,
+			fmt.Errorf("streaming unsupported"))
+		return
+	}
+
+	filter, err := parseStreamFilter(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	lastEventID, err := parseLastEventID(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest,
+			fmt.Errorf("invalid Last-Event-ID"))
+		return
+	}
+
+	StartStreamBroker()
+	events, missed, lost := streamBroker.Subscribe(lastEventID)
+	defer streamBroker.Unsubscribe(events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	_, err = fmt.Fprintf(w, "retry: %d\n\n", streamRetryInterval/time.Millisecond)
+	if err != nil {
+		return
+	}
+
+	if lost {
+		reset := StreamReset{LastEventID: lastEventID}
+		if len(missed) > 0 {
+			reset.OldestEventID = missed[0].ID
+		}
+
+		err = writeStreamReset(w, reset)
+		if err != nil {
+			return
+		}
+		lastEventID = 0
+	}
+
+	for x := range missed {
+		lastEventID = missed[x].ID
+		if !filter.matches(missed[x]) {
+			continue
+		}
+
+		err = writeStreamEvent(w, missed[x])
+		if err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	done := r.Context().Done()
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if evt.ID <= lastEventID || !filter.matches(evt) {
+				continue
+			}
+
+			err = writeStreamEvent(w, evt)
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
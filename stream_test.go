@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func testStreamTicker(exchangeName, base, quote string, last float64) WebsocketEvent {
+	return WebsocketEvent{
+		Exchange:  exchangeName,
+		AssetType: ticker.Spot,
+		Event:     FeedEventTicker,
+		Data: ticker.Price{
+			Pair: pair.NewCurrencyPair(base, quote),
+			Last: last,
+		},
+	}
+}
+
+func TestStreamBrokerReplay(t *testing.T) {
+	broker := NewStreamBroker()
+	for x := 0; x < streamReplayBufferSize+10; x++ {
+		broker.Publish(testStreamTicker("Bitfinex", "BTC", "USD", float64(x)))
+	}
+	broker.Publish(WebsocketEvent{Data: "unsupported"})
+
+	if len(broker.replay) != streamReplayBufferSize {
+		t.Errorf("Test failed. Replay buffer length %d expected %d",
+			len(broker.replay), streamReplayBufferSize)
+	}
+
+	ch, missed, lost := broker.Subscribe(uint64(streamReplayBufferSize + 5))
+	if len(missed) != 5 || missed[0].ID != uint64(streamReplayBufferSize+6) || lost {
+		t.Errorf("Test failed. Subscribe returned %d missed events", len(missed))
+	}
+
+	_, missed, lost = broker.Subscribe(0)
+	if len(missed) != 0 || lost {
+		t.Error("Test failed. Subscribe without a Last-Event-ID should not replay")
+	}
+
+	_, missed, lost = broker.Subscribe(5)
+	if len(missed) != streamReplayBufferSize || !lost {
+		t.Error("Test failed. Subscribe should report events older than the replay buffer as lost")
+	}
+
+	// Event IDs restart with the process, an ID which hasn't been issued yet
+	// is from a previous run
+	_, missed, lost = broker.Subscribe(uint64(streamReplayBufferSize * 2))
+	if len(missed) != streamReplayBufferSize || missed[0].ID != 11 || !lost {
+		t.Error("Test failed. Subscribe should treat an unissued Last-Event-ID as a reset")
+	}
+
+	broker.Publish(testStreamTicker("Bitfinex", "BTC", "USD", 1))
+	evt := <-ch
+	if evt.ID != uint64(streamReplayBufferSize+11) || evt.Event != streamEventTicker {
+		t.Errorf("Test failed. Subscriber received unexpected event %v", evt)
+	}
+
+	for x := 0; x < streamSubscriberBuffer+1; x++ {
+		broker.Publish(testStreamTicker("Bitfinex", "BTC", "USD", 1))
+	}
+
+	if _, ok := broker.subscribers[ch]; ok {
+		t.Error("Test failed. Slow subscriber should have been disconnected")
+	}
+	broker.Unsubscribe(ch)
+}
+
+func TestStreamFilter(t *testing.T) {
+	r := httptest.NewRequest("GET", "/stream?exchange=gdax&pair=BTCUSD&events=ticker", nil)
+	filter, err := parseStreamFilter(r)
+	if err != nil {
+		t.Fatalf("Test failed. parseStreamFilter: %s", err)
+	}
+
+	btc := pair.NewCurrencyPairDelimiter("BTC-USD", "-")
+	tests := []struct {
+		evt      StreamEvent
+		expected bool
+	}{
+		{StreamEvent{Event: streamEventTicker, Exchange: "GDAX", Data: RESTv2Ticker{Pair: btc.Pair().String()}}, true},
+		{StreamEvent{Event: streamEventTicker, Exchange: "Kraken", Data: RESTv2Ticker{Pair: "BTCUSD"}}, false},
+		{StreamEvent{Event: streamEventTicker, Exchange: "GDAX", Data: RESTv2Ticker{Pair: "LTC-USD"}}, false},
+		{StreamEvent{Event: streamEventOrderbook, Exchange: "GDAX", Data: RESTv2Orderbook{Pair: "BTC-USD"}}, false},
+	}
+
+	for x := range tests {
+		if filter.matches(tests[x].evt) != tests[x].expected {
+			t.Errorf("Test failed. Stream filter test %d returned %v", x,
+				!tests[x].expected)
+		}
+	}
+
+	r = httptest.NewRequest("GET", "/stream?events=trades", nil)
+	_, err = parseStreamFilter(r)
+	if err == nil {
+		t.Error("Test failed. parseStreamFilter should reject unsupported events")
+	}
+}
+
+func TestRESTStream(t *testing.T) {
+	StartStreamBroker()
+	streamBroker.Publish(testStreamTicker("Bitfinex", "BTC", "USD", 1000))
+	streamBroker.Publish(testStreamTicker("Bitfinex", "LTC", "USD", 100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRequest("GET", "/stream?pair=BTCUSD", nil).WithContext(ctx)
+	r.Header.Set(streamHeaderLastEventID, "1")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		RESTStream(w, r)
+		close(done)
+	}()
+
+	time.Sleep(time.Millisecond * 50)
+	streamBroker.Publish(WebsocketEvent{
+		Exchange:  "Bitfinex",
+		AssetType: orderbook.Spot,
+		Data:      orderbook.Base{Pair: pair.NewCurrencyPair("BTC", "USD")},
+	})
+	time.Sleep(time.Millisecond * 50)
+	cancel()
+	<-done
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Test failed. RESTStream returned %d %s", w.Code,
+			w.Header().Get("Content-Type"))
+	}
+
+	body := w.Body.String()
+	if strings.Contains(body, "LTC") || strings.Contains(body, "id: 1\n") {
+		t.Errorf("Test failed. RESTStream sent filtered events: %s", body)
+	}
+
+	if !strings.Contains(body, "event: orderbook") || strings.Contains(body, "event: reset") {
+		t.Errorf("Test failed. RESTStream missing live event: %s", body)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	r = httptest.NewRequest("GET", "/stream?pair=LTCUSD", nil).WithContext(ctx)
+	r.Header.Set(streamHeaderLastEventID, "18446744073709551615")
+	w = httptest.NewRecorder()
+	cancel()
+	RESTStream(w, r)
+
+	body = w.Body.String()
+	if !strings.Contains(body, "event: reset\ndata: {\"lastEventId\":18446744073709551615,\"oldestEventId\":") ||
+		!strings.Contains(body, "LTC") {
+		t.Errorf("Test failed. RESTStream should reset and replay after a restart: %s", body)
+	}
+}
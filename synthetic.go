@@ -0,0 +1,351 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	Bot "github.com/trustfeed/go-crypto-pricefeeder/bot"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for the synthetic pair pricing
+const (
+	syntheticMaxHops          = 4
+	syntheticSourceFX         = "FX"
+	syntheticSourceTranslated = "translation"
+	syntheticQueryUSDT        = "includeUSDT"
+
+	// syntheticValueCurrency is the currency ticker volumes are valued in, so
+	// the liquidity of tickers with different base currencies is comparable
+	syntheticValueCurrency = "USD"
+
+	// syntheticTranslationWeight is the cost of treating translated
+	// currencies such as XBT and BTC as the same currency, which is cheaper
+	// than any market hop
+	syntheticTranslationWeight = 0.1
+)
+
+var (
+	errSyntheticInvalidPair = errors.New("synthetic pair currencies must differ")
+	errSyntheticNoPath      = errors.New("no conversion path found for currency pair")
+)
+
+// SyntheticHop is a conversion of one currency to another along a synthetic
+// price path. Source is the exchange of the ticker used, FX for an exchange
+// rate or translation for equivalent currencies. Inverted is set when the
+// ticker or FX rate was converted against its quote direction
+type SyntheticHop struct {
+	From        string    `json:"from"`
+	To          string    `json:"to"`
+	Source      string    `json:"source"`
+	Pair        string    `json:"pair,omitempty"`
+	Rate        float64   `json:"rate"`
+	Inverted    bool      `json:"inverted"`
+	Volume      float64   `json:"volume,omitempty"`
+	LastUpdated time.Time `json:"lastUpdated,omitempty"`
+}
+
+// SyntheticPrice is the price of a currency pair computed along a conversion
+// path. LastUpdated is the time of the oldest ticker or FX rate used
+type SyntheticPrice struct {
+	Pair        string         `json:"pair"`
+	Price       float64        `json:"price"`
+	Path        []SyntheticHop `json:"path"`
+	LastUpdated time.Time      `json:"lastUpdated"`
+}
+
+// syntheticEdge is a directed conversion between two currencies, weight
+// favours fresh conversions with fewer hops. Ticker edges are also weighted
+// by their liquidity when the path is searched
+type syntheticEdge struct {
+	hop    SyntheticHop
+	weight float64
+	ticker bool
+}
+
+// PriceGraph is a graph of currencies connected by the tickers, FX rates and
+// currency translations which convert between them
+type PriceGraph struct {
+	edges map[string][]syntheticEdge
+}
+
+// NewPriceGraph returns an empty price graph
+func NewPriceGraph() *PriceGraph {
+	return &PriceGraph{edges: make(map[string][]syntheticEdge)}
+}
+
+// addEdge adds a conversion in both directions
+func (g *PriceGraph) addEdge(hop SyntheticHop, weight float64, isTicker bool) {
+	g.edges[hop.From] = append(g.edges[hop.From], syntheticEdge{hop: hop, weight: weight, ticker: isTicker})
+
+	inverse := hop
+	inverse.From, inverse.To = hop.To, hop.From
+	inverse.Rate = 1 / hop.Rate
+	inverse.Inverted = !hop.Inverted
+	g.edges[inverse.From] = append(g.edges[inverse.From], syntheticEdge{hop: inverse, weight: weight, ticker: isTicker})
+}
+
+// AddTicker adds the last price of an exchange ticker, ignoring tickers
+// older than maxAge. Fresher tickers with more volume, valued in the
+// syntheticValueCurrency, are preferred
+func (g *PriceGraph) AddTicker(exchangeName string, p pair.CurrencyPair, price ticker.Price, now time.Time, maxAge time.Duration) {
+	age := now.Sub(price.LastUpdated)
+	if price.Last <= 0 || age > maxAge {
+		return
+	}
+
+	g.addEdge(SyntheticHop{
+		From:        p.FirstCurrency.Upper().String(),
+		To:          p.SecondCurrency.Upper().String(),
+		Source:      exchangeName,
+		Pair:        p.Pair().String(),
+		Rate:        price.Last,
+		Volume:      price.Volume,
+		LastUpdated: price.LastUpdated,
+	}, 1+ageWeight(age, maxAge), true)
+}
+
+// AddFXRates adds the FX rates, rates older than staleAfter are kept at the
+// highest age weight so fresher tickers are preferred
+func (g *PriceGraph) AddFXRates(status currency.FXStatus, now time.Time, staleAfter time.Duration) {
+	for key, rate := range status.Rates {
+		if len(key) != 6 || rate <= 0 || key[:3] == key[3:] {
+			continue
+		}
+
+		updated := status.Updated[key]
+		g.addEdge(SyntheticHop{
+			From:        key[:3],
+			To:          key[3:],
+			Source:      syntheticSourceFX,
+			Pair:        key,
+			Rate:        rate,
+			LastUpdated: updated,
+		}, 1+ageWeight(now.Sub(updated), staleAfter), false)
+	}
+}
+
+// AddTranslations connects the currencies of the tickers and FX rates in the
+// graph to their translations, such as XBT and BTC. USD and USDT are only
+// treated as the same currency if incUSDT is set
+func (g *PriceGraph) AddTranslations(incUSDT bool) {
+	var pairs []pair.CurrencyPair
+	for _, edges := range g.edges {
+		for x := range edges {
+			if !edges[x].hop.Inverted {
+				pairs = append(pairs, pair.NewCurrencyPair(edges[x].hop.From, edges[x].hop.To))
+			}
+		}
+	}
+
+	added := make(map[string]bool)
+	translate := func(from, to pair.CurrencyItem) {
+		if from == to || added[from.String()+to.String()] {
+			return
+		}
+		added[from.String()+to.String()] = true
+		added[to.String()+from.String()] = true
+
+		g.addEdge(SyntheticHop{
+			From:   from.String(),
+			To:     to.String(),
+			Source: syntheticSourceTranslated,
+			Rate:   1,
+		}, syntheticTranslationWeight, false)
+	}
+
+	for x := range pairs {
+		translated := GetTranslatedPairs(pairs[x], incUSDT)
+		for y := range translated {
+			translate(pairs[x].FirstCurrency, translated[y].FirstCurrency)
+			translate(pairs[x].SecondCurrency, translated[y].SecondCurrency)
+		}
+	}
+}
+
+// currencyValues returns the value of each currency reachable from the
+// value currency, converting along the fewest hops
+func (g *PriceGraph) currencyValues(valueCurrency string) map[string]float64 {
+	values := map[string]float64{valueCurrency: 1}
+	queue := []string{valueCurrency}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, e := range g.edges[node] {
+			if _, ok := values[e.hop.To]; ok {
+				continue
+			}
+			values[e.hop.To] = values[node] / e.hop.Rate
+			queue = append(queue, e.hop.To)
+		}
+	}
+	return values
+}
+
+// edgeWeight returns the weight of an edge, ticker edges with more volume
+// valued in the value currency weigh less. Tickers whose base currency can't
+// be valued are treated as having no volume
+func edgeWeight(e syntheticEdge, values map[string]float64) float64 {
+	if !e.ticker {
+		return e.weight
+	}
+
+	base := e.hop.From
+	if e.hop.Inverted {
+		base = e.hop.To
+	}
+	return e.weight + 1/(1+math.Log10(1+e.hop.Volume*values[base]))
+}
+
+// Price returns the price of from in to along the lowest weight path of at
+// most maxHops conversions
+func (g *PriceGraph) Price(from, to string, maxHops int) (SyntheticPrice, error) {
+	from, to = common.StringToUpper(from), common.StringToUpper(to)
+	if from == to {
+		return SyntheticPrice{}, errSyntheticInvalidPair
+	}
+
+	type step struct {
+		cost float64
+		path []SyntheticHop
+	}
+
+	values := g.currencyValues(syntheticValueCurrency)
+	layer := map[string]step{from: {}}
+	var best *step
+	for hops := 0; hops < maxHops && len(layer) > 0; hops++ {
+		next := make(map[string]step)
+		for node, s := range layer {
+			for _, e := range g.edges[node] {
+				if e.hop.To == from {
+					continue
+				}
+
+				cost := s.cost + edgeWeight(e, values)
+				if existing, ok := next[e.hop.To]; ok && existing.cost <= cost {
+					continue
+				}
+
+				path := make([]SyntheticHop, len(s.path), len(s.path)+1)
+				copy(path, s.path)
+				next[e.hop.To] = step{cost: cost, path: append(path, e.hop)}
+			}
+		}
+
+		if s, ok := next[to]; ok && (best == nil || s.cost < best.cost) {
+			found := s
+			best = &found
+		}
+		delete(next, to)
+		layer = next
+	}
+
+	if best == nil {
+		return SyntheticPrice{}, errSyntheticNoPath
+	}
+
+	result := SyntheticPrice{
+		Pair:  pair.NewCurrencyPair(from, to).Pair().String(),
+		Price: 1,
+		Path:  best.path,
+	}
+	for _, hop := range best.path {
+		result.Price *= hop.Rate
+		if hop.Source == syntheticSourceTranslated {
+			continue
+		}
+		if result.LastUpdated.IsZero() || hop.LastUpdated.Before(result.LastUpdated) {
+			result.LastUpdated = hop.LastUpdated
+		}
+	}
+	return result, nil
+}
+
+// ageWeight returns the age as a fraction of maxAge, capped at 1
+func ageWeight(age, maxAge time.Duration) float64 {
+	if maxAge <= 0 || age >= maxAge {
+		return 1
+	}
+	if age < 0 {
+		return 0
+	}
+	return float64(age) / float64(maxAge)
+}
+
+// BuildPriceGraph returns a price graph of the spot tickers of the enabled
+// exchanges, or of a specific exchange if exchangeName is set, along with
+// the FX rates
+func BuildPriceGraph(bot Bot.Bot, exchangeName string, incUSDT bool, now time.Time) *PriceGraph {
+	g := NewPriceGraph()
+	maxAge := bot.Config.Webserver.AdapterTickerMaxAge
+
+	for x := range bot.Exchanges {
+		if bot.Exchanges[x] == nil || !bot.Exchanges[x].IsEnabled() {
+			continue
+		}
+
+		name := bot.Exchanges[x].GetName()
+		if exchangeName != "" && common.StringToLower(name) != common.StringToLower(exchangeName) {
+			continue
+		}
+
+		enabledCurrencies := bot.Exchanges[x].GetEnabledCurrencies()
+		for y := range enabledCurrencies {
+			price, err := ticker.GetTicker(name, enabledCurrencies[y], ticker.Spot)
+			if err != nil {
+				continue
+			}
+			g.AddTicker(name, enabledCurrencies[y], price, now, maxAge)
+		}
+	}
+
+	g.AddFXRates(currency.GetFXStatus(bot.Config.Currency.FXStaleAfter, now), now,
+		bot.Config.Currency.FXStaleAfter)
+	g.AddTranslations(incUSDT)
+	return g
+}
+
+// RESTv2GetSyntheticPrice returns the price of a currency pair computed
+// along the best conversion path across the tickers and FX rates
+func RESTv2GetSyntheticPrice(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	p, err := parseRESTv2Pair(mux.Vars(r)["currency"])
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var incUSDT bool
+	if value := r.URL.Query().Get(syntheticQueryUSDT); value != "" {
+		incUSDT, err = strconv.ParseBool(value)
+		if err != nil {
+			RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest,
+				fmt.Errorf("invalid %s value %s", syntheticQueryUSDT, value))
+			return
+		}
+	}
+
+	price, err := GetSyntheticPrice(bot, p, query.Exchange, incUSDT)
+	if err != nil {
+		status := http.StatusNotFound
+		if err == errSyntheticInvalidPair {
+			status = http.StatusBadRequest
+		}
+		RESTv2ErrorJSONResponse(w, r, status, err)
+		return
+	}
+	restV2Object(w, r, query, price)
+}
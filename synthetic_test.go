@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func newTestPriceGraph(now time.Time, incUSDT bool) *PriceGraph {
+	g := NewPriceGraph()
+	g.AddTicker("Binance", pair.NewCurrencyPair("ETH", "BTC"),
+		ticker.Price{Last: 0.05, Volume: 1000, LastUpdated: now}, now, time.Minute)
+	g.AddTicker("Binance", pair.NewCurrencyPair("BTC", "USDT"),
+		ticker.Price{Last: 10000, Volume: 500, LastUpdated: now.Add(-time.Second * 10)}, now, time.Minute)
+	g.AddTicker("Kraken", pair.NewCurrencyPair("XBT", "EUR"),
+		ticker.Price{Last: 8000, Volume: 0.0001, LastUpdated: now.Add(-time.Second * 50)}, now, time.Minute)
+	g.AddTicker("Bitstamp", pair.NewCurrencyPair("ETH", "EUR"),
+		ticker.Price{Last: 300, Volume: 5000, LastUpdated: now.Add(-time.Minute * 2)}, now, time.Minute)
+	g.AddFXRates(currency.FXStatus{
+		Rates:   map[string]float64{"USDEUR": 0.85},
+		Updated: map[string]time.Time{"USDEUR": now},
+	}, now, time.Hour)
+	g.AddTranslations(incUSDT)
+	return g
+}
+
+func TestPriceGraph(t *testing.T) {
+	now := time.Now()
+
+	price, err := newTestPriceGraph(now, true).Price("eth", "eur", syntheticMaxHops)
+	if err != nil {
+		t.Fatal("Test failed. PriceGraph.Price error", err)
+	}
+
+	if math.Abs(price.Price-425) > 1e-9 || len(price.Path) != 4 || price.Path[1].Pair != "BTCUSDT" ||
+		price.Path[2].Source != syntheticSourceTranslated || price.Path[3].Source != syntheticSourceFX ||
+		!price.LastUpdated.Equal(now.Add(-time.Second*10)) {
+		t.Errorf("Test failed. PriceGraph.Price unexpected liquid path %+v", price)
+	}
+
+	price, err = newTestPriceGraph(now, false).Price("EUR", "ETH", syntheticMaxHops)
+	if err != nil || math.Abs(price.Price-1/400.0) > 1e-12 || len(price.Path) != 3 ||
+		price.Path[0].Source != "Kraken" || !price.Path[0].Inverted {
+		t.Errorf("Test failed. PriceGraph.Price unexpected path without USDT %+v %v", price, err)
+	}
+
+	if _, err = newTestPriceGraph(now, true).Price("ETH", "EUR", 1); err != errSyntheticNoPath {
+		t.Error("Test failed. PriceGraph.Price used a stale ticker or exceeded the hops", err)
+	}
+
+	if _, err = newTestPriceGraph(now, true).Price("LTC", "EUR", syntheticMaxHops); err != errSyntheticNoPath {
+		t.Error("Test failed. PriceGraph.Price expected errSyntheticNoPath got", err)
+	}
+
+	if _, err = newTestPriceGraph(now, true).Price("ETH", "eth", syntheticMaxHops); err != errSyntheticInvalidPair {
+		t.Error("Test failed. PriceGraph.Price expected errSyntheticInvalidPair got", err)
+	}
+}
+
+func TestPriceGraphVolumeValue(t *testing.T) {
+	now := time.Now()
+	g := NewPriceGraph()
+	g.AddTicker("Bitfinex", pair.NewCurrencyPair("BTC", "USD"),
+		ticker.Price{Last: 10000, Volume: 100, LastUpdated: now}, now, time.Minute)
+	// 1 BTC of volume is worth more than 10000 DOGE at 0.0000011 BTC
+	g.AddTicker("Binance", pair.NewCurrencyPair("BTC", "DOGE"),
+		ticker.Price{Last: 1000000, Volume: 1, LastUpdated: now}, now, time.Minute)
+	g.AddTicker("Bittrex", pair.NewCurrencyPair("DOGE", "BTC"),
+		ticker.Price{Last: 0.0000011, Volume: 10000, LastUpdated: now}, now, time.Minute)
+	g.AddTranslations(false)
+
+	price, err := g.Price("BTC", "DOGE", syntheticMaxHops)
+	if err != nil || price.Price != 1000000 || len(price.Path) != 1 || price.Path[0].Source != "Binance" {
+		t.Errorf("Test failed. PriceGraph.Price did not value the ticker volumes %+v %v", price, err)
+	}
+}
+
+func TestRESTv2GetSyntheticPrice(t *testing.T) {
+	backupConfig, backupExchanges := bot.Config, bot.Exchanges
+	defer func() { bot.Config, bot.Exchanges = backupConfig, backupExchanges }()
+
+	bot.Config = &config.Config{}
+	bot.Config.Currency.FXStaleAfter = time.Hour
+	bot.Exchanges = nil
+	currency.SetExchangeRates(map[string]float64{"USDEUR": 0.8}, time.Now())
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v2/synthetic/{currency}", RESTv2GetSyntheticPrice)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/synthetic/EUR-USD", nil))
+
+	var price SyntheticPrice
+	err := json.NewDecoder(w.Body).Decode(&price)
+	if err != nil || price.Pair != "EURUSD" || price.Price != 1.25 || len(price.Path) != 1 {
+		t.Errorf("Test failed. RESTv2GetSyntheticPrice unexpected result %+v %v", price, err)
+	}
+
+	for path, expected := range map[string]int{
+		"/v2/synthetic/EUR-EUR":                  http.StatusBadRequest,
+		"/v2/synthetic/EUR-USD?includeUSDT=mayb": http.StatusBadRequest,
+		"/v2/synthetic/LTC-USD":                  http.StatusNotFound,
+	} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if w.Code != expected {
+			t.Errorf("Test failed. RESTv2GetSyntheticPrice %s expected %d got %d", path, expected, w.Code)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/telegram"
+)
+
+var (
+	errTelegramDisabled    = errors.New("telegram communications are not enabled")
+	errTelegramInvalidChat = errors.New("invalid telegram chat ID")
+	errTelegramChatUnknown = errors.New("telegram chat not authorised")
+)
+
+// RESTv2TelegramPairingCode holds a one-time code which authorises the
+// telegram chat it is sent from
+type RESTv2TelegramPairingCode struct {
+	Code    string    `json:"code"`
+	Expires time.Time `json:"expires"`
+}
+
+// RESTv2TelegramClient holds an authorised telegram chat
+type RESTv2TelegramClient struct {
+	ChatID int64 `json:"chatId"`
+}
+
+// getTelegram returns the telegram communication medium if it is enabled
+func getTelegram() (*telegram.Telegram, bool) {
+	if bot.Comms == nil {
+		return nil, false
+	}
+
+	for i := range bot.Comms.IComm {
+		if t, ok := bot.Comms.IComm[i].(*telegram.Telegram); ok && t.IsEnabled() {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// StartTelegram persists the telegram chats authorised through pairing
+func StartTelegram() {
+	t, ok := getTelegram()
+	if !ok {
+		return
+	}
+	t.SetPersistHandler(persistTelegramClients)
+}
+
+// RESTv2CreateTelegramPairingCode creates a one-time telegram pairing code
+func RESTv2CreateTelegramPairingCode(w http.ResponseWriter, r *http.Request) {
+	t, ok := getTelegram()
+	if !ok {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errTelegramDisabled)
+		return
+	}
+
+	code, expires, err := t.NewPairingCode()
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	RESTv2JSONResponse(w, r, http.StatusCreated, RESTv2TelegramPairingCode{
+		Code:    code,
+		Expires: expires,
+	})
+}
+
+// RESTv2GetTelegramClients returns the authorised telegram chats
+func RESTv2GetTelegramClients(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	t, ok := getTelegram()
+	if !ok {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errTelegramDisabled)
+		return
+	}
+
+	clients := t.GetAuthorisedClients()
+	result := make([]RESTv2TelegramClient, len(clients))
+	for i := range clients {
+		result[i].ChatID = clients[i]
+	}
+
+	restV2List(w, r, query, len(result), func(start, end int) interface{} {
+		return result[start:end]
+	})
+}
+
+// RESTv2DeleteTelegramClient revokes the authorisation of a telegram chat
+func RESTv2DeleteTelegramClient(w http.ResponseWriter, r *http.Request) {
+	t, ok := getTelegram()
+	if !ok {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errTelegramDisabled)
+		return
+	}
+
+	chatID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, errTelegramInvalidChat)
+		return
+	}
+
+	if !t.RemoveAuthorisedClient(chatID) {
+		RESTv2ErrorJSONResponse(w, r, http.StatusNotFound, errTelegramChatUnknown)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/base"
+	"github.com/trustfeed/go-crypto-pricefeeder/communications/telegram"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+func TestRESTv2Telegram(t *testing.T) {
+	backupComms, backupConfig, backupDryRun := bot.Comms, bot.Config, bot.DryRun
+	defer func() {
+		bot.Comms, bot.Config, bot.DryRun = backupComms, backupConfig, backupDryRun
+	}()
+	bot.Comms = &communications.Communications{}
+	bot.Config = &config.Config{}
+	bot.DryRun = true
+
+	w := httptest.NewRecorder()
+	RESTv2CreateTelegramPairingCode(w, httptest.NewRequest("POST", "/v2/telegram/pairing", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Test failed. RESTv2CreateTelegramPairingCode without telegram returned %d", w.Code)
+	}
+
+	tg := new(telegram.Telegram)
+	tg.Setup(config.CommunicationsConfig{TelegramConfig: config.TelegramConfig{
+		Name:    "Telegram",
+		Enabled: true,
+	}})
+	bot.Comms.IComm = base.IComm{tg}
+	StartTelegram()
+
+	w = httptest.NewRecorder()
+	RESTv2CreateTelegramPairingCode(w, httptest.NewRequest("POST", "/v2/telegram/pairing", nil))
+	var code RESTv2TelegramPairingCode
+	err := json.NewDecoder(w.Body).Decode(&code)
+	if w.Code != http.StatusCreated || err != nil || code.Code == "" {
+		t.Fatalf("Test failed. RESTv2CreateTelegramPairingCode returned %d %v", w.Code, err)
+	}
+
+	err = tg.Pair(1337, code.Code)
+	if err != nil {
+		t.Fatal("Test failed. Pair error", err)
+	}
+
+	if clients := bot.Config.Communications.TelegramConfig.AuthorisedClients; len(clients) != 1 || clients[0] != 1337 {
+		t.Errorf("Test failed. Paired chat not persisted in the config %v", clients)
+	}
+
+	w = httptest.NewRecorder()
+	RESTv2GetTelegramClients(w, httptest.NewRequest("GET", "/v2/telegram/clients", nil))
+	var list struct {
+		Data       []RESTv2TelegramClient
+		Pagination RESTv2Pagination
+	}
+	err = json.NewDecoder(w.Body).Decode(&list)
+	if err != nil || list.Pagination.Total != 1 || list.Data[0].ChatID != 1337 {
+		t.Errorf("Test failed. RESTv2GetTelegramClients unexpected result %v %v", list, err)
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v2/telegram/clients/{id}", RESTv2DeleteTelegramClient).Methods("DELETE")
+	for _, expected := range []int{http.StatusNoContent, http.StatusNotFound} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", "/v2/telegram/clients/1337", nil))
+		if w.Code != expected {
+			t.Errorf("Test failed. RESTv2DeleteTelegramClient expected %d got %d", expected, w.Code)
+		}
+	}
+
+	if len(bot.Config.Communications.TelegramConfig.AuthorisedClients) != 0 {
+		t.Error("Test failed. Removed chat not persisted in the config")
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/events"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/webhooks"
+)
+
+// Const vars for the webhook integration
+const (
+	webhookDefaultQueueFile   = "webhooks.json"
+	webhookFeedBufferSize     = 4096
+	webhookComponentTicker    = "ticker"
+	webhookComponentOrderbook = "orderbook"
+	webhookHealthOK           = "ok"
+	webhookHealthDegraded     = "degraded"
+	webhookQueryName          = "webhook"
+	webhookQueryStatus        = "status"
+)
+
+var (
+	webhookHealth    = make(map[string]bool)
+	webhookHealthMtx sync.Mutex
+)
+
+// WebhookHealthAlert is the webhook payload sent when an exchange stops or
+// resumes returning data
+type WebhookHealthAlert struct {
+	Exchange  string `json:"exchange"`
+	Component string `json:"component"`
+	Pair      string `json:"pair"`
+	AssetType string `json:"assetType"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+}
+
+// StartWebhooks restores the webhook queue, starts the delivery worker and
+// forwards ticker changes, event triggers and health alerts to the webhooks
+func StartWebhooks() {
+	if !bot.Config.Webhooks.Enabled {
+		return
+	}
+
+	queuePath := bot.Config.Webhooks.QueuePath
+	if queuePath == "" {
+		queuePath = filepath.Join(filepath.Dir(bot.ConfigFile), webhookDefaultQueueFile)
+	}
+
+	w, err := webhooks.New(bot.Config.Webhooks, queuePath)
+	if err != nil {
+		log.Printf("Webhooks: failed to load queue %s. Err: %s", queuePath, err)
+		return
+	}
+
+	bot.Webhooks = w
+	bot.Webhooks.Start()
+	events.SetWebhooks(bot.Webhooks)
+	go webhookTickerDispatcher(SubscribeFeed(webhookFeedBufferSize))
+	log.Printf("Webhooks: delivery started, queue %s.", queuePath)
+}
+
+// publishWebhook queues a webhook event if webhooks are enabled
+func publishWebhook(event string, data interface{}) {
+	if bot.Webhooks == nil {
+		return
+	}
+
+	err := bot.Webhooks.Publish(event, data)
+	if err != nil {
+		log.Printf("Webhooks: failed to queue %s event. Err: %s", event, err)
+	}
+}
+
+// webhookTickerDispatcher publishes the ticker updates whose last price has
+// changed since the previous update
+func webhookTickerDispatcher(feed chan WebsocketEvent) {
+	lastPrices := make(map[string]float64)
+	for evt := range feed {
+		t, ok := evt.Data.(ticker.Price)
+		if !ok {
+			continue
+		}
+
+		key := evt.Exchange + evt.AssetType + t.Pair.Pair().String()
+		last, ok := lastPrices[key]
+		lastPrices[key] = t.Last
+		if !ok || last == t.Last {
+			continue
+		}
+		publishWebhook(config.WebhookEventTicker,
+			NewRESTv2Ticker(evt.Exchange, evt.AssetType, t))
+	}
+}
+
+// ReportExchangeHealth records the result of an exchange update for the
+// digest reports and sends a health alert when an exchange currency pair
+// fails or recovers
+func ReportExchangeHealth(exchangeName, component string, p pair.CurrencyPair, assetType string, err error) {
+	if marketHistory != nil {
+		marketHistory.RecordHealth(exchangeName, component, assetType, p.Pair().String(), err, time.Now())
+	}
+
+	if bot.Webhooks == nil {
+		return
+	}
+
+	key := exchangeName + component + assetType + p.Pair().String()
+	healthy := err == nil
+
+	webhookHealthMtx.Lock()
+	previous, ok := webhookHealth[key]
+	webhookHealth[key] = healthy
+	webhookHealthMtx.Unlock()
+
+	if (!ok && healthy) || (ok && previous == healthy) {
+		return
+	}
+
+	alert := WebhookHealthAlert{
+		Exchange:  exchangeName,
+		Component: component,
+		Pair:      p.Pair().String(),
+		AssetType: assetType,
+		Status:    webhookHealthOK,
+	}
+
+	if !healthy {
+		alert.Status = webhookHealthDegraded
+		alert.Message = err.Error()
+	}
+	publishWebhook(config.WebhookEventHealth, alert)
+}
+
+// RESTv2GetWebhookDeliveries returns the pending and completed webhook
+// deliveries filtered by webhook name and status
+func RESTv2GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseRESTv2Query(r)
+	if err != nil {
+		RESTv2ErrorJSONResponse(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	name := r.URL.Query().Get(webhookQueryName)
+	status := common.StringToLower(r.URL.Query().Get(webhookQueryStatus))
+
+	deliveries := []webhooks.Delivery{}
+	if bot.Webhooks != nil {
+		for _, d := range bot.Webhooks.Deliveries() {
+			if (name != "" && common.StringToUpper(d.Webhook) != common.StringToUpper(name)) ||
+				(status != "" && d.Status != status) {
+				continue
+			}
+			deliveries = append(deliveries, d)
+		}
+	}
+
+	restV2List(w, r, query, len(deliveries), func(start, end int) interface{} {
+		return deliveries[start:end]
+	})
+}
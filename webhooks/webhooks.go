@@ -0,0 +1,437 @@
+// Package webhooks delivers signed JSON payloads to the configured webhook
+// URLs. Deliveries are queued on disk and retried with an exponential backoff
+// until they succeed or run out of attempts
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+// Const vars for the webhook deliveries
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+
+	HeaderSignature = "X-Pricefeeder-Signature"
+	HeaderTimestamp = "X-Pricefeeder-Timestamp"
+	HeaderEvent     = "X-Pricefeeder-Event"
+	HeaderDelivery  = "X-Pricefeeder-Delivery"
+
+	signaturePrefix   = "sha256="
+	maxResponseLength = 512
+	pollInterval      = time.Second
+)
+
+var errQueueFull = errors.New("webhook queue full, dropping oldest delivery")
+
+// Payload is the JSON body posted to a webhook
+type Payload struct {
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Delivery is a queued or completed webhook delivery
+type Delivery struct {
+	ID          uint64          `json:"id"`
+	Webhook     string          `json:"webhook"`
+	URL         string          `json:"url"`
+	Event       string          `json:"event"`
+	Body        json.RawMessage `json:"body"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	StatusCode  int             `json:"statusCode,omitempty"`
+	LastError   string          `json:"lastError,omitempty"`
+	Created     time.Time       `json:"created"`
+	NextAttempt time.Time       `json:"nextAttempt"`
+	Completed   time.Time       `json:"completed"`
+}
+
+// queueState is the on-disk representation of the queue and history
+type queueState struct {
+	NextID  uint64
+	Pending []*Delivery
+	History []Delivery
+}
+
+// Webhooks queues and delivers webhook payloads
+type Webhooks struct {
+	cfg      config.WebhooksConfig
+	path     string
+	client   *http.Client
+	pending  []*Delivery
+	history  []Delivery
+	nextID   uint64
+	inFlight map[uint64]bool
+	dirty    bool
+	notify   chan struct{}
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	mtx      sync.Mutex
+}
+
+// New returns a webhook dispatcher, restoring the queue and delivery history
+// persisted at the supplied path
+func New(cfg config.WebhooksConfig, path string) (*Webhooks, error) {
+	w := &Webhooks{
+		cfg:      cfg,
+		path:     path,
+		client:   &http.Client{},
+		nextID:   1,
+		inFlight: make(map[uint64]bool),
+		notify:   make(chan struct{}, 1),
+		shutdown: make(chan struct{}),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return w, nil
+		}
+		return nil, err
+	}
+
+	var state queueState
+	err = common.JSONDecode(data, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	w.pending = state.Pending
+	w.history = state.History
+	if state.NextID > w.nextID {
+		w.nextID = state.NextID
+	}
+
+	if len(w.pending) > 0 {
+		log.Printf("Webhooks: restored %d pending deliveries.", len(w.pending))
+	}
+	return w, nil
+}
+
+// Sign returns the signature header value of a payload, a hex encoded HMAC
+// SHA256 of the timestamp and body joined by a dot
+func Sign(secret string, timestamp int64, body []byte) string {
+	message := append([]byte(strconv.FormatInt(timestamp, 10)+"."), body...)
+	return signaturePrefix + common.HexEncodeToString(
+		common.GetHMAC(common.HashSHA256, message, []byte(secret)))
+}
+
+// Backoff returns the delay before the next attempt, doubling the initial
+// delay after each failed attempt up to the maximum
+func Backoff(attempts int, initial, max time.Duration) time.Duration {
+	delay := initial
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
+
+// subscribed returns whether or not the webhook accepts the event
+func subscribed(webhook config.WebhookConfig, event string) bool {
+	if webhook.Events == "" {
+		return true
+	}
+
+	for _, e := range common.SplitStrings(webhook.Events, ",") {
+		if common.StringToLower(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// endpoint returns the enabled webhook config by name
+func (w *Webhooks) endpoint(name string) (config.WebhookConfig, bool) {
+	for x := range w.cfg.Endpoints {
+		if w.cfg.Endpoints[x].Enabled && w.cfg.Endpoints[x].Name == name {
+			return w.cfg.Endpoints[x], true
+		}
+	}
+	return config.WebhookConfig{}, false
+}
+
+// Publish queues the event for every enabled webhook subscribed to it
+func (w *Webhooks) Publish(event string, data interface{}) error {
+	body, err := common.JSONEncode(Payload{
+		Event:     event,
+		Timestamp: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	var queued bool
+	for x := range w.cfg.Endpoints {
+		if !w.cfg.Endpoints[x].Enabled || !subscribed(w.cfg.Endpoints[x], event) {
+			continue
+		}
+
+		if len(w.pending) >= w.cfg.MaxQueueSize {
+			log.Printf("Webhooks: %s", errQueueFull)
+			w.complete(w.pending[0], StatusFailed)
+		}
+
+		w.pending = append(w.pending, &Delivery{
+			ID:          w.nextID,
+			Webhook:     w.cfg.Endpoints[x].Name,
+			URL:         w.cfg.Endpoints[x].URL,
+			Event:       event,
+			Body:        body,
+			Status:      StatusPending,
+			Created:     time.Now(),
+			NextAttempt: time.Now(),
+		})
+		w.nextID++
+		queued = true
+	}
+
+	if !queued {
+		return nil
+	}
+
+	w.dirty = true
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// isPending returns whether or not the delivery is still queued, it may have
+// been dropped from a full queue while in flight. The caller must hold the lock
+func (w *Webhooks) isPending(d *Delivery) bool {
+	for x := range w.pending {
+		if w.pending[x] == d {
+			return true
+		}
+	}
+	return false
+}
+
+// complete removes a delivery from the queue and records it in the history.
+// The caller must hold the lock
+func (w *Webhooks) complete(d *Delivery, status string) {
+	for x := range w.pending {
+		if w.pending[x] == d {
+			w.pending = append(w.pending[:x], w.pending[x+1:]...)
+			break
+		}
+	}
+
+	d.Status = status
+	d.Completed = time.Now()
+	d.NextAttempt = time.Time{}
+	w.history = append(w.history, *d)
+	if len(w.history) > w.cfg.HistorySize {
+		w.history = w.history[len(w.history)-w.cfg.HistorySize:]
+	}
+}
+
+// save writes the queue and history to disk if they changed since the last
+// save. Changes are saved in batches by the worker every pollInterval rather
+// than on every event, it must not be called concurrently
+func (w *Webhooks) save() error {
+	if w.path == "" {
+		return nil
+	}
+
+	w.mtx.Lock()
+	if !w.dirty {
+		w.mtx.Unlock()
+		return nil
+	}
+
+	data, err := common.JSONEncode(queueState{
+		NextID:  w.nextID,
+		Pending: w.pending,
+		History: w.history,
+	})
+	w.dirty = err != nil
+	w.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := w.path + ".tmp"
+	err = common.WriteFile(tmp, data)
+	if err == nil {
+		err = os.Rename(tmp, w.path)
+	}
+
+	if err != nil {
+		w.mtx.Lock()
+		w.dirty = true
+		w.mtx.Unlock()
+	}
+	return err
+}
+
+// Start starts the delivery worker
+func (w *Webhooks) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		tick := time.NewTicker(pollInterval)
+		defer tick.Stop()
+
+		for {
+			w.deliverDue()
+			select {
+			case <-w.shutdown:
+				return
+			case <-tick.C:
+				err := w.save()
+				if err != nil {
+					log.Printf("Webhooks: failed to save queue. Err: %s", err)
+				}
+			case <-w.notify:
+			}
+		}
+	}()
+}
+
+// Stop stops the delivery worker and saves the queue
+func (w *Webhooks) Stop() {
+	close(w.shutdown)
+	w.wg.Wait()
+
+	err := w.save()
+	if err != nil {
+		log.Printf("Webhooks: failed to save queue. Err: %s", err)
+	}
+}
+
+// deliverDue attempts every delivery whose next attempt time has passed
+func (w *Webhooks) deliverDue() {
+	w.mtx.Lock()
+	var due []*Delivery
+	for x := range w.pending {
+		if !w.inFlight[w.pending[x].ID] && !time.Now().Before(w.pending[x].NextAttempt) {
+			due = append(due, w.pending[x])
+			w.inFlight[w.pending[x].ID] = true
+		}
+	}
+	w.mtx.Unlock()
+
+	for x := range due {
+		select {
+		case <-w.shutdown:
+			w.mtx.Lock()
+			for y := x; y < len(due); y++ {
+				delete(w.inFlight, due[y].ID)
+			}
+			w.mtx.Unlock()
+			return
+		default:
+		}
+		w.attempt(due[x])
+	}
+}
+
+// attempt posts a delivery and updates its state with the result
+func (w *Webhooks) attempt(d *Delivery) {
+	webhook, ok := w.endpoint(d.Webhook)
+	var statusCode int
+	var err error
+	if ok {
+		statusCode, err = w.post(webhook, d)
+	} else {
+		err = fmt.Errorf("webhook %s no longer enabled", d.Webhook)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	delete(w.inFlight, d.ID)
+
+	if !w.isPending(d) {
+		return
+	}
+
+	d.Attempts++
+	d.StatusCode = statusCode
+	switch {
+	case err == nil:
+		d.LastError = ""
+		w.complete(d, StatusDelivered)
+	case !ok || d.Attempts >= w.cfg.MaxAttempts:
+		d.LastError = err.Error()
+		log.Printf("Webhooks: giving up delivery %d to %s after %d attempts. Err: %s",
+			d.ID, d.Webhook, d.Attempts, err)
+		w.complete(d, StatusFailed)
+	default:
+		d.LastError = err.Error()
+		d.NextAttempt = time.Now().Add(Backoff(d.Attempts, w.cfg.InitialBackoff,
+			w.cfg.MaxBackoff))
+	}
+	w.dirty = true
+}
+
+// post sends a signed delivery, non 2xx responses are treated as failures
+func (w *Webhooks) post(webhook config.WebhookConfig, d *Delivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(d.Body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, d.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, d.Body))
+
+	client := *w.client
+	client.Timeout = webhook.Timeout
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseLength))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s",
+			resp.StatusCode, body)
+	}
+	return resp.StatusCode, nil
+}
+
+// Deliveries returns the pending deliveries followed by the completed
+// deliveries, both most recent first
+func (w *Webhooks) Deliveries() []Delivery {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	deliveries := make([]Delivery, 0, len(w.pending)+len(w.history))
+	for x := len(w.pending) - 1; x >= 0; x-- {
+		deliveries = append(deliveries, *w.pending[x])
+	}
+
+	for x := len(w.history) - 1; x >= 0; x-- {
+		deliveries = append(deliveries, w.history[x])
+	}
+	return deliveries
+}
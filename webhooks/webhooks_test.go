@@ -0,0 +1,225 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+)
+
+func testConfig(url string) config.WebhooksConfig {
+	return config.WebhooksConfig{
+		Enabled:        true,
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond * 4,
+		HistorySize:    10,
+		MaxQueueSize:   10,
+		Endpoints: []config.WebhookConfig{
+			{Name: "test", Enabled: true, URL: url, Secret: "secret", Timeout: time.Second},
+			{Name: "health", Enabled: true, URL: url, Secret: "secret", Events: "health"},
+			{Name: "disabled", URL: url, Secret: "secret"},
+		},
+	}
+}
+
+func waitForDeliveries(t *testing.T, w *Webhooks, status string, count int) []Delivery {
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		var matched []Delivery
+		for _, d := range w.Deliveries() {
+			if d.Status == status {
+				matched = append(matched, d)
+			}
+		}
+
+		if len(matched) == count {
+			return matched
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatalf("test failed - timed out waiting for %d %s deliveries", count, status)
+	return nil
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", 1514764800, []byte(`{"event":"ticker"}`))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1514764800.{"event":"ticker"}`))
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if signature != expected {
+		t.Errorf("test failed - Sign() returned %s expected %s", signature, expected)
+	}
+
+	if Sign("secret", 1514764800, []byte("a")) == Sign("secret", 1514764801, []byte("a")) {
+		t.Error("test failed - Sign() does not cover the timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, time.Second * 2},
+		{4, time.Second * 8},
+		{10, time.Second * 30},
+	}
+
+	for _, test := range tests {
+		delay := Backoff(test.attempts, time.Second, time.Second*30)
+		if delay != test.expected {
+			t.Errorf("test failed - Backoff(%d) returned %v expected %v",
+				test.attempts, delay, test.expected)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if r.Header.Get(HeaderSignature) != Sign("secret", timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		atomic.AddInt32(&received, 1)
+	}))
+	defer server.Close()
+
+	w, err := New(testConfig(server.URL), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Start()
+	defer w.Stop()
+
+	err = w.Publish(config.WebhookEventTicker, map[string]float64{"last": 1000})
+	if err != nil {
+		t.Fatalf("test failed - Publish() error %s", err)
+	}
+
+	deliveries := waitForDeliveries(t, w, StatusDelivered, 1)
+	if deliveries[0].Webhook != "test" || deliveries[0].Attempts != 1 ||
+		deliveries[0].StatusCode != http.StatusOK || atomic.LoadInt32(&received) != 1 {
+		t.Errorf("test failed - unexpected delivery %v", deliveries[0])
+	}
+}
+
+func TestRetryAndFail(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	w, err := New(testConfig(server.URL), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Start()
+	defer w.Stop()
+
+	err = w.Publish(config.WebhookEventHealth, "degraded")
+	if err != nil {
+		t.Fatalf("test failed - Publish() error %s", err)
+	}
+
+	deliveries := waitForDeliveries(t, w, StatusFailed, 2)
+	for _, d := range deliveries {
+		if d.Attempts != 3 || d.StatusCode != http.StatusInternalServerError ||
+			d.LastError == "" {
+			t.Errorf("test failed - unexpected delivery %v", d)
+		}
+	}
+
+	if atomic.LoadInt32(&requests) != 6 {
+		t.Errorf("test failed - expected 6 requests got %d", requests)
+	}
+}
+
+func TestPersistentQueue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "webhooks.json")
+
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&received, 1)
+	}))
+	defer server.Close()
+
+	w, err := New(testConfig(server.URL), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Publish(config.WebhookEventTrigger, "triggered")
+	if err != nil {
+		t.Fatalf("test failed - Publish() error %s", err)
+	}
+
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("test failed - Publish() should not save the queue on every event")
+	}
+	w.Stop()
+
+	restored, err := New(testConfig(server.URL), path)
+	if err != nil {
+		t.Fatalf("test failed - New() error %s", err)
+	}
+
+	if len(restored.pending) != 1 || restored.nextID != 2 {
+		t.Fatalf("test failed - queue not restored, %d pending", len(restored.pending))
+	}
+
+	restored.Start()
+	waitForDeliveries(t, restored, StatusDelivered, 1)
+	restored.Stop()
+
+	restored, err = New(testConfig(server.URL), path)
+	if err != nil {
+		t.Fatalf("test failed - New() error %s", err)
+	}
+
+	if len(restored.pending) != 0 || len(restored.history) != 1 {
+		t.Error("test failed - delivery history not persisted")
+	}
+}
+
+func TestQueueLimit(t *testing.T) {
+	cfg := testConfig("http://127.0.0.1:1")
+	cfg.MaxQueueSize = 2
+	w, err := New(cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		err = w.Publish(config.WebhookEventTicker, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(w.pending) != 2 || len(w.history) != 1 ||
+		w.history[0].Status != StatusFailed || w.pending[0].ID != 2 {
+		t.Errorf("test failed - queue limit not enforced, %d pending", len(w.pending))
+	}
+}
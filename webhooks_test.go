@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/config"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+	"github.com/trustfeed/go-crypto-pricefeeder/webhooks"
+)
+
+func setupTestWebhooks(t *testing.T) {
+	w, err := webhooks.New(config.WebhooksConfig{
+		Enabled:        true,
+		MaxAttempts:    1,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		HistorySize:    10,
+		MaxQueueSize:   10,
+		Endpoints: []config.WebhookConfig{
+			{Name: "health", Enabled: true, URL: "http://127.0.0.1:1", Secret: "secret", Events: "health"},
+			{Name: "ticker", Enabled: true, URL: "http://127.0.0.1:1", Secret: "secret", Events: "ticker"},
+		},
+	}, "")
+	if err != nil {
+		t.Fatalf("Test failed. webhooks.New: %s", err)
+	}
+	bot.Webhooks = w
+}
+
+func TestReportExchangeHealth(t *testing.T) {
+	setupTestWebhooks(t)
+	defer func() { bot.Webhooks = nil }()
+
+	p := pair.NewCurrencyPair("BTC", "USD")
+	ReportExchangeHealth("Bitfinex", webhookComponentTicker, p, ticker.Spot, nil)
+	ReportExchangeHealth("Bitfinex", webhookComponentTicker, p, ticker.Spot, errors.New("timeout"))
+	ReportExchangeHealth("Bitfinex", webhookComponentTicker, p, ticker.Spot, errors.New("timeout"))
+	ReportExchangeHealth("Bitfinex", webhookComponentTicker, p, ticker.Spot, nil)
+
+	deliveries := bot.Webhooks.Deliveries()
+	if len(deliveries) != 2 {
+		t.Fatalf("Test failed. Expected 2 health alerts got %d", len(deliveries))
+	}
+
+	var payload struct {
+		Data WebhookHealthAlert
+	}
+	err := json.Unmarshal(deliveries[1].Body, &payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Data.Status != webhookHealthDegraded || payload.Data.Message != "timeout" ||
+		payload.Data.Pair != "BTCUSD" || deliveries[1].Webhook != "health" {
+		t.Errorf("Test failed. Unexpected health alert %v", payload.Data)
+	}
+}
+
+func TestRESTv2GetWebhookDeliveries(t *testing.T) {
+	setupTestWebhooks(t)
+	defer func() { bot.Webhooks = nil }()
+
+	bot.Webhooks.Publish(config.WebhookEventTicker, "ticker")
+	bot.Webhooks.Publish(config.WebhookEventHealth, "health")
+
+	w := httptest.NewRecorder()
+	RESTv2GetWebhookDeliveries(w, httptest.NewRequest("GET",
+		"/v2/webhooks/deliveries?webhook=HEALTH&status=pending", nil))
+
+	var resp struct {
+		Data       []webhooks.Delivery
+		Pagination RESTv2Pagination
+	}
+	err := json.NewDecoder(w.Body).Decode(&resp)
+	if err != nil {
+		t.Fatalf("Test failed. RESTv2GetWebhookDeliveries returned invalid JSON: %s", err)
+	}
+
+	if resp.Pagination.Total != 1 || resp.Data[0].Webhook != "health" ||
+		resp.Data[0].Event != config.WebhookEventHealth {
+		t.Errorf("Test failed. RESTv2GetWebhookDeliveries unexpected result %v", resp)
+	}
+}
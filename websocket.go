@@ -3,11 +3,11 @@ package main
 import (
 	"log"
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/websocket"
 	"github.com/trustfeed/go-crypto-pricefeeder/common"
 	"github.com/trustfeed/go-crypto-pricefeeder/config"
-	"github.com/trustfeed/go-crypto-pricefeeder/currency"
 )
 
 // Const vars for websocket
@@ -21,21 +21,26 @@ var (
 )
 
 type wsCommandHandler struct {
-	authRequired bool
-	handler      func(client *WebsocketClient, data interface{}) error
+	scope   string
+	handler func(client *WebsocketClient, data interface{}) error
 }
 
 var wsHandlers = map[string]wsCommandHandler{
-	"auth":             wsCommandHandler{authRequired: false, handler: wsAuth},
-	"getconfig":        wsCommandHandler{authRequired: true, handler: wsGetConfig},
-	"saveconfig":       wsCommandHandler{authRequired: true, handler: wsSaveConfig},
-	"getaccountinfo":   wsCommandHandler{authRequired: true, handler: wsGetAccountInfo},
-	"gettickers":       wsCommandHandler{authRequired: false, handler: wsGetTickers},
-	"getticker":        wsCommandHandler{authRequired: false, handler: wsGetTicker},
-	"getorderbooks":    wsCommandHandler{authRequired: false, handler: wsGetOrderbooks},
-	"getorderbook":     wsCommandHandler{authRequired: false, handler: wsGetOrderbook},
-	"getexchangerates": wsCommandHandler{authRequired: false, handler: wsGetExchangeRates},
-	"getportfolio":     wsCommandHandler{authRequired: true, handler: wsGetPortfolio},
+	"auth":             wsCommandHandler{scope: "", handler: wsAuth},
+	"getconfig":        wsCommandHandler{scope: config.APIScopeAdmin, handler: wsGetConfig},
+	"saveconfig":       wsCommandHandler{scope: config.APIScopeAdmin, handler: wsSaveConfig},
+	"getaccountinfo":   wsCommandHandler{scope: config.APIScopeReadPortfolio, handler: wsGetAccountInfo},
+	"gettickers":       wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsGetTickers},
+	"getticker":        wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsGetTicker},
+	"getorderbooks":    wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsGetOrderbooks},
+	"getorderbook":     wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsGetOrderbook},
+	"getexchangerates": wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsGetExchangeRates},
+	"getportfolio":     wsCommandHandler{scope: config.APIScopeReadPortfolio, handler: wsGetPortfolio},
+	"subscribe":        wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsSubscribe},
+	"unsubscribe":      wsCommandHandler{scope: config.APIScopeReadMarket, handler: wsUnsubscribe},
+	"getevents":        wsCommandHandler{scope: config.APIScopeAdmin, handler: wsGetEvents},
+	"addevent":         wsCommandHandler{scope: config.APIScopeAdmin, handler: wsAddEvent},
+	"removeevent":      wsCommandHandler{scope: config.APIScopeAdmin, handler: wsRemoveEvent},
 }
 
 // WebsocketClient stores information related to the websocket client
@@ -43,8 +48,14 @@ type WebsocketClient struct {
 	Hub           *WebsocketHub
 	Conn          *websocket.Conn
 	Authenticated bool
+	Identity      *AuthIdentity
 	authFailures  int
 	Send          chan []byte
+	subscribed    bool
+	pending       map[string][]byte
+	pendingOrder  []string
+	pendingMtx    sync.Mutex
+	notify        chan struct{}
 }
 
 // WebsocketHub stores the data for managing websocket clients
@@ -78,10 +89,12 @@ type WebsocketOrderbookTickerRequest struct {
 	AssetType string `json:"assetType"`
 }
 
-// WebsocketAuth is a struct used for
+// WebsocketAuth is a struct used for authenticating a websocket client with
+// either the admin username and SHA256 hashed password or an API/session token
 type WebsocketAuth struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Token    string `json:"token,omitempty"`
 }
 
 // NewWebsocketHub Creates a new websocket hub
@@ -102,15 +115,21 @@ func (h *WebsocketHub) run() {
 		case client := <-h.Unregister:
 			if _, ok := h.Clients[client]; ok {
 				log.Printf("websocket: disconnected client")
+				client.UnsubscribeAllTopics()
 				delete(h.Clients, client)
 				close(client.Send)
 			}
 		case message := <-h.Broadcast:
 			for client := range h.Clients {
+				if client.IsSubscribed() {
+					continue
+				}
+
 				select {
 				case client.Send <- message:
 				default:
 					log.Printf("websocket: disconnected client")
+					client.UnsubscribeAllTopics()
 					close(client.Send)
 					delete(h.Clients, client)
 				}
@@ -174,10 +193,29 @@ func (c *WebsocketClient) read() {
 				continue
 			}
 
-			if result.authRequired && !c.Authenticated {
-				log.Printf("Websocket: request %s failed due to unauthenticated request on an authenticated API", evt.Event)
-				c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "unauthorised request on authenticated API"})
-				continue
+			if c.Authenticated && c.Identity.IsExpired() {
+				log.Printf("websocket: client session expired")
+				c.Authenticated = false
+				c.Identity = nil
+			}
+
+			if IsAuthScopeRequired(result.scope) {
+				if !c.Authenticated {
+					log.Printf("Websocket: request %s failed due to unauthenticated request on an authenticated API", evt.Event)
+					c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "unauthorised request on authenticated API"})
+					continue
+				}
+
+				if !c.Identity.HasScope(result.scope) {
+					log.Printf("Websocket: request %s failed due to missing %s scope", evt.Event, result.scope)
+					c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: errAuthScopeDenied.Error()})
+					continue
+				}
+
+				if _, ok := CheckRateLimit(c.Identity); !ok {
+					c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: errAuthRateLimited.Error()})
+					continue
+				}
 			}
 
 			err = result.handler(c, dataJSON)
@@ -219,6 +257,11 @@ func (c *WebsocketClient) write() {
 				log.Printf("websocket: failed to close io.WriteCloser: %s", err)
 				return
 			}
+		case <-c.notify:
+			if err := c.writePending(); err != nil {
+				log.Printf("websocket: failed to write topic messages: %s", err)
+				return
+			}
 		}
 	}
 }
@@ -230,6 +273,7 @@ func StartWebsocketHandler() {
 		wsHubStarted = true
 		wsHub = NewWebsocketHub()
 		go wsHub.run()
+		go websocketTopicDispatcher()
 	}
 }
 
@@ -278,7 +322,13 @@ func WebsocketClientHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client := &WebsocketClient{Hub: wsHub, Conn: conn, Send: make(chan []byte, 1024)}
+	client := &WebsocketClient{
+		Hub:     wsHub,
+		Conn:    conn,
+		Send:    make(chan []byte, 1024),
+		pending: make(map[string][]byte),
+		notify:  make(chan struct{}, 1),
+	}
 	client.Hub.Register <- client
 	log.Printf("websocket: client connected. Connected clients: %d. Limit %d.",
 		numClients+1, connectionLimit)
@@ -300,33 +350,62 @@ func wsAuth(client *WebsocketClient, data interface{}) error {
 		return err
 	}
 
-	hashPW := common.HexEncodeToString(common.GetSHA256([]byte(bot.Config.Webserver.AdminPassword)))
-	if auth.Username == bot.Config.Webserver.AdminUsername && auth.Password == hashPW {
-		client.Authenticated = true
-		wsResp.Data = WebsocketResponseSuccess
-		log.Println("websocket: client authenticated successfully")
-		return client.SendWebsocketMessage(wsResp)
-	} else {
-		wsResp.Error = "invalid username/password"
-		client.authFailures++
-		client.SendWebsocketMessage(wsResp)
-		if client.authFailures >= bot.Config.Webserver.WebsocketMaxAuthFailures {
-			log.Printf("websocket: disconnecting client, maximum auth failures threshold reached (failures: %d limit: %d)",
-				client.authFailures, bot.Config.Webserver.WebsocketMaxAuthFailures)
-			wsHub.Unregister <- client
-			return nil
+	if auth.Token != "" {
+		identity, err := AuthenticateToken(auth.Token)
+		if err == nil {
+			client.Authenticated = true
+			client.Identity = identity
+			wsResp.Data = WebsocketResponseSuccess
+			log.Printf("websocket: client authenticated successfully with %s token", identity.Name)
+			return client.SendWebsocketMessage(wsResp)
 		}
+		return wsAuthFailed(client, wsResp, err)
+	}
 
-		log.Printf("websocket: client sent wrong username/password (failures: %d limit: %d)",
+	err = CheckLogin(client.Conn.RemoteAddr().String(), auth.Username, auth.Password)
+	if err != nil {
+		return wsAuthFailed(client, wsResp, err)
+	}
+
+	session, err := NewSessionToken()
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	client.Authenticated = true
+	client.Identity = session
+	wsResp.Data = AuthSessionResponse{
+		Token:   session.token,
+		Expires: session.Expires,
+	}
+	log.Println("websocket: client authenticated successfully")
+	return client.SendWebsocketMessage(wsResp)
+}
+
+// wsAuthFailed replies to a failed auth request and disconnects the client
+// once the maximum auth failures threshold is reached
+func wsAuthFailed(client *WebsocketClient, wsResp WebsocketEventResponse, authErr error) error {
+	wsResp.Error = authErr.Error()
+	client.authFailures++
+	client.SendWebsocketMessage(wsResp)
+	if client.authFailures >= bot.Config.Webserver.WebsocketMaxAuthFailures {
+		log.Printf("websocket: disconnecting client, maximum auth failures threshold reached (failures: %d limit: %d)",
 			client.authFailures, bot.Config.Webserver.WebsocketMaxAuthFailures)
+		wsHub.Unregister <- client
 		return nil
 	}
+
+	log.Printf("websocket: client auth failed: %s (failures: %d limit: %d)",
+		authErr, client.authFailures, bot.Config.Webserver.WebsocketMaxAuthFailures)
+	return nil
 }
 
 func wsGetConfig(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetConfig",
-		Data:  bot.Config,
+		Data:  bot.Config.RedactSecrets(),
 	}
 	return client.SendWebsocketMessage(wsResp)
 }
@@ -351,6 +430,7 @@ func wsSaveConfig(client *WebsocketClient, data interface{}) error {
 	}
 
 	SetupExchanges(bot)
+	ReloadEvents()
 	wsResp.Data = WebsocketResponseSuccess
 	return client.SendWebsocketMessage(wsResp)
 }
@@ -432,7 +512,7 @@ func wsGetExchangeRates(client *WebsocketClient, data interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetExchangeRates",
 	}
-	wsResp.Data = currency.GetExchangeRates()
+	wsResp.Data = GetFXStatus()
 	return client.SendWebsocketMessage(wsResp)
 }
 
@@ -0,0 +1,560 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/trustfeed/go-crypto-pricefeeder/common"
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+// Const vars for websocket topic subscriptions
+const (
+	WebsocketTopicTicker    = "ticker"
+	WebsocketTopicOrderbook = "orderbook"
+	WebsocketTopicReference = "reference"
+	WebsocketTopicWildcard  = "*"
+
+	wsTopicFeedBufferSize = 4096
+)
+
+// Vars for websocket topic subscriptions
+var (
+	errWebsocketTopicInvalid = errors.New("invalid topic, expected ticker:{exchange}:{pair}, orderbook:{exchange}:{pair}:{depth} or reference:{pair}")
+	errWebsocketTopicsEmpty  = errors.New("no topics supplied")
+
+	wsTopics    = make(map[string]map[*WebsocketClient]map[string]WebsocketTopic)
+	wsTopicsMtx sync.Mutex
+)
+
+// WebsocketTopic is a parsed websocket subscription topic
+type WebsocketTopic struct {
+	Name     string
+	Channel  string
+	Exchange string
+	Pair     pair.CurrencyPair
+	Depth    int
+}
+
+// WebsocketSubscribeRequest holds the topics to subscribe or unsubscribe
+type WebsocketSubscribeRequest struct {
+	Topics []string `json:"topics"`
+}
+
+// WebsocketTopicMessage is sent to clients subscribed to a topic
+type WebsocketTopicMessage struct {
+	Event    string      `json:"event"`
+	Topic    string      `json:"topic"`
+	Data     interface{} `json:"data"`
+	Snapshot bool        `json:"snapshot,omitempty"`
+}
+
+// WebsocketReferencePrice is the reference price sent to reference topic
+// subscribers
+type WebsocketReferencePrice struct {
+	Pair    string               `json:"pair"`
+	Price   float64              `json:"price"`
+	Sources []AdapterPriceSource `json:"sources"`
+}
+
+// normaliseTopicPair returns the upper case currency pair without a delimiter
+func normaliseTopicPair(p pair.CurrencyPair) string {
+	return p.FirstCurrency.Upper().String() + p.SecondCurrency.Upper().String()
+}
+
+// topicKey returns the fan-out key for a channel, exchange and pair
+func topicKey(channel, exchangeName, p string) string {
+	return channel + ":" + common.StringToLower(exchangeName) + ":" + p
+}
+
+// Key returns the fan-out key of the topic
+func (t *WebsocketTopic) Key() string {
+	p := WebsocketTopicWildcard
+	if t.Pair.FirstCurrency != "" {
+		p = normaliseTopicPair(t.Pair)
+	}
+	return topicKey(t.Channel, t.Exchange, p)
+}
+
+// ParseWebsocketTopic parses a ticker:{exchange}:{pair},
+// orderbook:{exchange}:{pair}:{depth} or reference:{pair} topic. The exchange
+// and pair of ticker and orderbook topics may be * to match all, the orderbook
+// depth is optional
+func ParseWebsocketTopic(topic string) (WebsocketTopic, error) {
+	parts := common.SplitStrings(topic, ":")
+	result := WebsocketTopic{
+		Name:    topic,
+		Channel: common.StringToLower(parts[0]),
+	}
+
+	switch result.Channel {
+	case WebsocketTopicTicker, WebsocketTopicOrderbook:
+		if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+			return result, errWebsocketTopicInvalid
+		}
+
+		if result.Channel == WebsocketTopicTicker && len(parts) != 3 {
+			return result, errWebsocketTopicInvalid
+		}
+
+		if len(parts) > 4 {
+			return result, errWebsocketTopicInvalid
+		}
+
+		result.Exchange = common.StringToLower(parts[1])
+		if parts[2] != WebsocketTopicWildcard {
+			p, err := parseRESTv2Pair(parts[2])
+			if err != nil {
+				return result, err
+			}
+			result.Pair = p
+		}
+
+		if len(parts) == 4 {
+			depth, err := strconv.Atoi(parts[3])
+			if err != nil || depth < 1 {
+				return result, fmt.Errorf("invalid orderbook depth %s", parts[3])
+			}
+			result.Depth = depth
+		}
+	case WebsocketTopicReference:
+		if len(parts) != 2 || parts[1] == WebsocketTopicWildcard {
+			return result, errWebsocketTopicInvalid
+		}
+
+		p, err := parseRESTv2Pair(parts[1])
+		if err != nil {
+			return result, err
+		}
+		result.Pair = p
+		result.Exchange = WebsocketTopicWildcard
+	default:
+		return result, errWebsocketTopicInvalid
+	}
+	return result, nil
+}
+
+// truncateOrderbook limits the bids and asks of an orderbook to the supplied
+// depth
+func truncateOrderbook(ob orderbook.Base, depth int) orderbook.Base {
+	if depth <= 0 {
+		return ob
+	}
+
+	if len(ob.Bids) > depth {
+		ob.Bids = ob.Bids[:depth]
+	}
+
+	if len(ob.Asks) > depth {
+		ob.Asks = ob.Asks[:depth]
+	}
+	return ob
+}
+
+// QueueTopicMessage queues a topic message for the client. Pending messages
+// for the same topic are replaced so the client always receives the latest
+// value under backpressure
+func (c *WebsocketClient) QueueTopicMessage(msg WebsocketTopicMessage) error {
+	data, err := common.JSONEncode(msg)
+	if err != nil {
+		return err
+	}
+
+	c.pendingMtx.Lock()
+	if _, ok := c.pending[msg.Topic]; !ok {
+		c.pendingOrder = append(c.pendingOrder, msg.Topic)
+	}
+	c.pending[msg.Topic] = data
+	c.pendingMtx.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// writePending writes the pending topic messages to the connection
+func (c *WebsocketClient) writePending() error {
+	c.pendingMtx.Lock()
+	pending := c.pending
+	order := c.pendingOrder
+	c.pending = make(map[string][]byte)
+	c.pendingOrder = nil
+	c.pendingMtx.Unlock()
+
+	for x := range order {
+		err := c.Conn.WriteMessage(websocket.TextMessage, pending[order[x]])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubscribeTopic subscribes the client to a topic and sends the initial
+// snapshot
+func (c *WebsocketClient) SubscribeTopic(topic WebsocketTopic) {
+	key := topic.Key()
+
+	wsTopicsMtx.Lock()
+	if _, ok := wsTopics[key]; !ok {
+		wsTopics[key] = make(map[*WebsocketClient]map[string]WebsocketTopic)
+	}
+
+	if _, ok := wsTopics[key][c]; !ok {
+		wsTopics[key][c] = make(map[string]WebsocketTopic)
+	}
+	wsTopics[key][c][topic.Name] = topic
+	c.subscribed = true
+	wsTopicsMtx.Unlock()
+
+	sendTopicSnapshot(c, topic)
+}
+
+// UnsubscribeTopic removes a client topic subscription
+func (c *WebsocketClient) UnsubscribeTopic(topic WebsocketTopic) {
+	key := topic.Key()
+
+	wsTopicsMtx.Lock()
+	defer wsTopicsMtx.Unlock()
+
+	delete(wsTopics[key][c], topic.Name)
+	if len(wsTopics[key][c]) == 0 {
+		delete(wsTopics[key], c)
+	}
+
+	if len(wsTopics[key]) == 0 {
+		delete(wsTopics, key)
+	}
+	c.subscribed = c.hasTopics()
+}
+
+// UnsubscribeAllTopics removes all client topic subscriptions
+func (c *WebsocketClient) UnsubscribeAllTopics() {
+	wsTopicsMtx.Lock()
+	defer wsTopicsMtx.Unlock()
+
+	for key := range wsTopics {
+		delete(wsTopics[key], c)
+		if len(wsTopics[key]) == 0 {
+			delete(wsTopics, key)
+		}
+	}
+	c.subscribed = false
+}
+
+// hasTopics returns whether or not the client is subscribed to any topic. The
+// caller must hold wsTopicsMtx
+func (c *WebsocketClient) hasTopics() bool {
+	for key := range wsTopics {
+		if _, ok := wsTopics[key][c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSubscribed returns whether or not the client is subscribed to any topic,
+// subscribed clients don't receive the broadcast firehose until they have
+// unsubscribed from all their topics
+func (c *WebsocketClient) IsSubscribed() bool {
+	wsTopicsMtx.Lock()
+	defer wsTopicsMtx.Unlock()
+	return c.subscribed
+}
+
+// sendTopicSnapshot sends the currently stored values matching the topic to
+// the client
+func sendTopicSnapshot(c *WebsocketClient, topic WebsocketTopic) {
+	if topic.Channel == WebsocketTopicReference {
+		ref, err := getReferencePrice(topic.Pair)
+		if err != nil {
+			return
+		}
+		c.QueueTopicMessage(WebsocketTopicMessage{
+			Event:    WebsocketTopicReference,
+			Topic:    topic.Name,
+			Data:     ref,
+			Snapshot: true,
+		})
+		return
+	}
+
+	query := RESTv2Query{Pair: topic.Pair}
+	if topic.Exchange != WebsocketTopicWildcard {
+		query.Exchange = topic.Exchange
+	}
+
+	activePairs := restV2FilteredPairs(query)
+	for x := range activePairs {
+		exchangeName := activePairs[x].Exchange.GetName()
+		msg := WebsocketTopicMessage{
+			Event:    topic.Channel,
+			Topic:    topicMessageName(topic, exchangeName, activePairs[x].Pair),
+			Snapshot: true,
+		}
+
+		switch topic.Channel {
+		case WebsocketTopicTicker:
+			tickerPrice, err := ticker.GetTicker(exchangeName,
+				activePairs[x].Pair, activePairs[x].AssetType)
+			if err != nil {
+				continue
+			}
+			msg.Data = NewRESTv2Ticker(exchangeName, activePairs[x].AssetType,
+				tickerPrice)
+		case WebsocketTopicOrderbook:
+			ob, err := orderbook.GetOrderbook(exchangeName, activePairs[x].Pair,
+				activePairs[x].AssetType)
+			if err != nil {
+				continue
+			}
+			msg.Data = NewRESTv2Orderbook(exchangeName, activePairs[x].AssetType,
+				truncateOrderbook(ob, topic.Depth))
+		}
+		c.QueueTopicMessage(msg)
+	}
+}
+
+// topicMessageName returns the topic name used for a message, wildcard topics
+// are expanded so conflation keeps the latest value per exchange and pair
+func topicMessageName(topic WebsocketTopic, exchangeName string, p pair.CurrencyPair) string {
+	if topic.Exchange != WebsocketTopicWildcard && topic.Pair.FirstCurrency != "" {
+		return topic.Name
+	}
+
+	name := topic.Channel + ":" + common.StringToLower(exchangeName) + ":" +
+		normaliseTopicPair(p)
+	if topic.Depth > 0 {
+		name += ":" + strconv.Itoa(topic.Depth)
+	}
+	return name
+}
+
+// getReferencePrice returns the reference price of a pair across all enabled
+// exchanges
+func getReferencePrice(p pair.CurrencyPair) (WebsocketReferencePrice, error) {
+	price, sources, err := GetAdapterPrice(bot, p.FirstCurrency.String(),
+		p.SecondCurrency.String(), "", "", bot.Config.Webserver.AdapterTickerMaxAge)
+	if err != nil {
+		return WebsocketReferencePrice{}, err
+	}
+
+	return WebsocketReferencePrice{
+		Pair:    normaliseTopicPair(p),
+		Price:   price,
+		Sources: sources,
+	}, nil
+}
+
+// topicSubscribers returns the client subscriptions for the supplied keys
+func topicSubscribers(keys ...string) map[*WebsocketClient][]WebsocketTopic {
+	wsTopicsMtx.Lock()
+	defer wsTopicsMtx.Unlock()
+
+	result := make(map[*WebsocketClient][]WebsocketTopic)
+	for x := range keys {
+		for client, topics := range wsTopics[keys[x]] {
+			for _, topic := range topics {
+				result[client] = append(result[client], topic)
+			}
+		}
+	}
+	return result
+}
+
+// referenceTopics returns the subscribed reference topics
+func referenceTopics() []WebsocketTopic {
+	wsTopicsMtx.Lock()
+	defer wsTopicsMtx.Unlock()
+
+	var result []WebsocketTopic
+	for _, clients := range wsTopics {
+		for _, topics := range clients {
+			for _, topic := range topics {
+				if topic.Channel == WebsocketTopicReference {
+					result = append(result, topic)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// PublishTopicEvent fans out a ticker or orderbook event to the clients
+// subscribed to matching topics
+func PublishTopicEvent(evt WebsocketEvent) {
+	var channel string
+	var p pair.CurrencyPair
+	switch d := evt.Data.(type) {
+	case ticker.Price:
+		channel = WebsocketTopicTicker
+		p = d.Pair
+	case orderbook.Base:
+		channel = WebsocketTopicOrderbook
+		p = d.Pair
+	default:
+		return
+	}
+
+	pairStr := normaliseTopicPair(p)
+	subscribers := topicSubscribers(
+		topicKey(channel, evt.Exchange, pairStr),
+		topicKey(channel, evt.Exchange, WebsocketTopicWildcard),
+		topicKey(channel, WebsocketTopicWildcard, pairStr),
+		topicKey(channel, WebsocketTopicWildcard, WebsocketTopicWildcard),
+	)
+
+	for client, topics := range subscribers {
+		for x := range topics {
+			msg := WebsocketTopicMessage{
+				Event: channel,
+				Topic: topicMessageName(topics[x], evt.Exchange, p),
+			}
+
+			switch d := evt.Data.(type) {
+			case ticker.Price:
+				msg.Data = NewRESTv2Ticker(evt.Exchange, evt.AssetType, d)
+			case orderbook.Base:
+				msg.Data = NewRESTv2Orderbook(evt.Exchange, evt.AssetType,
+					truncateOrderbook(d, topics[x].Depth))
+			}
+
+			err := client.QueueTopicMessage(msg)
+			if err != nil {
+				log.Printf("websocket: failed to queue %s message: %s",
+					msg.Topic, err)
+			}
+		}
+	}
+
+	if channel == WebsocketTopicTicker {
+		publishReferenceTopics(p)
+	}
+}
+
+// publishReferenceTopics recalculates the reference price of subscribed pairs
+// which share a currency with the updated ticker pair
+func publishReferenceTopics(p pair.CurrencyPair) {
+	refTopics := referenceTopics()
+	calculated := make(map[string]bool)
+	for x := range refTopics {
+		key := refTopics[x].Key()
+		if calculated[key] {
+			continue
+		}
+
+		if !isAdapterCurrency(p.FirstCurrency, refTopics[x].Pair.FirstCurrency) &&
+			!isAdapterCurrency(p.SecondCurrency, refTopics[x].Pair.FirstCurrency) &&
+			!isAdapterCurrency(p.FirstCurrency, refTopics[x].Pair.SecondCurrency) &&
+			!isAdapterCurrency(p.SecondCurrency, refTopics[x].Pair.SecondCurrency) {
+			continue
+		}
+		calculated[key] = true
+
+		ref, err := getReferencePrice(refTopics[x].Pair)
+		if err != nil {
+			continue
+		}
+
+		for client, topics := range topicSubscribers(key) {
+			for y := range topics {
+				client.QueueTopicMessage(WebsocketTopicMessage{
+					Event: WebsocketTopicReference,
+					Topic: topics[y].Name,
+					Data:  ref,
+				})
+			}
+		}
+	}
+}
+
+// websocketTopicDispatcher fans out the feed events to topic subscribers
+func websocketTopicDispatcher() {
+	feed := SubscribeFeed(wsTopicFeedBufferSize)
+	for evt := range feed {
+		PublishTopicEvent(evt)
+	}
+}
+
+// parseTopics parses the topics of a subscribe or unsubscribe request
+func parseTopics(data interface{}) ([]WebsocketTopic, error) {
+	var req WebsocketSubscribeRequest
+	err := common.JSONDecode(data.([]byte), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Topics) == 0 {
+		return nil, errWebsocketTopicsEmpty
+	}
+
+	var topics []WebsocketTopic
+	for x := range req.Topics {
+		topic, err := ParseWebsocketTopic(strings.TrimSpace(req.Topics[x]))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", req.Topics[x], err)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+func wsSubscribe(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "Subscribe",
+	}
+
+	topics, err := parseTopics(data)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	var subscribed []string
+	for x := range topics {
+		subscribed = append(subscribed, topics[x].Name)
+	}
+
+	wsResp.Data = subscribed
+	err = client.SendWebsocketMessage(wsResp)
+	if err != nil {
+		return err
+	}
+
+	for x := range topics {
+		client.SubscribeTopic(topics[x])
+	}
+	return nil
+}
+
+func wsUnsubscribe(client *WebsocketClient, data interface{}) error {
+	wsResp := WebsocketEventResponse{
+		Event: "Unsubscribe",
+	}
+
+	topics, err := parseTopics(data)
+	if err != nil {
+		wsResp.Error = err.Error()
+		client.SendWebsocketMessage(wsResp)
+		return err
+	}
+
+	var unsubscribed []string
+	for x := range topics {
+		client.UnsubscribeTopic(topics[x])
+		unsubscribed = append(unsubscribed, topics[x].Name)
+	}
+
+	wsResp.Data = unsubscribed
+	return client.SendWebsocketMessage(wsResp)
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/trustfeed/go-crypto-pricefeeder/currency/pair"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/orderbook"
+	"github.com/trustfeed/go-crypto-pricefeeder/exchanges/ticker"
+)
+
+func newTestTopicClient() *WebsocketClient {
+	return &WebsocketClient{
+		pending: make(map[string][]byte),
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+func TestParseWebsocketTopic(t *testing.T) {
+	topic, err := ParseWebsocketTopic("ticker:Bitfinex:BTC-USD")
+	if err != nil {
+		t.Fatalf("Test failed. ParseWebsocketTopic: %s", err)
+	}
+
+	if topic.Key() != "ticker:bitfinex:BTCUSD" {
+		t.Errorf("Test failed. ParseWebsocketTopic unexpected key %s", topic.Key())
+	}
+
+	topic, err = ParseWebsocketTopic("orderbook:kraken:XBTUSD:10")
+	if err != nil {
+		t.Fatalf("Test failed. ParseWebsocketTopic: %s", err)
+	}
+
+	if topic.Depth != 10 || topic.Key() != "orderbook:kraken:XBTUSD" {
+		t.Errorf("Test failed. ParseWebsocketTopic unexpected result %v", topic)
+	}
+
+	topic, err = ParseWebsocketTopic("ticker:*:*")
+	if err != nil || topic.Key() != "ticker:*:*" {
+		t.Errorf("Test failed. ParseWebsocketTopic wildcard topic error: %v", err)
+	}
+
+	topic, err = ParseWebsocketTopic("reference:BTCUSD")
+	if err != nil || topic.Key() != "reference:*:BTCUSD" {
+		t.Errorf("Test failed. ParseWebsocketTopic reference topic error: %v", err)
+	}
+
+	invalid := []string{"", "ticker", "ticker:bitfinex", "ticker:bitfinex:BTCUSD:10",
+		"orderbook:bitfinex:BTCUSD:0", "orderbook:bitfinex:BTCUSD:abc",
+		"reference:*", "reference", "trades:bitfinex:BTCUSD", "ticker:bitfinex:BTC"}
+	for x := range invalid {
+		_, err = ParseWebsocketTopic(invalid[x])
+		if err == nil {
+			t.Errorf("Test failed. ParseWebsocketTopic %s should have thrown an error",
+				invalid[x])
+		}
+	}
+}
+
+func TestTruncateOrderbook(t *testing.T) {
+	ob := orderbook.Base{
+		Bids: []orderbook.Item{{Price: 3}, {Price: 2}, {Price: 1}},
+		Asks: []orderbook.Item{{Price: 4}},
+	}
+
+	result := truncateOrderbook(ob, 2)
+	if len(result.Bids) != 2 || len(result.Asks) != 1 {
+		t.Error("Test failed. truncateOrderbook returned incorrect depth")
+	}
+
+	result = truncateOrderbook(ob, 0)
+	if len(result.Bids) != 3 {
+		t.Error("Test failed. truncateOrderbook should not truncate without a depth")
+	}
+}
+
+func TestQueueTopicMessageConflation(t *testing.T) {
+	client := newTestTopicClient()
+	for x := 1; x <= 3; x++ {
+		err := client.QueueTopicMessage(WebsocketTopicMessage{
+			Event: WebsocketTopicTicker,
+			Topic: "ticker:bitfinex:BTCUSD",
+			Data:  x,
+		})
+		if err != nil {
+			t.Fatalf("Test failed. QueueTopicMessage: %s", err)
+		}
+	}
+
+	client.QueueTopicMessage(WebsocketTopicMessage{
+		Event: WebsocketTopicTicker,
+		Topic: "ticker:bitfinex:LTCUSD",
+		Data:  1,
+	})
+
+	if len(client.pending) != 2 || len(client.pendingOrder) != 2 {
+		t.Fatalf("Test failed. Expected 2 pending topics, got %d", len(client.pending))
+	}
+
+	var msg WebsocketTopicMessage
+	json.Unmarshal(client.pending["ticker:bitfinex:BTCUSD"], &msg)
+	if msg.Data.(float64) != 3 {
+		t.Error("Test failed. QueueTopicMessage did not keep the latest value")
+	}
+
+	if len(client.notify) != 1 {
+		t.Error("Test failed. QueueTopicMessage did not notify the writer")
+	}
+}
+
+func TestPublishTopicEvent(t *testing.T) {
+	client := newTestTopicClient()
+	wildcard := newTestTopicClient()
+	other := newTestTopicClient()
+
+	topic, _ := ParseWebsocketTopic("orderbook:bitfinex:BTCUSD:1")
+	client.SubscribeTopic(topic)
+	topic, _ = ParseWebsocketTopic("orderbook:*:*")
+	wildcard.SubscribeTopic(topic)
+	topic, _ = ParseWebsocketTopic("ticker:bitfinex:BTCUSD")
+	other.SubscribeTopic(topic)
+	defer client.UnsubscribeAllTopics()
+	defer wildcard.UnsubscribeAllTopics()
+	defer other.UnsubscribeAllTopics()
+
+	PublishTopicEvent(WebsocketEvent{
+		Exchange:  "Bitfinex",
+		AssetType: orderbook.Spot,
+		Event:     FeedEventOrderbook,
+		Data: orderbook.Base{
+			Pair: pair.NewCurrencyPair("BTC", "USD"),
+			Bids: []orderbook.Item{{Price: 2}, {Price: 1}},
+		},
+	})
+
+	var msg struct {
+		Topic string          `json:"topic"`
+		Data  RESTv2Orderbook `json:"data"`
+	}
+	err := json.Unmarshal(client.pending["orderbook:bitfinex:BTCUSD:1"], &msg)
+	if err != nil {
+		t.Fatalf("Test failed. PublishTopicEvent message not queued: %s", err)
+	}
+
+	if len(msg.Data.Bids) != 1 {
+		t.Error("Test failed. PublishTopicEvent did not apply the orderbook depth")
+	}
+
+	if _, ok := wildcard.pending["orderbook:bitfinex:BTCUSD"]; !ok {
+		t.Error("Test failed. PublishTopicEvent did not fan out to wildcard subscriber")
+	}
+
+	if len(other.pending) != 0 {
+		t.Error("Test failed. PublishTopicEvent sent orderbook to ticker subscriber")
+	}
+
+	topic, _ = ParseWebsocketTopic("orderbook:bitfinex:BTCUSD:1")
+	client.UnsubscribeTopic(topic)
+	client.pending = make(map[string][]byte)
+	PublishTopicEvent(WebsocketEvent{
+		Exchange: "Bitfinex",
+		Data:     ticker.Price{Pair: pair.NewCurrencyPair("BTC", "USD")},
+	})
+
+	if len(client.pending) != 0 || len(other.pending) != 1 {
+		t.Error("Test failed. PublishTopicEvent ticker fan-out incorrect")
+	}
+}
+
+func TestWebsocketUnsubscribeBroadcast(t *testing.T) {
+	hub := NewWebsocketHub()
+	go hub.run()
+
+	client := newTestTopicClient()
+	client.Hub = hub
+	client.Send = make(chan []byte, 1)
+	hub.Register <- client
+
+	tickerTopic, _ := ParseWebsocketTopic("ticker:bitfinex:BTCUSD")
+	orderbookTopic, _ := ParseWebsocketTopic("orderbook:bitfinex:BTCUSD")
+	client.SubscribeTopic(tickerTopic)
+	client.SubscribeTopic(orderbookTopic)
+	defer client.UnsubscribeAllTopics()
+
+	// Registering the client again returns once the hub has finished sending
+	// the previous broadcast
+	broadcast := func(msg string) {
+		hub.Broadcast <- []byte(msg)
+		hub.Register <- client
+	}
+
+	broadcast("subscribed")
+	client.UnsubscribeTopic(tickerTopic)
+	if !client.IsSubscribed() {
+		t.Error("Test failed. UnsubscribeTopic cleared the subscription with topics left")
+	}
+
+	broadcast("partially unsubscribed")
+	client.UnsubscribeTopic(orderbookTopic)
+	if client.IsSubscribed() {
+		t.Error("Test failed. UnsubscribeTopic did not clear the subscription")
+	}
+
+	broadcast("unsubscribed")
+	select {
+	case msg := <-client.Send:
+		if string(msg) != "unsubscribed" {
+			t.Errorf("Test failed. Subscribed client received the broadcast %s", msg)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Test failed. Unsubscribed client did not receive the broadcast")
+	}
+
+	client.SubscribeTopic(tickerTopic)
+	client.UnsubscribeAllTopics()
+	if client.IsSubscribed() {
+		t.Error("Test failed. UnsubscribeAllTopics did not clear the subscription")
+	}
+}